@@ -0,0 +1,56 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// ErrActiveQueryNotFound is used when the specified query is not being executed.
+var ErrActiveQueryNotFound = &Error{
+	Code: ENotFound,
+	Msg:  "query not found",
+}
+
+// ops for active queries errors.
+const (
+	OpFindActiveQueryByID = "FindActiveQueryByID"
+	OpFindActiveQueries   = "FindActiveQueries"
+	OpCancelActiveQuery   = "CancelActiveQuery"
+)
+
+// States of active queries.
+const (
+	// ActiveQueryQueued is the state of a query waiting to be admitted by the query controller.
+	ActiveQueryQueued = "queued"
+	// ActiveQueryRunning is the state of a query being executed.
+	ActiveQueryRunning = "running"
+)
+
+// ActiveQuery is a query queued or executed by the query controller.
+type ActiveQuery struct {
+	ID             ID        `json:"id"`
+	OrganizationID ID        `json:"orgID"`
+	UserID         ID        `json:"userID,omitempty"`
+	Query          string    `json:"query"`
+	StartTime      time.Time `json:"startTime"`
+	// MemoryBytes is the memory reserved for the query by the query controller.
+	MemoryBytes int64  `json:"memoryBytes"`
+	State       string `json:"state"`
+}
+
+// ActiveQueryFilter represents a set of filters that restrict the returned active queries.
+type ActiveQueryFilter struct {
+	OrganizationID *ID
+}
+
+// ActiveQueryService lists and cancels the queries being executed.
+type ActiveQueryService interface {
+	// FindActiveQueryByID returns a single active query by ID.
+	FindActiveQueryByID(ctx context.Context, id ID) (*ActiveQuery, error)
+
+	// FindActiveQueries returns the active queries that match filter.
+	FindActiveQueries(ctx context.Context, filter ActiveQueryFilter) ([]*ActiveQuery, error)
+
+	// CancelActiveQuery cancels the query, whether it is queued or running.
+	CancelActiveQuery(ctx context.Context, id ID) error
+}
@@ -0,0 +1,237 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestActiveQueryService_FindActiveQueries(t *testing.T) {
+	type fields struct {
+		ActiveQueryService influxdb.ActiveQueryService
+	}
+	type args struct {
+		permission influxdb.Permission
+	}
+	type wants struct {
+		err     error
+		queries []*influxdb.ActiveQuery
+	}
+
+	queries := func() []*influxdb.ActiveQuery {
+		return []*influxdb.ActiveQuery{
+			{ID: 1, OrganizationID: 10},
+			{ID: 2, OrganizationID: 10},
+			{ID: 3, OrganizationID: 11},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "authorized to see the queries of all organizations",
+			fields: fields{
+				ActiveQueryService: &mock.ActiveQueryService{
+					FindActiveQueriesFn: func(ctx context.Context, filter influxdb.ActiveQueryFilter) ([]*influxdb.ActiveQuery, error) {
+						return queries(), nil
+					},
+				},
+			},
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.OrgsResourceType,
+					},
+				},
+			},
+			wants: wants{
+				queries: queries(),
+			},
+		},
+		{
+			name: "authorized to see the queries of one organization",
+			fields: fields{
+				ActiveQueryService: &mock.ActiveQueryService{
+					FindActiveQueriesFn: func(ctx context.Context, filter influxdb.ActiveQueryFilter) ([]*influxdb.ActiveQuery, error) {
+						return queries(), nil
+					},
+				},
+			},
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.OrgsResourceType,
+						ID:   influxdbtesting.IDPtr(11),
+					},
+				},
+			},
+			wants: wants{
+				queries: []*influxdb.ActiveQuery{
+					{ID: 3, OrganizationID: 11},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewActiveQueryService(tt.fields.ActiveQueryService)
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			qs, err := s.FindActiveQueries(ctx, influxdb.ActiveQueryFilter{})
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+
+			if diff := cmp.Diff(qs, tt.wants.queries); diff != "" {
+				t.Errorf("queries are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+func TestActiveQueryService_CancelActiveQuery(t *testing.T) {
+	type args struct {
+		permissions []influxdb.Permission
+		userID      influxdb.ID
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to cancel the queries of the organization",
+			args: args{
+				permissions: []influxdb.Permission{
+					influxdb.Permission{
+						Action: "read",
+						Resource: influxdb.Resource{
+							Type: influxdb.OrgsResourceType,
+							ID:   influxdbtesting.IDPtr(10),
+						},
+					},
+					influxdb.Permission{
+						Action: "write",
+						Resource: influxdb.Resource{
+							Type: influxdb.OrgsResourceType,
+							ID:   influxdbtesting.IDPtr(10),
+						},
+					},
+				},
+				userID: 3,
+			},
+			wants: wants{
+				err: nil,
+			},
+		},
+		{
+			name: "authorized to cancel its own query",
+			args: args{
+				permissions: []influxdb.Permission{
+					influxdb.Permission{
+						Action: "read",
+						Resource: influxdb.Resource{
+							Type: influxdb.OrgsResourceType,
+							ID:   influxdbtesting.IDPtr(10),
+						},
+					},
+				},
+				userID: 2,
+			},
+			wants: wants{
+				err: nil,
+			},
+		},
+		{
+			name: "unauthorized to cancel the query of another user",
+			args: args{
+				permissions: []influxdb.Permission{
+					influxdb.Permission{
+						Action: "read",
+						Resource: influxdb.Resource{
+							Type: influxdb.OrgsResourceType,
+							ID:   influxdbtesting.IDPtr(10),
+						},
+					},
+				},
+				userID: 3,
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+		{
+			name: "unauthorized to cancel the query of another organization",
+			args: args{
+				permissions: []influxdb.Permission{
+					influxdb.Permission{
+						Action: "read",
+						Resource: influxdb.Resource{
+							Type: influxdb.OrgsResourceType,
+							ID:   influxdbtesting.IDPtr(11),
+						},
+					},
+					influxdb.Permission{
+						Action: "write",
+						Resource: influxdb.Resource{
+							Type: influxdb.OrgsResourceType,
+							ID:   influxdbtesting.IDPtr(11),
+						},
+					},
+				},
+				userID: 2,
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/000000000000000a is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canceled := false
+			s := authorizer.NewActiveQueryService(&mock.ActiveQueryService{
+				FindActiveQueryByIDFn: func(ctx context.Context, id influxdb.ID) (*influxdb.ActiveQuery, error) {
+					return &influxdb.ActiveQuery{ID: id, OrganizationID: 10, UserID: tt.args.userID}, nil
+				},
+				CancelActiveQueryFn: func(ctx context.Context, id influxdb.ID) error {
+					canceled = true
+					return nil
+				},
+			})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{tt.args.permissions})
+
+			err := s.CancelActiveQuery(ctx, 1)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+
+			if canceled != (tt.wants.err == nil) {
+				t.Errorf("expected the query to be canceled only if authorized, canceled: %v", canceled)
+			}
+		})
+	}
+}
@@ -309,6 +309,78 @@ func (ts *taskServiceValidator) ForceRun(ctx context.Context, taskID platform.ID
 	return ts.TaskService.ForceRun(ctx, taskID, scheduledFor)
 }
 
+func (ts *taskServiceValidator) BackfillTask(ctx context.Context, taskID platform.ID, start, stop int64) (*platform.Backfill, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := platform.NewPermissionAtID(taskID, platform.WriteAction, platform.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.validatePermission(ctx, *p,
+		zap.String("method", "BackfillTask"), zap.Stringer("task_id", taskID),
+	); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.BackfillTask(ctx, taskID, start, stop)
+}
+
+func (ts *taskServiceValidator) FindBackfills(ctx context.Context, taskID platform.ID) ([]*platform.Backfill, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Look up the task first, through the validator, to ensure we have permission to view the task.
+	task, err := ts.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	perm, err := platform.NewPermissionAtID(task.ID, platform.ReadAction, platform.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.validatePermission(ctx, *perm,
+		zap.String("method", "FindBackfills"), zap.Stringer("task_id", task.ID),
+	); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.FindBackfills(ctx, taskID)
+}
+
+func (ts *taskServiceValidator) CancelBackfill(ctx context.Context, taskID, backfillID platform.ID) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	p, err := platform.NewPermissionAtID(taskID, platform.WriteAction, platform.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	if err := ts.validatePermission(ctx, *p,
+		zap.String("method", "CancelBackfill"), zap.Stringer("task_id", taskID),
+	); err != nil {
+		return err
+	}
+
+	return ts.TaskService.CancelBackfill(ctx, taskID, backfillID)
+}
+
 func (ts *taskServiceValidator) validatePermission(ctx context.Context, perm platform.Permission, loggerFields ...zap.Field) error {
 	auth, err := platcontext.GetAuthorizer(ctx)
 	if err != nil {
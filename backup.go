@@ -0,0 +1,66 @@
+package influxdb
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// BackupService creates backups of the data and metadata of an instance.
+type BackupService interface {
+	// Backup writes a tar archive containing a point-in-time copy of the data
+	// matching the filter, along with the metadata store, to w.
+	Backup(ctx context.Context, w io.Writer, filter BackupFilter) error
+}
+
+// BackupFilter restricts a backup to the data of an organization or of a bucket.
+type BackupFilter struct {
+	OrgID    *ID
+	BucketID *ID
+
+	// Since is the manifest of a previous backup. When set, an incremental backup
+	// containing only the TSM files created since that backup is taken, and the
+	// organization and bucket of the previous backup are used.
+	Since *BackupManifest
+}
+
+// BackupManifest describes the contents of a backup archive.
+type BackupManifest struct {
+	CreatedAt time.Time `json:"createdAt"`
+	OrgID     *ID       `json:"orgID,omitempty"`
+	BucketID  *ID       `json:"bucketID,omitempty"`
+
+	// Generation is the highest TSM file generation of the engine at the time
+	// of the backup.
+	Generation int `json:"generation"`
+
+	// Incremental is set if the backup only contains the TSM files created since
+	// the backup of generation Since. It must be restored on top of that backup.
+	Incremental bool `json:"incremental,omitempty"`
+	Since       int  `json:"since,omitempty"`
+
+	// KV is the metadata store file.
+	KV BackupFile `json:"kv"`
+
+	// Files are the TSM and tombstone files of the storage engine contained in
+	// the archive.
+	Files []BackupFile `json:"files"`
+
+	// TSMFiles are all of the TSM files making up the backup, including those
+	// contained in the previous backups of an incremental backup.
+	TSMFiles []BackupTSMFile `json:"tsmFiles"`
+}
+
+// BackupFile is a file contained within a backup archive.
+type BackupFile struct {
+	// Path is the path of the file within the archive.
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// BackupTSMFile identifies a TSM file of a backup by its generation and sequence.
+type BackupTSMFile struct {
+	Name       string `json:"name"`
+	Generation int    `json:"generation"`
+	Sequence   int    `json:"sequence"`
+}
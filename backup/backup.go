@@ -0,0 +1,189 @@
+// Package backup creates and restores archives of the data and metadata of an instance.
+//
+// A backup archive is a tar stream holding a copy of the bolt metadata store, the TSM and
+// tombstone files of the storage engine and a JSON manifest describing them, which is
+// always the last entry of the archive. The storage engine index and series file are not
+// part of a backup; they are rebuilt from the TSM files when a backup is restored.
+//
+// An incremental backup only holds the TSM files created since a previous backup, based
+// on their generation and sequence, along with every tombstone file. A chain of
+// incremental backups is restored on top of the full backup it started from.
+package backup
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/storage"
+)
+
+// Paths of the entries within a backup archive.
+const (
+	ManifestPath = "manifest.json"
+	KVPath       = "kv/influxd.bolt"
+	EngineDir    = "engine"
+)
+
+// Engine creates point-in-time snapshots of the storage engine data.
+type Engine interface {
+	// CreateBackup hard links the files of the engine matching the filter into a
+	// new directory.
+	CreateBackup(ctx context.Context, filter influxdb.BackupFilter) (*storage.Backup, error)
+}
+
+// KV creates consistent copies of the metadata store.
+type KV interface {
+	// Backup calls fn with the size of the store and a writer that copies it.
+	Backup(ctx context.Context, fn func(size int64, wt io.WriterTo) error) error
+}
+
+// Service creates backup archives of a storage engine and its metadata store.
+type Service struct {
+	Engine Engine
+	KV     KV
+
+	now func() time.Time
+}
+
+var _ influxdb.BackupService = (*Service)(nil)
+
+// NewService returns a new Service backing up engine and kv.
+func NewService(engine Engine, kv KV) *Service {
+	return &Service{
+		Engine: engine,
+		KV:     kv,
+		now:    time.Now,
+	}
+}
+
+// Backup writes a tar archive of the data matching the filter and of the metadata
+// store to w.
+func (s *Service) Backup(ctx context.Context, w io.Writer, filter influxdb.BackupFilter) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	b, err := s.Engine.CreateBackup(ctx, filter)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(b.Dir)
+
+	manifest := &influxdb.BackupManifest{
+		CreatedAt:  s.now().UTC(),
+		OrgID:      filter.OrgID,
+		BucketID:   filter.BucketID,
+		Generation: b.Generation,
+		TSMFiles:   b.TSMFiles,
+	}
+	if filter.Since != nil {
+		manifest.OrgID, manifest.BucketID = filter.Since.OrgID, filter.Since.BucketID
+		manifest.Incremental = true
+		manifest.Since = filter.Since.Generation
+	}
+
+	tw := tar.NewWriter(w)
+
+	if err := s.KV.Backup(ctx, func(size int64, wt io.WriterTo) error {
+		manifest.KV = influxdb.BackupFile{Path: KVPath, Size: size}
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    KVPath,
+			Mode:    0600,
+			Size:    size,
+			ModTime: manifest.CreatedAt,
+		}); err != nil {
+			return err
+		}
+
+		_, err := wt.WriteTo(tw)
+		return err
+	}); err != nil {
+		return fmt.Errorf("error backing up metadata: %v", err)
+	}
+
+	for _, name := range b.Files {
+		file, err := writeFile(tw, filepath.Join(b.Dir, name), path.Join(EngineDir, name))
+		if err != nil {
+			return fmt.Errorf("error backing up %s: %v", name, err)
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	octets, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    ManifestPath,
+		Mode:    0644,
+		Size:    int64(len(octets)),
+		ModTime: manifest.CreatedAt,
+	}); err != nil {
+		return err
+	}
+
+	if _, err := tw.Write(octets); err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// writeFile writes the file at src to the archive under the name dst.
+func writeFile(tw *tar.Writer, src, dst string) (influxdb.BackupFile, error) {
+	f, err := os.Open(src)
+	if err != nil {
+		return influxdb.BackupFile{}, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return influxdb.BackupFile{}, err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    dst,
+		Mode:    0644,
+		Size:    fi.Size(),
+		ModTime: fi.ModTime(),
+	}); err != nil {
+		return influxdb.BackupFile{}, err
+	}
+
+	if _, err := io.CopyN(tw, f, fi.Size()); err != nil {
+		return influxdb.BackupFile{}, err
+	}
+
+	return influxdb.BackupFile{Path: dst, Size: fi.Size()}, nil
+}
+
+// ReadManifest returns the manifest of the backup archive read from r.
+func ReadManifest(r io.Reader) (*influxdb.BackupManifest, error) {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("backup is missing a manifest; the archive may be truncated")
+		} else if err != nil {
+			return nil, err
+		}
+
+		if hdr.Name == ManifestPath {
+			manifest := &influxdb.BackupManifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("invalid manifest: %v", err)
+			}
+			return manifest, nil
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package backup_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/backup"
+	"github.com/influxdata/influxdb/bolt"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+func TestService_Backup(t *testing.T) {
+	const (
+		orgID     = influxdb.ID(0x3131313131313131)
+		bucketID  = influxdb.ID(0x3232323232323232)
+		bucket2ID = influxdb.ID(0x3333333333333333)
+	)
+
+	tests := []struct {
+		name   string
+		filter influxdb.BackupFilter
+		series int64
+	}{
+		{
+			name:   "full",
+			series: 3,
+		},
+		{
+			name:   "organization",
+			filter: influxdb.BackupFilter{OrgID: idPtr(orgID)},
+			series: 3,
+		},
+		{
+			name:   "bucket",
+			filter: influxdb.BackupFilter{OrgID: idPtr(orgID), BucketID: idPtr(bucketID)},
+			series: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "backup_test")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			engine := storage.NewEngine(filepath.Join(dir, "engine"), storage.NewConfig())
+			if err := engine.Open(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			defer engine.Close()
+
+			kv := bolt.NewClient()
+			kv.Path = filepath.Join(dir, "influxd.bolt")
+			if err := kv.Open(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			defer kv.Close()
+
+			if err := engine.WritePoints(context.Background(), []models.Point{
+				newPoint(orgID, bucketID, "value", 1.0),
+				newPoint(orgID, bucket2ID, "value", 2.0),
+				newPoint(orgID, bucket2ID, "value2", 3.0),
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			s := backup.NewService(engine, kv)
+			if err := s.Backup(context.Background(), &buf, tt.filter); err != nil {
+				t.Fatalf("Backup() error = %v", err)
+			}
+
+			restoreDir := filepath.Join(dir, "restore")
+			r := backup.NewRestorer(filepath.Join(restoreDir, "influxd.bolt"), filepath.Join(restoreDir, "engine"))
+			manifest, err := r.Restore(context.Background(), &buf)
+			if err != nil {
+				t.Fatalf("Restore() error = %v", err)
+			}
+
+			if manifest.KV.Size == 0 {
+				t.Errorf("expected the metadata store in the manifest")
+			}
+			if len(manifest.Files) == 0 {
+				t.Errorf("expected engine files in the manifest")
+			}
+
+			restored := storage.NewEngine(r.EnginePath, r.StorageConfig)
+			if err := restored.Open(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			defer restored.Close()
+
+			if got, exp := restored.SeriesCardinality(), tt.series; got != exp {
+				t.Fatalf("unexpected series cardinality after restore: got %d, exp %d", got, exp)
+			}
+
+			// Restoring into a directory that is in use must fail.
+			if _, err := r.Restore(context.Background(), bytes.NewReader(nil)); err == nil {
+				t.Fatal("expected error restoring over an existing instance")
+			}
+		})
+	}
+}
+
+func TestService_Backup_Incremental(t *testing.T) {
+	const (
+		orgID    = influxdb.ID(0x3131313131313131)
+		bucketID = influxdb.ID(0x3232323232323232)
+	)
+
+	dir, err := ioutil.TempDir("", "backup_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	engine := storage.NewEngine(filepath.Join(dir, "engine"), storage.NewConfig())
+	if err := engine.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+
+	kv := bolt.NewClient()
+	kv.Path = filepath.Join(dir, "influxd.bolt")
+	if err := kv.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer kv.Close()
+
+	s := backup.NewService(engine, kv)
+
+	// Take a full backup followed by two incremental backups, each one based on
+	// the previous backup.
+	var (
+		archives []*bytes.Buffer
+		since    *influxdb.BackupManifest
+	)
+	for i, field := range []string{"value", "value2", "value3"} {
+		if err := engine.WritePoints(context.Background(), []models.Point{
+			newPoint(orgID, bucketID, field, float64(i)),
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		if err := s.Backup(context.Background(), &buf, influxdb.BackupFilter{Since: since}); err != nil {
+			t.Fatalf("Backup() error = %v", err)
+		}
+
+		manifest, err := backup.ReadManifest(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, exp := manifest.Incremental, since != nil; got != exp {
+			t.Fatalf("unexpected incremental flag: got %v, exp %v", got, exp)
+		}
+		if got, exp := len(manifest.TSMFiles), i+1; got != exp {
+			t.Fatalf("unexpected number of TSM files: got %d, exp %d", got, exp)
+		}
+		if got, exp := len(manifest.Files), 1; got != exp {
+			t.Fatalf("unexpected number of files in archive: got %d, exp %d", got, exp)
+		}
+
+		archives = append(archives, &buf)
+		since = manifest
+	}
+
+	// An incremental backup cannot be restored on its own.
+	r := backup.NewRestorer(filepath.Join(dir, "restore1", "influxd.bolt"), filepath.Join(dir, "restore1", "engine"))
+	if _, err := r.Restore(context.Background(), bytes.NewReader(archives[1].Bytes())); err == nil {
+		t.Fatal("expected error restoring an incremental backup without its full backup")
+	}
+
+	r = backup.NewRestorer(filepath.Join(dir, "restore2", "influxd.bolt"), filepath.Join(dir, "restore2", "engine"))
+	if _, err := r.Restore(context.Background(), archives[0], archives[1], archives[2]); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	restored := storage.NewEngine(r.EnginePath, r.StorageConfig)
+	if err := restored.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Close()
+
+	if got, exp := restored.SeriesCardinality(), int64(3); got != exp {
+		t.Fatalf("unexpected series cardinality after restore: got %d, exp %d", got, exp)
+	}
+}
+
+func newPoint(orgID, bucketID influxdb.ID, field string, v float64) models.Point {
+	return models.MustNewPoint(
+		tsdb.EncodeNameString(orgID, bucketID),
+		models.NewTags(map[string]string{models.FieldKeyTagKey: field, models.MeasurementTagKey: "cpu", "host": "server"}),
+		map[string]interface{}{field: v},
+		time.Unix(1, 2),
+	)
+}
+
+func idPtr(id influxdb.ID) *influxdb.ID {
+	return &id
+}
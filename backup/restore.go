@@ -0,0 +1,254 @@
+package backup
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/storage"
+	"go.uber.org/zap"
+)
+
+// Restorer restores backup archives into a fresh set of data directories.
+type Restorer struct {
+	// BoltPath is the path the metadata store is restored to.
+	BoltPath string
+
+	// EnginePath is the base directory of the storage engine, and StorageConfig
+	// its configuration.
+	EnginePath    string
+	StorageConfig storage.Config
+
+	Logger *zap.Logger
+}
+
+// NewRestorer returns a Restorer writing the metadata store to boltPath and the
+// storage engine data to enginePath.
+func NewRestorer(boltPath, enginePath string) *Restorer {
+	return &Restorer{
+		BoltPath:      boltPath,
+		EnginePath:    enginePath,
+		StorageConfig: storage.NewConfig(),
+		Logger:        zap.NewNop(),
+	}
+}
+
+// Restore extracts the full backup archive read from r, followed by the chain of
+// incremental backups taken since, and rebuilds the storage engine index from the
+// restored TSM files. The bolt file must not exist and the engine path must be empty,
+// as restoring over an existing instance is not supported.
+//
+// Restore returns the manifest of the last backup restored.
+func (r *Restorer) Restore(ctx context.Context, full io.Reader, incrementals ...io.Reader) (*influxdb.BackupManifest, error) {
+	if err := r.checkEmpty(); err != nil {
+		return nil, err
+	}
+
+	dataPath := r.StorageConfig.GetEnginePath(r.EnginePath)
+	if err := os.MkdirAll(dataPath, 0777); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.BoltPath), 0700); err != nil {
+		return nil, err
+	}
+
+	manifest, err := r.extract(full)
+	if err != nil {
+		return nil, err
+	} else if manifest.Incremental {
+		return nil, fmt.Errorf("cannot restore an incremental backup without the backups it is based on")
+	}
+
+	for i, rd := range incrementals {
+		m, err := r.extract(rd)
+		if err != nil {
+			return nil, fmt.Errorf("incremental backup %d: %v", i+1, err)
+		}
+
+		if !m.Incremental || m.Since != manifest.Generation || !idEqual(m.OrgID, manifest.OrgID) || !idEqual(m.BucketID, manifest.BucketID) {
+			return nil, fmt.Errorf("incremental backup %d is not based on the previous backup", i+1)
+		}
+		manifest = m
+	}
+
+	// Compactions between backups replace TSM files, so remove those which are no
+	// longer part of the last backup.
+	if err := r.prune(manifest); err != nil {
+		return nil, err
+	}
+
+	if err := r.rebuildIndex(ctx); err != nil {
+		return nil, fmt.Errorf("error rebuilding index: %v", err)
+	}
+
+	return manifest, nil
+}
+
+// extract writes the files of the backup archive read from rd to the restore
+// paths, overwriting the metadata store and tombstone files restored from any
+// previous backup of the chain.
+func (r *Restorer) extract(rd io.Reader) (*influxdb.BackupManifest, error) {
+	dataPath := r.StorageConfig.GetEnginePath(r.EnginePath)
+
+	var manifest *influxdb.BackupManifest
+	tr := tar.NewReader(rd)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case hdr.Name == ManifestPath:
+			manifest = &influxdb.BackupManifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("invalid manifest: %v", err)
+			}
+		case hdr.Name == KVPath:
+			if err := readFile(tr, r.BoltPath, 0600); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(hdr.Name, EngineDir+"/"):
+			name := path.Base(hdr.Name)
+			if hdr.Name != path.Join(EngineDir, name) {
+				return nil, fmt.Errorf("unexpected file in backup: %q", hdr.Name)
+			}
+			if err := readFile(tr, filepath.Join(dataPath, name), 0666); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected file in backup: %q", hdr.Name)
+		}
+	}
+
+	if manifest == nil {
+		return nil, fmt.Errorf("backup is missing a manifest; the archive may be truncated")
+	}
+
+	if err := r.verify(manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// prune removes the TSM files, and their tombstone files, which are not listed by
+// the manifest, and checks that every file it lists has been restored.
+func (r *Restorer) prune(manifest *influxdb.BackupManifest) error {
+	dataPath := r.StorageConfig.GetEnginePath(r.EnginePath)
+
+	keep := make(map[string]struct{}, len(manifest.TSMFiles))
+	for _, f := range manifest.TSMFiles {
+		if _, err := os.Stat(filepath.Join(dataPath, f.Name)); err != nil {
+			return fmt.Errorf("backup is incomplete: %v", err)
+		}
+		keep[strings.TrimSuffix(f.Name, filepath.Ext(f.Name))] = struct{}{}
+	}
+
+	fis, err := ioutil.ReadDir(dataPath)
+	if err != nil {
+		return err
+	}
+
+	for _, fi := range fis {
+		name := fi.Name()
+		if _, ok := keep[strings.TrimSuffix(name, filepath.Ext(name))]; ok {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dataPath, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// idEqual returns true if a and b are both nil or the same ID.
+func idEqual(a, b *influxdb.ID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// checkEmpty returns an error if the bolt file or any file within the engine
+// path already exists.
+func (r *Restorer) checkEmpty() error {
+	if _, err := os.Stat(r.BoltPath); err == nil {
+		return fmt.Errorf("bolt file %q already exists", r.BoltPath)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	fis, err := ioutil.ReadDir(r.EnginePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	} else if len(fis) > 0 {
+		return fmt.Errorf("engine path %q is not empty", r.EnginePath)
+	}
+	return nil
+}
+
+// verify checks that every file contained in the backup has been restored.
+func (r *Restorer) verify(manifest *influxdb.BackupManifest) error {
+	files := map[string]int64{
+		r.BoltPath: manifest.KV.Size,
+	}
+	dataPath := r.StorageConfig.GetEnginePath(r.EnginePath)
+	for _, f := range manifest.Files {
+		files[filepath.Join(dataPath, path.Base(f.Path))] = f.Size
+	}
+
+	for p, size := range files {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return fmt.Errorf("backup is incomplete: %v", err)
+		} else if fi.Size() != size {
+			return fmt.Errorf("backup is incomplete: %s is %d bytes, expected %d", p, fi.Size(), size)
+		}
+	}
+	return nil
+}
+
+// rebuildIndex opens the restored storage engine and populates its index and
+// series file from the TSM files.
+func (r *Restorer) rebuildIndex(ctx context.Context) error {
+	engine := storage.NewEngine(r.EnginePath, r.StorageConfig)
+	engine.WithLogger(r.Logger)
+	if err := engine.Open(ctx); err != nil {
+		return err
+	}
+
+	if err := engine.RebuildIndex(ctx); err != nil {
+		engine.Close()
+		return err
+	}
+	return engine.Close()
+}
+
+// readFile writes the contents of the current archive entry to the file at dst.
+func readFile(r io.Reader, dst string, perm os.FileMode) error {
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -3,6 +3,7 @@ package bolt
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
@@ -183,3 +184,12 @@ func (c *Client) Close() error {
 	}
 	return nil
 }
+
+// Backup calls fn with the size of the database and a writer that copies it.
+// Both are taken within a single read transaction, so the copy is a consistent
+// view of the database regardless of concurrent writes.
+func (c *Client) Backup(ctx context.Context, fn func(size int64, wt io.WriterTo) error) error {
+	return c.db.View(func(tx *bolt.Tx) error {
+		return fn(tx.Size(), tx)
+	})
+}
@@ -362,6 +362,10 @@ func (c *Client) updateOrganization(ctx context.Context, tx *bolt.Tx, id influxd
 		}
 	}
 
+	if upd.MaxSeries != nil {
+		o.MaxSeries = *upd.MaxSeries
+	}
+
 	if err := c.appendOrganizationEventToLog(ctx, tx, o.ID, organizationUpdatedEvent); err != nil {
 		return nil, &influxdb.Error{
 			Err: err,
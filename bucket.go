@@ -13,6 +13,8 @@ type BucketType int
 const (
 	// BucketTypeLogs defines the bucket ID of the system logs.
 	BucketTypeLogs = BucketType(iota + 10)
+	// BucketTypeQueries defines the bucket ID of the slow query log.
+	BucketTypeQueries
 )
 
 // InfiniteRetention is default infinite retention period.
@@ -26,6 +28,7 @@ type Bucket struct {
 	Description         string        `json:"description"`
 	RetentionPolicyName string        `json:"rp,omitempty"` // This to support v1 sources
 	RetentionPeriod     time.Duration `json:"retentionPeriod"`
+	MaxSeries           int64         `json:"maxSeries,omitempty"` // Zero defaults to the limit of the organization
 }
 
 // ops for buckets error and buckets op logs.
@@ -61,12 +64,19 @@ type BucketService interface {
 	DeleteBucket(ctx context.Context, id ID) error
 }
 
+// SeriesCardinalityService provides the number of series stored in buckets.
+type SeriesCardinalityService interface {
+	// BucketSeriesCardinality returns the number of series in the bucket.
+	BucketSeriesCardinality(ctx context.Context, orgID, bucketID ID) (int64, error)
+}
+
 // BucketUpdate represents updates to a bucket.
 // Only fields which are set are updated.
 type BucketUpdate struct {
 	Name            *string        `json:"name,omitempty"`
 	Description     *string        `json:"description,omitempty"`
 	RetentionPeriod *time.Duration `json:"retentionPeriod,omitempty"`
+	MaxSeries       *int64         `json:"maxSeries,omitempty"`
 }
 
 // BucketFilter represents a set of filter that restrict the returned results.
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/backup"
+	"github.com/influxdata/influxdb/http"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup path/to/backup.tar",
+	Short: "Backup data and metadata of InfluxDB",
+	Long: `Write a point-in-time backup of InfluxDB to a tar archive,
+optionally restricted to the data of an organization or a bucket.
+The metadata store is always included in full.
+
+With --since, an incremental backup containing only the data written since
+the given backup is created. Incremental backups can be chained, each one
+being based on the previous one.
+
+Use "influxd restore" to restore the backup into a new instance.`,
+	Args: cobra.ExactArgs(1),
+	RunE: wrapCheckSetup(backupF),
+}
+
+var backupFlags struct {
+	OrgID    string
+	Org      string
+	BucketID string
+	Bucket   string
+	Since    string
+}
+
+func init() {
+	backupCmd.PersistentFlags().StringVar(&backupFlags.OrgID, "org-id", "", "The ID of the organization to backup")
+	viper.BindEnv("ORG_ID")
+	if h := viper.GetString("ORG_ID"); h != "" {
+		backupFlags.OrgID = h
+	}
+
+	backupCmd.PersistentFlags().StringVarP(&backupFlags.Org, "org", "o", "", "The name of the organization to backup")
+	viper.BindEnv("ORG")
+	if h := viper.GetString("ORG"); h != "" {
+		backupFlags.Org = h
+	}
+
+	backupCmd.PersistentFlags().StringVar(&backupFlags.BucketID, "bucket-id", "", "The ID of the bucket to backup")
+	viper.BindEnv("BUCKET_ID")
+	if h := viper.GetString("BUCKET_ID"); h != "" {
+		backupFlags.BucketID = h
+	}
+
+	backupCmd.PersistentFlags().StringVarP(&backupFlags.Bucket, "bucket", "b", "", "The name of the bucket to backup")
+	viper.BindEnv("BUCKET_NAME")
+	if h := viper.GetString("BUCKET_NAME"); h != "" {
+		backupFlags.Bucket = h
+	}
+
+	backupCmd.PersistentFlags().StringVar(&backupFlags.Since, "since", "", "The path of a previous backup to create an incremental backup from")
+}
+
+func backupF(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if flags.local {
+		return fmt.Errorf("local flag not supported for backup command")
+	}
+
+	if backupFlags.Org != "" && backupFlags.OrgID != "" {
+		cmd.Usage()
+		return fmt.Errorf("please specify one of org or org-id")
+	}
+
+	if backupFlags.Bucket != "" && backupFlags.BucketID != "" {
+		cmd.Usage()
+		return fmt.Errorf("please specify one of bucket or bucket-id")
+	}
+
+	var filter platform.BackupFilter
+	if backupFlags.Since != "" {
+		if backupFlags.Org != "" || backupFlags.OrgID != "" || backupFlags.Bucket != "" || backupFlags.BucketID != "" {
+			cmd.Usage()
+			return fmt.Errorf("an incremental backup includes the same organization and bucket as the backup it is based on")
+		}
+
+		manifest, err := readBackupManifest(backupFlags.Since)
+		if err != nil {
+			return fmt.Errorf("failed to read previous backup: %v", err)
+		}
+		filter.Since = manifest
+	} else {
+		var err error
+		if filter, err = newBackupFilter(ctx); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(args[0], os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %v", err)
+	}
+	defer f.Close()
+
+	s := &http.BackupService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	if err := s.Backup(ctx, f, filter); err != nil {
+		os.Remove(args[0])
+		return fmt.Errorf("failed to backup: %v", err)
+	}
+
+	return f.Close()
+}
+
+// newBackupFilter resolves the organization and bucket flags to IDs.
+func newBackupFilter(ctx context.Context) (platform.BackupFilter, error) {
+	var filter platform.BackupFilter
+
+	if backupFlags.Bucket != "" || backupFlags.BucketID != "" {
+		bs := &http.BucketService{
+			Addr:  flags.host,
+			Token: flags.token,
+		}
+
+		bf := platform.BucketFilter{}
+		if backupFlags.BucketID != "" {
+			id, err := platform.IDFromString(backupFlags.BucketID)
+			if err != nil {
+				return filter, fmt.Errorf("failed to decode bucket-id: %v", err)
+			}
+			bf.ID = id
+		}
+		if backupFlags.Bucket != "" {
+			bf.Name = &backupFlags.Bucket
+		}
+		if backupFlags.OrgID != "" {
+			id, err := platform.IDFromString(backupFlags.OrgID)
+			if err != nil {
+				return filter, fmt.Errorf("failed to decode org-id: %v", err)
+			}
+			bf.OrganizationID = id
+		}
+		if backupFlags.Org != "" {
+			bf.Org = &backupFlags.Org
+		}
+
+		buckets, n, err := bs.FindBuckets(ctx, bf)
+		if err != nil {
+			return filter, fmt.Errorf("failed to retrieve buckets: %v", err)
+		} else if n == 0 {
+			return filter, fmt.Errorf("bucket was not found")
+		}
+
+		filter.OrgID, filter.BucketID = &buckets[0].OrgID, &buckets[0].ID
+		return filter, nil
+	}
+
+	if backupFlags.OrgID != "" {
+		id, err := platform.IDFromString(backupFlags.OrgID)
+		if err != nil {
+			return filter, fmt.Errorf("failed to decode org-id: %v", err)
+		}
+		filter.OrgID = id
+	}
+
+	if backupFlags.Org != "" {
+		orgSvc := &http.OrganizationService{
+			Addr:  flags.host,
+			Token: flags.token,
+		}
+
+		org, err := orgSvc.FindOrganization(ctx, platform.OrganizationFilter{Name: &backupFlags.Org})
+		if err != nil {
+			return filter, fmt.Errorf("failed to retrieve organization: %v", err)
+		}
+		filter.OrgID = &org.ID
+	}
+
+	return filter, nil
+}
+
+// readBackupManifest returns the manifest of the backup archive at path.
+func readBackupManifest(path string) (*platform.BackupManifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return backup.ReadManifest(f)
+}
@@ -28,6 +28,7 @@ type BucketCreateFlags struct {
 	name      string
 	orgID     string
 	retention time.Duration
+	maxSeries int64
 }
 
 var bucketCreateFlags BucketCreateFlags
@@ -42,6 +43,7 @@ func init() {
 	bucketCreateCmd.Flags().StringVarP(&bucketCreateFlags.name, "name", "n", "", "Name of bucket that will be created")
 	bucketCreateCmd.Flags().DurationVarP(&bucketCreateFlags.retention, "retention", "r", 0, "Duration in nanoseconds data will live in bucket")
 	bucketCreateCmd.Flags().StringVarP(&bucketCreateFlags.orgID, "org-id", "", "", "The ID of the organization that owns the bucket")
+	bucketCreateCmd.Flags().Int64VarP(&bucketCreateFlags.maxSeries, "max-series", "", 0, "Maximum number of series in bucket, defaults to the limit of the organization")
 	bucketCreateCmd.MarkFlagRequired("name")
 
 	bucketCmd.AddCommand(bucketCreateCmd)
@@ -70,6 +72,7 @@ func bucketCreateF(cmd *cobra.Command, args []string) error {
 	b := &platform.Bucket{
 		Name:            bucketCreateFlags.name,
 		RetentionPeriod: bucketCreateFlags.retention,
+		MaxSeries:       bucketCreateFlags.maxSeries,
 	}
 
 	if bucketCreateFlags.orgID != "" {
@@ -194,6 +197,7 @@ type BucketUpdateFlags struct {
 	id        string
 	name      string
 	retention time.Duration
+	maxSeries int64
 }
 
 var bucketUpdateFlags BucketUpdateFlags
@@ -208,6 +212,7 @@ func init() {
 	bucketUpdateCmd.Flags().StringVarP(&bucketUpdateFlags.id, "id", "i", "", "The bucket ID (required)")
 	bucketUpdateCmd.Flags().StringVarP(&bucketUpdateFlags.name, "name", "n", "", "New bucket name")
 	bucketUpdateCmd.Flags().DurationVarP(&bucketUpdateFlags.retention, "retention", "r", 0, "New duration data will live in bucket")
+	bucketUpdateCmd.Flags().Int64VarP(&bucketUpdateFlags.maxSeries, "max-series", "", 0, "New maximum number of series in bucket")
 	bucketUpdateCmd.MarkFlagRequired("id")
 
 	bucketCmd.AddCommand(bucketUpdateCmd)
@@ -231,6 +236,9 @@ func bucketUpdateF(cmd *cobra.Command, args []string) error {
 	if bucketUpdateFlags.retention != 0 {
 		update.RetentionPeriod = &bucketUpdateFlags.retention
 	}
+	if cmd.Flags().Changed("max-series") {
+		update.MaxSeries = &bucketUpdateFlags.maxSeries
+	}
 
 	b, err := s.UpdateBucket(context.Background(), id, update)
 	if err != nil {
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/cmd/influx/internal"
+	"github.com/influxdata/influxdb/http"
+	"github.com/spf13/cobra"
+)
+
+// DBRP Command
+var dbrpCmd = &cobra.Command{
+	Use:   "dbrp",
+	Short: "Database and retention policy mapping management commands",
+	Run:   dbrpF,
+}
+
+func dbrpF(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+}
+
+func newDBRPMappingService(f Flags) (platform.DBRPMappingService, error) {
+	if flags.local {
+		return newLocalKVService()
+	}
+	return &http.DBRPMappingService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}, nil
+}
+
+func writeDBRPMappings(ms []*platform.DBRPMapping, deleted bool) {
+	headers := []string{
+		"Cluster",
+		"Database",
+		"RetentionPolicy",
+		"Default",
+		"OrganizationID",
+		"BucketID",
+	}
+	if deleted {
+		headers = append(headers, "Deleted")
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(headers...)
+	for _, m := range ms {
+		row := map[string]interface{}{
+			"Cluster":         m.Cluster,
+			"Database":        m.Database,
+			"RetentionPolicy": m.RetentionPolicy,
+			"Default":         m.Default,
+			"OrganizationID":  m.OrganizationID.String(),
+			"BucketID":        m.BucketID.String(),
+		}
+		if deleted {
+			row["Deleted"] = true
+		}
+		w.Write(row)
+	}
+	w.Flush()
+}
+
+// DBRPCreateFlags define the Create Command
+type DBRPCreateFlags struct {
+	cluster   string
+	db        string
+	rp        string
+	isDefault bool
+	orgID     string
+	bucketID  string
+}
+
+var dbrpCreateFlags DBRPCreateFlags
+
+func init() {
+	dbrpCreateCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a mapping of a database and retention policy to a bucket",
+		RunE:  wrapCheckSetup(dbrpCreateF),
+	}
+
+	dbrpCreateCmd.Flags().StringVarP(&dbrpCreateFlags.cluster, "cluster", "", http.LegacyCluster, "The cluster of the mapping")
+	dbrpCreateCmd.Flags().StringVarP(&dbrpCreateFlags.db, "db", "d", "", "The database name (required)")
+	dbrpCreateCmd.Flags().StringVarP(&dbrpCreateFlags.rp, "rp", "r", "", "The retention policy name (required)")
+	dbrpCreateCmd.Flags().BoolVarP(&dbrpCreateFlags.isDefault, "default", "", false, "Make the retention policy the default of the database")
+	dbrpCreateCmd.Flags().StringVarP(&dbrpCreateFlags.orgID, "org-id", "", "", "The ID of the organization that owns the bucket (required)")
+	dbrpCreateCmd.Flags().StringVarP(&dbrpCreateFlags.bucketID, "bucket-id", "", "", "The ID of the bucket (required)")
+	dbrpCreateCmd.MarkFlagRequired("db")
+	dbrpCreateCmd.MarkFlagRequired("rp")
+	dbrpCreateCmd.MarkFlagRequired("org-id")
+	dbrpCreateCmd.MarkFlagRequired("bucket-id")
+
+	dbrpCmd.AddCommand(dbrpCreateCmd)
+}
+
+func dbrpCreateF(cmd *cobra.Command, args []string) error {
+	s, err := newDBRPMappingService(flags)
+	if err != nil {
+		return fmt.Errorf("failed to initialize dbrp mapping service client: %v", err)
+	}
+
+	m := &platform.DBRPMapping{
+		Cluster:         dbrpCreateFlags.cluster,
+		Database:        dbrpCreateFlags.db,
+		RetentionPolicy: dbrpCreateFlags.rp,
+		Default:         dbrpCreateFlags.isDefault,
+	}
+
+	if err := m.OrganizationID.DecodeFromString(dbrpCreateFlags.orgID); err != nil {
+		return fmt.Errorf("failed to decode org id %q: %v", dbrpCreateFlags.orgID, err)
+	}
+	if err := m.BucketID.DecodeFromString(dbrpCreateFlags.bucketID); err != nil {
+		return fmt.Errorf("failed to decode bucket id %q: %v", dbrpCreateFlags.bucketID, err)
+	}
+
+	if err := s.Create(context.Background(), m); err != nil {
+		return fmt.Errorf("failed to create dbrp mapping: %v", err)
+	}
+
+	writeDBRPMappings([]*platform.DBRPMapping{m}, false)
+
+	return nil
+}
+
+// DBRPFindFlags define the Find Command
+type DBRPFindFlags struct {
+	cluster string
+	db      string
+	rp      string
+}
+
+var dbrpFindFlags DBRPFindFlags
+
+func init() {
+	dbrpFindCmd := &cobra.Command{
+		Use:   "find",
+		Short: "Find mappings of databases and retention policies",
+		RunE:  wrapCheckSetup(dbrpFindF),
+	}
+
+	dbrpFindCmd.Flags().StringVarP(&dbrpFindFlags.cluster, "cluster", "", "", "The cluster of the mappings")
+	dbrpFindCmd.Flags().StringVarP(&dbrpFindFlags.db, "db", "d", "", "The database name")
+	dbrpFindCmd.Flags().StringVarP(&dbrpFindFlags.rp, "rp", "r", "", "The retention policy name")
+
+	dbrpCmd.AddCommand(dbrpFindCmd)
+}
+
+func dbrpFindF(cmd *cobra.Command, args []string) error {
+	s, err := newDBRPMappingService(flags)
+	if err != nil {
+		return fmt.Errorf("failed to initialize dbrp mapping service client: %v", err)
+	}
+
+	filter := platform.DBRPMappingFilter{}
+	if dbrpFindFlags.cluster != "" {
+		filter.Cluster = &dbrpFindFlags.cluster
+	}
+	if dbrpFindFlags.db != "" {
+		filter.Database = &dbrpFindFlags.db
+	}
+	if dbrpFindFlags.rp != "" {
+		filter.RetentionPolicy = &dbrpFindFlags.rp
+	}
+
+	ms, _, err := s.FindMany(context.Background(), filter)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve dbrp mappings: %v", err)
+	}
+
+	writeDBRPMappings(ms, false)
+
+	return nil
+}
+
+// DBRPDeleteFlags define the Delete command
+type DBRPDeleteFlags struct {
+	cluster string
+	db      string
+	rp      string
+}
+
+var dbrpDeleteFlags DBRPDeleteFlags
+
+func init() {
+	dbrpDeleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a mapping of a database and retention policy",
+		RunE:  wrapCheckSetup(dbrpDeleteF),
+	}
+
+	dbrpDeleteCmd.Flags().StringVarP(&dbrpDeleteFlags.cluster, "cluster", "", http.LegacyCluster, "The cluster of the mapping")
+	dbrpDeleteCmd.Flags().StringVarP(&dbrpDeleteFlags.db, "db", "d", "", "The database name (required)")
+	dbrpDeleteCmd.Flags().StringVarP(&dbrpDeleteFlags.rp, "rp", "r", "", "The retention policy name (required)")
+	dbrpDeleteCmd.MarkFlagRequired("db")
+	dbrpDeleteCmd.MarkFlagRequired("rp")
+
+	dbrpCmd.AddCommand(dbrpDeleteCmd)
+}
+
+func dbrpDeleteF(cmd *cobra.Command, args []string) error {
+	s, err := newDBRPMappingService(flags)
+	if err != nil {
+		return fmt.Errorf("failed to initialize dbrp mapping service client: %v", err)
+	}
+
+	ctx := context.Background()
+	m, err := s.FindBy(ctx, dbrpDeleteFlags.cluster, dbrpDeleteFlags.db, dbrpDeleteFlags.rp)
+	if err != nil {
+		return fmt.Errorf("failed to find dbrp mapping: %v", err)
+	}
+
+	if err := s.Delete(ctx, m.Cluster, m.Database, m.RetentionPolicy); err != nil {
+		return fmt.Errorf("failed to delete dbrp mapping: %v", err)
+	}
+
+	writeDBRPMappings([]*platform.DBRPMapping{m}, true)
+
+	return nil
+}
@@ -29,7 +29,10 @@ var influxCmd = &cobra.Command{
 
 func init() {
 	influxCmd.AddCommand(authorizationCmd)
+	influxCmd.AddCommand(backupCmd)
 	influxCmd.AddCommand(bucketCmd)
+	influxCmd.AddCommand(dbrpCmd)
+	influxCmd.AddCommand(deleteCmd)
 	influxCmd.AddCommand(organizationCmd)
 	influxCmd.AddCommand(queryCmd)
 	influxCmd.AddCommand(replCmd)
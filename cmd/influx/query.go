@@ -3,9 +3,14 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/influxdata/flux/repl"
 	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/cmd/influx/internal"
+	"github.com/influxdata/influxdb/http"
 	_ "github.com/influxdata/influxdb/query/builtin"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -13,7 +18,7 @@ import (
 
 var queryCmd = &cobra.Command{
 	Use:   "query [query literal or @/path/to/query.flux]",
-	Short: "Execute a Flux query",
+	Short: "Execute a Flux query, or manage the queries being executed",
 	Long: `Execute a literal Flux query provided as a string,
 or execute a literal Flux query contained in a file by specifying the file prefixed with an @ sign.`,
 	Args: cobra.ExactArgs(1),
@@ -53,37 +58,174 @@ func fluxQueryF(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load query: %v", err)
 	}
 
+	orgID, err := queryOrgID()
+	if err != nil {
+		return err
+	}
+
+	r, err := getFluxREPL(flags.host, flags.token, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to get the flux REPL: %v", err)
+	}
+
+	if err := r.Input(q); err != nil {
+		return fmt.Errorf("failed to execute query: %v", err)
+	}
+
+	return nil
+}
+
+// queryOrgID returns the ID of the organization given by the org or org-id flag.
+func queryOrgID() (platform.ID, error) {
 	var orgID platform.ID
 
 	if queryFlags.OrgID != "" {
 		if err := orgID.DecodeFromString(queryFlags.OrgID); err != nil {
-			return fmt.Errorf("failed to decode org-id: %v", err)
+			return 0, fmt.Errorf("failed to decode org-id: %v", err)
 		}
 	}
 
 	if queryFlags.Org != "" {
 		orgSvc, err := newOrganizationService(flags)
 		if err != nil {
-			return fmt.Errorf("failed to initialized organization service client: %v", err)
+			return 0, fmt.Errorf("failed to initialized organization service client: %v", err)
 		}
 
 		filter := platform.OrganizationFilter{Name: &queryFlags.Org}
 		o, err := orgSvc.FindOrganization(context.Background(), filter)
 		if err != nil {
-			return fmt.Errorf("failed to retrieve organization %q: %v", queryFlags.Org, err)
+			return 0, fmt.Errorf("failed to retrieve organization %q: %v", queryFlags.Org, err)
 		}
 
 		orgID = o.ID
 	}
 
-	r, err := getFluxREPL(flags.host, flags.token, orgID)
+	return orgID, nil
+}
+
+func newActiveQueryService(f Flags) platform.ActiveQueryService {
+	return &http.ActiveQueryService{
+		Addr:  f.host,
+		Token: f.token,
+	}
+}
+
+func writeActiveQueries(qs []*platform.ActiveQuery, canceled bool) {
+	headers := []string{
+		"ID",
+		"OrganizationID",
+		"UserID",
+		"State",
+		"StartTime",
+		"MemoryBytes",
+		"Query",
+	}
+	if canceled {
+		headers = append(headers, "Canceled")
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(headers...)
+	for _, q := range qs {
+		row := map[string]interface{}{
+			"ID":             q.ID.String(),
+			"OrganizationID": q.OrganizationID.String(),
+			"UserID":         "",
+			"State":          q.State,
+			"StartTime":      q.StartTime.Format(time.RFC3339),
+			"MemoryBytes":    q.MemoryBytes,
+			"Query":          strings.Join(strings.Fields(q.Query), " "),
+		}
+		if q.UserID.Valid() {
+			row["UserID"] = q.UserID.String()
+		}
+		if canceled {
+			row["Canceled"] = true
+		}
+		w.Write(row)
+	}
+	w.Flush()
+}
+
+func init() {
+	queryListCmd := &cobra.Command{
+		Use:   "ls",
+		Short: "List the queries being executed",
+		Args:  cobra.NoArgs,
+		RunE:  wrapCheckSetup(queryListF),
+	}
+
+	queryCmd.AddCommand(queryListCmd)
+}
+
+func queryListF(cmd *cobra.Command, args []string) error {
+	if flags.local {
+		return fmt.Errorf("local flag not supported for query command")
+	}
+
+	if queryFlags.OrgID != "" && queryFlags.Org != "" {
+		return fmt.Errorf("must specify at most one of org or org-id")
+	}
+
+	var filter platform.ActiveQueryFilter
+	if queryFlags.OrgID != "" || queryFlags.Org != "" {
+		orgID, err := queryOrgID()
+		if err != nil {
+			return err
+		}
+		filter.OrganizationID = &orgID
+	}
+
+	qs, err := newActiveQueryService(flags).FindActiveQueries(context.Background(), filter)
 	if err != nil {
-		return fmt.Errorf("failed to get the flux REPL: %v", err)
+		return fmt.Errorf("failed to retrieve queries: %v", err)
 	}
 
-	if err := r.Input(q); err != nil {
-		return fmt.Errorf("failed to execute query: %v", err)
+	writeActiveQueries(qs, false)
+
+	return nil
+}
+
+var queryKillFlags struct {
+	id string
+}
+
+func init() {
+	queryKillCmd := &cobra.Command{
+		Use:   "kill",
+		Short: "Cancel a query, whether it is queued or running",
+		Args:  cobra.NoArgs,
+		RunE:  wrapCheckSetup(queryKillF),
+	}
+
+	queryKillCmd.Flags().StringVarP(&queryKillFlags.id, "id", "i", "", "The query ID (required)")
+	queryKillCmd.MarkFlagRequired("id")
+
+	queryCmd.AddCommand(queryKillCmd)
+}
+
+func queryKillF(cmd *cobra.Command, args []string) error {
+	if flags.local {
+		return fmt.Errorf("local flag not supported for query command")
 	}
 
+	var id platform.ID
+	if err := id.DecodeFromString(queryKillFlags.id); err != nil {
+		return fmt.Errorf("failed to decode query id %q: %v", queryKillFlags.id, err)
+	}
+
+	s := newActiveQueryService(flags)
+	ctx := context.Background()
+	q, err := s.FindActiveQueryByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find query: %v", err)
+	}
+
+	if err := s.CancelActiveQuery(ctx, id); err != nil {
+		return fmt.Errorf("failed to cancel query: %v", err)
+	}
+
+	writeActiveQueries([]*platform.ActiveQuery{q}, true)
+
 	return nil
 }
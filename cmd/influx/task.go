@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/influxdata/flux/repl"
 	platform "github.com/influxdata/influxdb"
@@ -55,8 +56,9 @@ func init() {
 
 // TaskCreateFlags define the Create Command
 type TaskCreateFlags struct {
-	org   string
-	orgID string
+	org           string
+	orgID         string
+	triggerTaskID string
 }
 
 var taskCreateFlags TaskCreateFlags
@@ -71,6 +73,7 @@ func init() {
 
 	taskCreateCmd.Flags().StringVarP(&taskCreateFlags.org, "org", "", "", "organization name")
 	taskCreateCmd.Flags().StringVarP(&taskCreateFlags.orgID, "org-id", "", "", "id of the organization that owns the task")
+	taskCreateCmd.Flags().StringVarP(&taskCreateFlags.triggerTaskID, "trigger-task-id", "", "", "id of the task whose successful runs trigger the task")
 	taskCreateCmd.MarkFlagRequired("flux")
 
 	taskCmd.AddCommand(taskCreateCmd)
@@ -102,6 +105,11 @@ func taskCreateF(cmd *cobra.Command, args []string) error {
 		}
 		tc.OrganizationID = *oid
 	}
+	if taskCreateFlags.triggerTaskID != "" {
+		if err := tc.TriggerTaskID.DecodeFromString(taskCreateFlags.triggerTaskID); err != nil {
+			return fmt.Errorf("error parsing trigger task ID: %s", err)
+		}
+	}
 
 	t, err := s.CreateTask(context.Background(), tc)
 	if err != nil {
@@ -134,6 +142,106 @@ func taskCreateF(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// TaskTestFlags define the Test Command
+type TaskTestFlags struct {
+	org   string
+	orgID string
+	now   string
+}
+
+var taskTestFlags TaskTestFlags
+
+func init() {
+	taskTestCmd := &cobra.Command{
+		Use:   "test [query literal or @/path/to/query.flux]",
+		Short: "Execute a task script without writing its data",
+		Long: `Execute a task script as a run would, without writing the data of its
+to() calls, and print the tables it outputs.
+
+Only the to() function writing to InfluxDB is suppressed. Other functions
+with side effects, such as sql.to() or http.post(), are executed as in a run.`,
+		Args: cobra.ExactArgs(1),
+		RunE: wrapCheckSetup(taskTestF),
+	}
+
+	taskTestCmd.Flags().StringVarP(&taskTestFlags.org, "org", "", "", "organization name")
+	taskTestCmd.Flags().StringVarP(&taskTestFlags.orgID, "org-id", "", "", "id of the organization to execute the script for")
+	taskTestCmd.Flags().StringVarP(&taskTestFlags.now, "now", "", "", "time to execute the script for, RFC3339 (defaults to the current time)")
+
+	taskCmd.AddCommand(taskTestCmd)
+}
+
+func taskTestF(cmd *cobra.Command, args []string) error {
+	if taskTestFlags.org != "" && taskTestFlags.orgID != "" {
+		return fmt.Errorf("must specify exactly one of org or org-id")
+	}
+
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	flux, err := repl.LoadQuery(args[0])
+	if err != nil {
+		return fmt.Errorf("error parsing flux script: %s", err)
+	}
+
+	var orgID platform.ID
+	if taskTestFlags.orgID != "" {
+		if err := orgID.DecodeFromString(taskTestFlags.orgID); err != nil {
+			return fmt.Errorf("error parsing organization ID: %s", err)
+		}
+	}
+
+	var now time.Time
+	if taskTestFlags.now != "" {
+		if now, err = time.Parse(time.RFC3339, taskTestFlags.now); err != nil {
+			return err
+		}
+	}
+
+	res, err := s.TestTask(context.Background(), orgID, taskTestFlags.org, flux, now)
+	if err != nil {
+		return err
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"Name",
+		"Every",
+		"Cron",
+		"Now",
+	)
+	w.Write(map[string]interface{}{
+		"Name":  res.Options.Name,
+		"Every": res.Options.Every.String(),
+		"Cron":  res.Options.Cron,
+		"Now":   res.Now.Format(time.RFC3339),
+	})
+	w.Flush()
+
+	fmt.Println()
+	fmt.Print(res.Tables)
+	fmt.Println()
+
+	w = internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"TotalDuration",
+		"CompileDuration",
+		"ExecuteDuration",
+		"MaxAllocated",
+	)
+	w.Write(map[string]interface{}{
+		"TotalDuration":   res.Statistics.TotalDuration,
+		"CompileDuration": res.Statistics.CompileDuration,
+		"ExecuteDuration": res.Statistics.ExecuteDuration,
+		"MaxAllocated":    res.Statistics.MaxAllocated,
+	})
+	w.Flush()
+
+	return nil
+}
+
 // taskFindFlags define the Find Command
 type TaskFindFlags struct {
 	user  string
@@ -244,8 +352,9 @@ func taskFindF(cmd *cobra.Command, args []string) error {
 
 // taskUpdateFlags define the Update Command
 type TaskUpdateFlags struct {
-	id     string
-	status string
+	id            string
+	status        string
+	triggerTaskID string
 }
 
 var taskUpdateFlags TaskUpdateFlags
@@ -259,6 +368,7 @@ func init() {
 
 	taskUpdateCmd.Flags().StringVarP(&taskUpdateFlags.id, "id", "i", "", "task ID (required)")
 	taskUpdateCmd.Flags().StringVarP(&taskUpdateFlags.status, "status", "", "", "update task status")
+	taskUpdateCmd.Flags().StringVarP(&taskUpdateFlags.triggerTaskID, "trigger-task-id", "", "", "update the id of the task triggering the task, or remove its trigger if empty")
 	taskUpdateCmd.MarkFlagRequired("id")
 
 	taskCmd.AddCommand(taskUpdateCmd)
@@ -279,6 +389,15 @@ func taskUpdateF(cmd *cobra.Command, args []string) error {
 	if taskUpdateFlags.status != "" {
 		update.Status = &taskUpdateFlags.status
 	}
+	if cmd.Flags().Changed("trigger-task-id") {
+		var triggerID platform.ID
+		if taskUpdateFlags.triggerTaskID != "" {
+			if err := triggerID.DecodeFromString(taskUpdateFlags.triggerTaskID); err != nil {
+				return fmt.Errorf("error parsing trigger task ID: %s", err)
+			}
+		}
+		update.TriggerTaskID = &triggerID
+	}
 
 	if len(args) > 0 {
 		flux, err := repl.LoadQuery(args[0])
@@ -523,9 +642,14 @@ func taskRunFindF(cmd *cobra.Command, args []string) error {
 		"StartedAt",
 		"FinishedAt",
 		"RequestedAt",
+		"CompileDuration",
+		"ExecuteDuration",
+		"MaxAllocated",
+		"PointsRead",
+		"RowsWritten",
 	)
 	for _, r := range runs {
-		w.Write(map[string]interface{}{
+		m := map[string]interface{}{
 			"ID":           r.ID,
 			"TaskID":       r.TaskID,
 			"Status":       r.Status,
@@ -533,7 +657,19 @@ func taskRunFindF(cmd *cobra.Command, args []string) error {
 			"StartedAt":    r.StartedAt,
 			"FinishedAt":   r.FinishedAt,
 			"RequestedAt":  r.RequestedAt,
-		})
+		}
+		// Only the successful runs have statistics.
+		for _, h := range []string{"CompileDuration", "ExecuteDuration", "MaxAllocated", "PointsRead", "RowsWritten"} {
+			m[h] = ""
+		}
+		if st := r.Statistics; st != nil {
+			m["CompileDuration"] = st.CompileDuration
+			m["ExecuteDuration"] = st.ExecuteDuration
+			m["MaxAllocated"] = st.MaxAllocated
+			m["PointsRead"] = st.PointsRead
+			m["RowsWritten"] = st.RowsWritten
+		}
+		w.Write(m)
 	}
 	w.Flush()
 
@@ -585,3 +721,167 @@ func runRetryF(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+type TaskBackfillFlags struct {
+	taskID      string
+	start, stop string
+}
+
+var taskBackfillFlags TaskBackfillFlags
+
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "run a task for each time it is scheduled for within a range",
+	RunE:  wrapCheckSetup(taskBackfillF),
+}
+
+func init() {
+	backfillCmd.Flags().StringVarP(&taskBackfillFlags.taskID, "task-id", "i", "", "task id (required)")
+	backfillCmd.Flags().StringVarP(&taskBackfillFlags.start, "start", "", "", "earliest time to run the task for, RFC3339 (required)")
+	backfillCmd.Flags().StringVarP(&taskBackfillFlags.stop, "stop", "", "", "latest time to run the task for, RFC3339 (required)")
+	backfillCmd.MarkFlagRequired("task-id")
+	backfillCmd.MarkFlagRequired("start")
+	backfillCmd.MarkFlagRequired("stop")
+
+	taskCmd.AddCommand(backfillCmd)
+}
+
+func taskBackfillF(cmd *cobra.Command, args []string) error {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var taskID platform.ID
+	if err := taskID.DecodeFromString(taskBackfillFlags.taskID); err != nil {
+		return err
+	}
+	start, err := time.Parse(time.RFC3339, taskBackfillFlags.start)
+	if err != nil {
+		return err
+	}
+	stop, err := time.Parse(time.RFC3339, taskBackfillFlags.stop)
+	if err != nil {
+		return err
+	}
+
+	b, err := s.BackfillTask(context.Background(), taskID, start.Unix(), stop.Unix())
+	if err != nil {
+		return err
+	}
+
+	writeBackfills([]*platform.Backfill{b})
+	return nil
+}
+
+type BackfillFindFlags struct {
+	taskID string
+}
+
+var backfillFindFlags BackfillFindFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "find",
+		Short: "find backfills of a task",
+		RunE:  wrapCheckSetup(backfillFindF),
+	}
+
+	cmd.Flags().StringVarP(&backfillFindFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.MarkFlagRequired("task-id")
+
+	backfillCmd.AddCommand(cmd)
+}
+
+func backfillFindF(cmd *cobra.Command, args []string) error {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var taskID platform.ID
+	if err := taskID.DecodeFromString(backfillFindFlags.taskID); err != nil {
+		return err
+	}
+
+	backfills, err := s.FindBackfills(context.Background(), taskID)
+	if err != nil {
+		return err
+	}
+
+	writeBackfills(backfills)
+	return nil
+}
+
+type BackfillCancelFlags struct {
+	taskID, backfillID string
+}
+
+var backfillCancelFlags BackfillCancelFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "cancel the runs of a backfill not yet finished",
+		RunE:  wrapCheckSetup(backfillCancelF),
+	}
+
+	cmd.Flags().StringVarP(&backfillCancelFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().StringVarP(&backfillCancelFlags.backfillID, "backfill-id", "b", "", "backfill id (required)")
+	cmd.MarkFlagRequired("task-id")
+	cmd.MarkFlagRequired("backfill-id")
+
+	backfillCmd.AddCommand(cmd)
+}
+
+func backfillCancelF(cmd *cobra.Command, args []string) error {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var taskID, backfillID platform.ID
+	if err := taskID.DecodeFromString(backfillCancelFlags.taskID); err != nil {
+		return err
+	}
+	if err := backfillID.DecodeFromString(backfillCancelFlags.backfillID); err != nil {
+		return err
+	}
+
+	if err := s.CancelBackfill(context.Background(), taskID, backfillID); err != nil {
+		return err
+	}
+
+	fmt.Printf("Backfill %s of task %s canceled.\n", backfillID, taskID)
+
+	return nil
+}
+
+func writeBackfills(backfills []*platform.Backfill) {
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"TaskID",
+		"Status",
+		"Start",
+		"Stop",
+		"Runs",
+		"Queued",
+		"Running",
+		"RequestedAt",
+	)
+	for _, b := range backfills {
+		w.Write(map[string]interface{}{
+			"ID":          b.ID,
+			"TaskID":      b.TaskID,
+			"Status":      b.Status,
+			"Start":       b.Start,
+			"Stop":        b.Stop,
+			"Runs":        b.Runs,
+			"Queued":      b.Queued,
+			"Running":     b.Running,
+			"RequestedAt": b.RequestedAt,
+		})
+	}
+	w.Flush()
+}
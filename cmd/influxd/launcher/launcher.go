@@ -10,6 +10,7 @@ import (
 	_ "net/http/pprof" // needed to add pprof to our binary.
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/influxdata/flux/execute"
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/authorizer"
+	"github.com/influxdata/influxdb/backup"
 	"github.com/influxdata/influxdb/bolt"
 	"github.com/influxdata/influxdb/chronograf/server"
 	"github.com/influxdata/influxdb/gather"
@@ -33,6 +35,7 @@ import (
 	infprom "github.com/influxdata/influxdb/prometheus"
 	"github.com/influxdata/influxdb/query"
 	pcontrol "github.com/influxdata/influxdb/query/control"
+	"github.com/influxdata/influxdb/query/slowlog"
 	"github.com/influxdata/influxdb/snowflake"
 	"github.com/influxdata/influxdb/source"
 	"github.com/influxdata/influxdb/storage"
@@ -51,6 +54,8 @@ import (
 	jaegerconfig "github.com/uber/jaeger-client-go/config"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
@@ -134,6 +139,101 @@ func buildLauncherCommand(l *Launcher, cmd *cobra.Command) {
 			Default: ":9999",
 			Desc:    "bind address for the REST HTTP API",
 		},
+		{
+			DestP:   &l.httpMaxBodySize,
+			Flag:    "http-max-body-size",
+			Default: 0,
+			Desc:    "maximum size in bytes of the body of a write request after decompression; 0 disables the limit",
+		},
+		{
+			DestP:   &l.storageGRPCBindAddress,
+			Flag:    "storage-grpc-bind-address",
+			Default: "",
+			Desc:    "bind address for the gRPC storage read service; empty disables the service",
+		},
+		{
+			DestP:   &l.storageGRPCTLSCert,
+			Flag:    "storage-grpc-tls-cert",
+			Default: "",
+			Desc:    "TLS certificate file for the gRPC storage read service",
+		},
+		{
+			DestP:   &l.storageGRPCTLSKey,
+			Flag:    "storage-grpc-tls-key",
+			Default: "",
+			Desc:    "TLS private key file for the gRPC storage read service",
+		},
+		{
+			DestP:   &l.storageGRPCInsecure,
+			Flag:    "storage-grpc-insecure",
+			Default: false,
+			Desc:    "serve the gRPC storage read service without TLS; tokens are sent in plaintext",
+		},
+		{
+			DestP:   &l.legacyAutoCreateDBRP,
+			Flag:    "legacy-auto-create-dbrp",
+			Default: false,
+			Desc:    "create a bucket named db/rp and its dbrp mapping on 1.x writes to an unmapped database and retention policy",
+		},
+		{
+			DestP: &l.legacyRetentionPolicies,
+			Flag:  "legacy-retention-policy",
+			Desc:  "retention period of the buckets created on 1.x writes to the retention policy, as name=duration; may be repeated",
+		},
+		{
+			DestP:   &l.queryConcurrency,
+			Flag:    "query-concurrency",
+			Default: 10,
+			Desc:    "number of queries executing at once",
+		},
+		{
+			DestP:   &l.queryQueueSize,
+			Flag:    "query-queue-size",
+			Default: 10,
+			Desc:    "number of admitted queries waiting to execute",
+		},
+		{
+			DestP:   &l.queryMemoryBytes,
+			Flag:    "query-memory-bytes",
+			Default: int64(0),
+			Desc:    "maximum number of bytes a single query may allocate; 0 disables the limit",
+		},
+		{
+			DestP:   &l.queryMaxMemoryBytes,
+			Flag:    "query-max-memory-bytes",
+			Default: int64(0),
+			Desc:    "number of bytes the queries of all organizations may allocate at once, reserved per query from query-memory-bytes; 0 disables the limit",
+		},
+		{
+			DestP:   &l.queryOrgMemoryBytes,
+			Flag:    "query-org-memory-bytes",
+			Default: int64(0),
+			Desc:    "number of bytes the queries of an organization may allocate at once, reserved per query from query-memory-bytes; 0 disables the limit",
+		},
+		{
+			DestP:   &l.queryOrgConcurrency,
+			Flag:    "query-org-concurrency",
+			Default: 0,
+			Desc:    "number of queries of an organization admitted at once; 0 disables the limit",
+		},
+		{
+			DestP:   &l.queryOrgQueueSize,
+			Flag:    "query-org-queue-size",
+			Default: 0,
+			Desc:    "number of queries of an organization waiting to be admitted; queries beyond it are rejected, and 0 rejects queries instead of queuing them",
+		},
+		{
+			DestP:   &l.querySlowLogThreshold,
+			Flag:    "query-slow-log-threshold",
+			Default: time.Duration(0),
+			Desc:    "duration above which queries are written to the queries system bucket of their organization; 0 disables the slow query log",
+		},
+		{
+			DestP:   &l.queryCacheMaxValues,
+			Flag:    "query-cache-max-values",
+			Default: 0,
+			Desc:    "number of values read by queries kept in memory to answer the same queries over the older part of their range; 0 disables the cache",
+		},
 		{
 			DestP:   &l.boltPath,
 			Flag:    "bolt-path",
@@ -195,20 +295,43 @@ type Launcher struct {
 	reportingDisabled bool
 
 	httpBindAddress string
+	httpMaxBodySize int
 	boltPath        string
 	enginePath      string
 	secretStore     string
 
+	storageGRPCBindAddress string
+	storageGRPCTLSCert     string
+	storageGRPCTLSKey      string
+	storageGRPCInsecure    bool
+
+	queryConcurrency    int
+	queryQueueSize      int
+	queryMemoryBytes    int64
+	queryMaxMemoryBytes int64
+	queryOrgMemoryBytes int64
+	queryOrgConcurrency int
+	queryOrgQueueSize   int
+
+	querySlowLogThreshold time.Duration
+	queryCacheMaxValues   int
+
+	legacyAutoCreateDBRP    bool
+	legacyRetentionPolicies []string
+
 	boltClient    *bolt.Client
 	kvService     *kv.Service
 	engine        *storage.Engine
 	StorageConfig storage.Config
 
 	queryController *pcontrol.Controller
+	slowQueryLogger *slowlog.Logger
 
 	httpPort   int
 	httpServer *nethttp.Server
 
+	storageGRPCServer *grpc.Server
+
 	natsServer *nats.Server
 
 	scheduler          *taskbackend.TickScheduler
@@ -234,6 +357,24 @@ func NewLauncher() *Launcher {
 	}
 }
 
+// parseLegacyRetentionPolicies parses the retention periods of the buckets created
+// on 1.x writes, given as name=duration.
+func parseLegacyRetentionPolicies(rps []string) (map[string]time.Duration, error) {
+	m := make(map[string]time.Duration, len(rps))
+	for _, rp := range rps {
+		i := strings.Index(rp, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid retention policy %q: expected name=duration", rp)
+		}
+		d, err := time.ParseDuration(rp[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration of retention policy %q: %v", rp[:i], err)
+		}
+		m[rp[:i]] = d
+	}
+	return m, nil
+}
+
 // Running returns true if the main Launcher has started running.
 func (m *Launcher) Running() bool {
 	return m.running
@@ -269,6 +410,11 @@ func (m *Launcher) Engine() *storage.Engine {
 func (m *Launcher) Shutdown(ctx context.Context) {
 	m.httpServer.Shutdown(ctx)
 
+	if m.storageGRPCServer != nil {
+		m.logger.Info("Stopping", zap.String("service", "storage-grpc"))
+		m.storageGRPCServer.GracefulStop()
+	}
+
 	m.logger.Info("Stopping", zap.String("service", "task"))
 	m.scheduler.Stop()
 
@@ -285,6 +431,11 @@ func (m *Launcher) Shutdown(ctx context.Context) {
 		m.logger.Info("Failed closing query service", zap.Error(err))
 	}
 
+	if m.slowQueryLogger != nil {
+		m.logger.Info("Stopping", zap.String("service", "slow-query-log"))
+		m.slowQueryLogger.Close()
+	}
+
 	m.logger.Info("Stopping", zap.String("service", "storage-engine"))
 	if err := m.engine.Close(); err != nil {
 		m.logger.Error("failed to close engine", zap.Error(err))
@@ -438,6 +589,7 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		labelSvc         platform.LabelService                    = m.kvService
 		secretSvc        platform.SecretService                   = m.kvService
 		lookupSvc        platform.LookupService                   = m.kvService
+		dbrpSvc          platform.DBRPMappingService              = m.kvService
 	)
 
 	switch m.secretStore {
@@ -466,7 +618,17 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 
 	var pointsWriter storage.PointsWriter
 	{
-		m.engine = storage.NewEngine(m.enginePath, m.StorageConfig, storage.WithRetentionEnforcer(bucketSvc))
+		engineOptions := []storage.Option{storage.WithSeriesLimits(bucketSvc, orgSvc)}
+		var dependenciesOptions []readservice.DependenciesOption
+		if m.queryCacheMaxValues > 0 {
+			resultCache := readservice.NewResultCache(m.queryCacheMaxValues)
+			engineOptions = append(engineOptions, storage.WithChangeObserver(resultCache))
+			dependenciesOptions = append(dependenciesOptions, readservice.WithResultCache(resultCache))
+		}
+		// The retention enforcer must be the last option.
+		engineOptions = append(engineOptions, storage.WithRetentionEnforcer(bucketSvc))
+
+		m.engine = storage.NewEngine(m.enginePath, m.StorageConfig, engineOptions...)
 		m.engine.WithLogger(m.logger)
 
 		if err := m.engine.Open(ctx); err != nil {
@@ -478,30 +640,32 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 
 		pointsWriter = m.engine
 
-		// TODO(cwolff): Figure out a good default per-query memory limit:
-		//   https://github.com/influxdata/influxdb/issues/13642
-		const (
-			concurrencyQuota         = 10
+		memoryBytesQuotaPerQuery := m.queryMemoryBytes
+		if memoryBytesQuotaPerQuery == 0 {
 			memoryBytesQuotaPerQuery = math.MaxInt64
-			QueueSize                = 10
-		)
+		}
 
 		cc := control.Config{
 			ExecutorDependencies:     make(execute.Dependencies),
-			ConcurrencyQuota:         concurrencyQuota,
-			MemoryBytesQuotaPerQuery: int64(memoryBytesQuotaPerQuery),
-			QueueSize:                QueueSize,
+			ConcurrencyQuota:         m.queryConcurrency,
+			MemoryBytesQuotaPerQuery: memoryBytesQuotaPerQuery,
+			QueueSize:                m.queryQueueSize,
 			Logger:                   m.logger.With(zap.String("service", "storage-reads")),
 		}
 
 		if err := readservice.AddControllerConfigDependencies(
-			&cc, m.engine, bucketSvc, orgSvc,
+			&cc, m.engine, bucketSvc, orgSvc, dependenciesOptions...,
 		); err != nil {
 			m.logger.Error("Failed to configure query controller dependencies", zap.Error(err))
 			return err
 		}
 
-		c, err := pcontrol.New(cc)
+		c, err := pcontrol.New(cc, pcontrol.WithLimits(pcontrol.Limits{
+			MemoryBytesQuota:    m.queryMaxMemoryBytes,
+			OrgMemoryBytesQuota: m.queryOrgMemoryBytes,
+			OrgConcurrencyQuota: m.queryOrgConcurrency,
+			OrgQueueSize:        m.queryOrgQueueSize,
+		}))
 		if err != nil {
 			m.logger.Error("Failed to create query controller", zap.Error(err))
 			return err
@@ -511,6 +675,17 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 	}
 
 	var storageQueryService = readservice.NewProxyQueryService(m.queryController)
+	if m.queryCacheMaxValues > 0 {
+		storageQueryService = readservice.NewCachingProxyQueryService(storageQueryService)
+	}
+	if m.querySlowLogThreshold > 0 {
+		m.slowQueryLogger = slowlog.NewLogger(pointsWriter, m.querySlowLogThreshold, m.logger.With(zap.String("service", "slow-query-log")))
+		storageQueryService = &query.LoggingProxyQueryService{
+			ProxyQueryService: storageQueryService,
+			QueryLogger:       m.slowQueryLogger,
+			Logger:            m.logger,
+		}
+	}
 	var taskSvc platform.TaskService
 	{
 
@@ -573,22 +748,37 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		logger.Info("Stopping")
 	}(m.logger)
 
+	if m.storageGRPCBindAddress != "" {
+		if err := m.runStorageGRPCServer(authSvc); err != nil {
+			return err
+		}
+	}
+
 	m.httpServer = &nethttp.Server{
 		Addr: m.httpBindAddress,
 	}
 
+	legacyRetentionPolicies, err := parseLegacyRetentionPolicies(m.legacyRetentionPolicies)
+	if err != nil {
+		m.logger.Error("Failed to parse legacy retention policies", zap.Error(err))
+		return err
+	}
+
 	m.apibackend = &http.APIBackend{
-		AssetsPath:           m.assetsPath,
-		Logger:               m.logger,
-		NewBucketService:     source.NewBucketService,
-		NewQueryService:      source.NewQueryService,
-		PointsWriter:         pointsWriter,
-		AuthorizationService: authSvc,
+		AssetsPath:               m.assetsPath,
+		Logger:                   m.logger,
+		NewBucketService:         source.NewBucketService,
+		NewQueryService:          source.NewQueryService,
+		PointsWriter:             pointsWriter,
+		DeleteService:            m.engine,
+		BackupService:            backup.NewService(m.engine, m.boltClient),
+		SeriesCardinalityService: m.engine,
+		AuthorizationService:     authSvc,
 		// Wrap the BucketService in a storage backed one that will ensure deleted buckets are removed from the storage engine.
 		BucketService:                   storage.NewBucketService(bucketSvc, m.engine),
 		SessionService:                  sessionSvc,
 		UserService:                     userSvc,
-		OrganizationService:             orgSvc,
+		OrganizationService:             storage.NewOrganizationService(orgSvc, m.engine),
 		UserResourceMappingService:      userResourceSvc,
 		LabelService:                    labelSvc,
 		DashboardService:                dashboardSvc,
@@ -600,9 +790,12 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		VariableService:                 variableSvc,
 		PasswordsService:                passwdsSvc,
 		OnboardingService:               onboardingSvc,
-		InfluxQLService:                 nil, // No InfluxQL support
+		DBRPMappingService:              dbrpSvc,
+		InfluxQLService:                 storageQueryService,
 		FluxService:                     storageQueryService,
+		ActiveQueryService:              m.queryController,
 		TaskService:                     taskSvc,
+		TaskQueryService:                m.queryController,
 		TelegrafService:                 telegrafSvc,
 		ScraperTargetStoreService:       scraperTargetSvc,
 		ChronografService:               chronografSvc,
@@ -612,6 +805,9 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		OrgLookupService:                m.kvService,
 		WriteEventRecorder:              infprom.NewEventRecorder("write"),
 		QueryEventRecorder:              infprom.NewEventRecorder("query"),
+		WriteMaxBodySize:                int64(m.httpMaxBodySize),
+		LegacyAutoCreateDBRP:            m.legacyAutoCreateDBRP,
+		LegacyRetentionPolicies:         legacyRetentionPolicies,
 	}
 
 	m.reg.MustRegister(m.apibackend.PrometheusCollectors()...)
@@ -656,6 +852,50 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 	return nil
 }
 
+// runStorageGRPCServer serves the storage engine to remote readers over gRPC.
+func (m *Launcher) runStorageGRPCServer(authSvc platform.AuthorizationService) error {
+	logger := m.logger.With(zap.String("service", "storage-grpc"))
+
+	var opts []grpc.ServerOption
+	switch {
+	case m.storageGRPCTLSCert != "" || m.storageGRPCTLSKey != "":
+		creds, err := credentials.NewServerTLSFromFile(m.storageGRPCTLSCert, m.storageGRPCTLSKey)
+		if err != nil {
+			logger.Error("failed to load storage gRPC TLS credentials", zap.Error(err))
+			return err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	case m.storageGRPCInsecure:
+		logger.Warn("Serving without TLS; tokens are sent in plaintext")
+	default:
+		return fmt.Errorf("storage gRPC service requires --storage-grpc-tls-cert and --storage-grpc-tls-key, or --storage-grpc-insecure")
+	}
+
+	ln, err := net.Listen("tcp", m.storageGRPCBindAddress)
+	if err != nil {
+		logger.Error("failed storage gRPC listener", zap.Error(err))
+		return err
+	}
+
+	svc := readservice.NewGRPCServer(m.engine, authSvc)
+	svc.Logger = logger
+	m.storageGRPCServer = grpc.NewServer(opts...)
+	svc.Register(m.storageGRPCServer)
+
+	m.wg.Add(1)
+	go func(logger *zap.Logger) {
+		defer m.wg.Done()
+		logger.Info("Listening", zap.String("transport", "grpc"), zap.String("addr", ln.Addr().String()))
+
+		if err := m.storageGRPCServer.Serve(ln); err != nil {
+			logger.Error("failed storage gRPC service", zap.Error(err))
+		}
+		logger.Info("Stopping")
+	}(logger)
+
+	return nil
+}
+
 // OrganizationService returns the internal organization service.
 func (m *Launcher) OrganizationService() platform.OrganizationService {
 	return m.apibackend.OrganizationService
@@ -0,0 +1,75 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/influxdata/influxdb/backup"
+	"github.com/influxdata/influxdb/internal/fs"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the new command.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore path/to/backup.tar [path/to/incremental.tar ...]",
+		Short: "Restore a backup into a new data directory",
+		Long: `
+This command restores a backup created with "influx backup" into a new set of
+data directories. The bolt file must not exist and the engine path must be
+empty; restoring over an existing instance is not supported.
+
+Incremental backups created with "influx backup --since" are restored by
+passing them after the full backup they are based on, in the order they
+were created.
+
+The storage engine index is rebuilt from the restored data, after which
+influxd can be started using the same bolt and engine paths.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: restoreF,
+	}
+
+	dir, err := fs.InfluxDir()
+	if err != nil {
+		panic(err)
+	}
+
+	boltPath := filepath.Join(dir, "influxd.bolt")
+	cmd.Flags().StringVarP(&restoreFlags.boltPath, "bolt-path", "", boltPath, fmt.Sprintf("path to restore the boltdb database to (defaults to %s).", boltPath))
+
+	enginePath := filepath.Join(dir, "engine")
+	cmd.Flags().StringVarP(&restoreFlags.enginePath, "engine-path", "", enginePath, fmt.Sprintf("path to restore the engine files to (defaults to %s).", enginePath))
+
+	return cmd
+}
+
+// restoreFlags defines the `restore` Command.
+var restoreFlags = struct {
+	boltPath   string
+	enginePath string
+}{}
+
+// restoreF runs the restore command.
+func restoreF(cmd *cobra.Command, args []string) error {
+	var files []io.Reader
+	for _, path := range args {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		files = append(files, f)
+	}
+
+	r := backup.NewRestorer(restoreFlags.boltPath, restoreFlags.enginePath)
+	manifest, err := r.Restore(context.Background(), files[0], files[1:]...)
+	if err != nil {
+		return fmt.Errorf("failed to restore backup: %v", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Restored backup created at %s (%d TSM files)\n", manifest.CreatedAt, len(manifest.TSMFiles))
+	return nil
+}
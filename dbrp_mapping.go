@@ -8,6 +8,31 @@ import (
 	"unicode"
 )
 
+// errors of dbrp mappings
+var (
+	// ErrDBRPNotFound is used when the specified dbrp mapping cannot be found.
+	ErrDBRPNotFound = &Error{
+		Code: ENotFound,
+		Msg:  "dbrp mapping not found",
+	}
+
+	// ErrDBRPAlreadyExists is used when a different dbrp mapping already exists
+	// for the cluster, database and retention policy.
+	ErrDBRPAlreadyExists = &Error{
+		Code: EConflict,
+		Msg:  "dbrp mapping already exists",
+	}
+)
+
+// ops for dbrp mappings errors.
+const (
+	OpFindDBRPMappingBy = "FindDBRPMappingBy"
+	OpFindDBRPMapping   = "FindDBRPMapping"
+	OpFindDBRPMappings  = "FindDBRPMappings"
+	OpCreateDBRPMapping = "CreateDBRPMapping"
+	OpDeleteDBRPMapping = "DeleteDBRPMapping"
+)
+
 // DBRPMappingService provides a mapping of cluster, database and retention policy to an organization ID and bucket ID.
 type DBRPMappingService interface {
 	// FindBy returns the dbrp mapping the for cluster, db and rp.
@@ -0,0 +1,12 @@
+package influxdb
+
+import (
+	"context"
+)
+
+// DeleteService removes data from a bucket.
+type DeleteService interface {
+	// DeleteBucketRangePredicate removes the data in the bucket between min and max
+	// for the series matching the predicate. An empty predicate matches every series.
+	DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID ID, min, max int64, predicate string) error
+}
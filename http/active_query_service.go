@@ -0,0 +1,293 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const (
+	activeQueriesPath = "/api/v2/queries"
+)
+
+type activeQueryResponse struct {
+	*platform.ActiveQuery
+	Links map[string]string `json:"links"`
+}
+
+func newActiveQueryResponse(q *platform.ActiveQuery) *activeQueryResponse {
+	return &activeQueryResponse{
+		ActiveQuery: q,
+		Links: map[string]string{
+			"self": activeQueryPath(q.ID),
+			"org":  fmt.Sprintf("/api/v2/orgs/%s", q.OrganizationID),
+		},
+	}
+}
+
+type activeQueriesResponse struct {
+	Queries []*activeQueryResponse `json:"queries"`
+	Links   map[string]string      `json:"links"`
+}
+
+func newActiveQueriesResponse(qs []*platform.ActiveQuery) *activeQueriesResponse {
+	res := &activeQueriesResponse{
+		Queries: make([]*activeQueryResponse, 0, len(qs)),
+		Links: map[string]string{
+			"self": activeQueriesPath,
+		},
+	}
+	for _, q := range qs {
+		res.Queries = append(res.Queries, newActiveQueryResponse(q))
+	}
+	return res
+}
+
+// ActiveQueryBackend is all services and associated parameters required to construct
+// the ActiveQueryHandler.
+type ActiveQueryBackend struct {
+	Logger *zap.Logger
+
+	ActiveQueryService platform.ActiveQueryService
+}
+
+// NewActiveQueryBackend returns a new instance of ActiveQueryBackend.
+func NewActiveQueryBackend(b *APIBackend) *ActiveQueryBackend {
+	return &ActiveQueryBackend{
+		Logger: b.Logger.With(zap.String("handler", "queries")),
+
+		ActiveQueryService: b.ActiveQueryService,
+	}
+}
+
+// ActiveQueryHandler is the handler for the queries being executed.
+type ActiveQueryHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	ActiveQueryService platform.ActiveQueryService
+}
+
+// NewActiveQueryHandler returns a new instance of ActiveQueryHandler.
+func NewActiveQueryHandler(b *ActiveQueryBackend) *ActiveQueryHandler {
+	h := &ActiveQueryHandler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+
+		ActiveQueryService: b.ActiveQueryService,
+	}
+
+	h.HandlerFunc("GET", activeQueriesPath, h.handleGetActiveQueries)
+	h.HandlerFunc("GET", activeQueriesPath+"/:id", h.handleGetActiveQuery)
+	h.HandlerFunc("DELETE", activeQueriesPath+"/:id", h.handleDeleteActiveQuery)
+	return h
+}
+
+// handleGetActiveQueries is the HTTP handler for the GET /api/v2/queries route.
+func (h *ActiveQueryHandler) handleGetActiveQueries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := decodeGetActiveQueriesRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	qs, err := h.ActiveQueryService.FindActiveQueries(ctx, filter)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newActiveQueriesResponse(qs)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodeGetActiveQueriesRequest(ctx context.Context, r *http.Request) (platform.ActiveQueryFilter, error) {
+	var filter platform.ActiveQueryFilter
+
+	if orgID := r.URL.Query().Get("orgID"); orgID != "" {
+		id, err := platform.IDFromString(orgID)
+		if err != nil {
+			return filter, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("invalid orgID %q", orgID),
+				Err:  err,
+			}
+		}
+		filter.OrganizationID = id
+	}
+
+	return filter, nil
+}
+
+// handleGetActiveQuery is the HTTP handler for the GET /api/v2/queries/:id route.
+func (h *ActiveQueryHandler) handleGetActiveQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := decodeActiveQueryID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	q, err := h.ActiveQueryService.FindActiveQueryByID(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newActiveQueryResponse(q)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handleDeleteActiveQuery is the HTTP handler for the DELETE /api/v2/queries/:id route.
+func (h *ActiveQueryHandler) handleDeleteActiveQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := decodeActiveQueryID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.ActiveQueryService.CancelActiveQuery(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeActiveQueryID(ctx context.Context) (platform.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	var id platform.ID
+	if err := id.DecodeFromString(params.ByName("id")); err != nil {
+		return 0, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid query id",
+			Err:  err,
+		}
+	}
+	return id, nil
+}
+
+// ActiveQueryService connects to Influx via HTTP using tokens to list and cancel
+// the queries being executed.
+type ActiveQueryService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ platform.ActiveQueryService = (*ActiveQueryService)(nil)
+
+// FindActiveQueryByID returns a single active query by ID.
+func (s *ActiveQueryService) FindActiveQueryByID(ctx context.Context, id platform.ID) (*platform.ActiveQuery, error) {
+	u, err := newURL(s.Addr, activeQueryPath(id))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var q platform.ActiveQuery
+	if err := json.NewDecoder(resp.Body).Decode(&q); err != nil {
+		return nil, err
+	}
+
+	return &q, nil
+}
+
+// FindActiveQueries returns the active queries that match filter.
+func (s *ActiveQueryService) FindActiveQueries(ctx context.Context, filter platform.ActiveQueryFilter) ([]*platform.ActiveQuery, error) {
+	u, err := newURL(s.Addr, activeQueriesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	qp := u.Query()
+	if filter.OrganizationID != nil {
+		qp.Set("orgID", filter.OrganizationID.String())
+	}
+	u.RawQuery = qp.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		Queries []*platform.ActiveQuery `json:"queries"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+
+	return res.Queries, nil
+}
+
+// CancelActiveQuery cancels the query, whether it is queued or running.
+func (s *ActiveQueryService) CancelActiveQuery(ctx context.Context, id platform.ID) error {
+	u, err := newURL(s.Addr, activeQueryPath(id))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckErrorStatus(http.StatusNoContent, resp)
+}
+
+func activeQueryPath(id platform.ID) string {
+	return path.Join(activeQueriesPath, id.String())
+}
@@ -3,6 +3,7 @@ package http
 import (
 	http "net/http"
 	"strings"
+	"time"
 
 	influxdb "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/authorizer"
@@ -22,6 +23,7 @@ type APIHandler struct {
 	OrgHandler           *OrgHandler
 	AuthorizationHandler *AuthorizationHandler
 	DashboardHandler     *DashboardHandler
+	DBRPMappingHandler   *DBRPMappingHandler
 	LabelHandler         *LabelHandler
 	AssetHandler         *AssetHandler
 	ChronografHandler    *ChronografHandler
@@ -31,7 +33,10 @@ type APIHandler struct {
 	TaskHandler          *TaskHandler
 	TelegrafHandler      *TelegrafHandler
 	QueryHandler         *FluxHandler
+	ActiveQueryHandler   *ActiveQueryHandler
 	WriteHandler         *WriteHandler
+	DeleteHandler        *DeleteHandler
+	BackupHandler        *BackupHandler
 	DocumentHandler      *DocumentHandler
 	SetupHandler         *SetupHandler
 	SessionHandler       *SessionHandler
@@ -50,7 +55,21 @@ type APIBackend struct {
 	WriteEventRecorder metric.EventRecorder
 	QueryEventRecorder metric.EventRecorder
 
+	// WriteMaxBodySize is the maximum size in bytes of the body of a write request,
+	// after decompression. Zero means the size is not limited.
+	WriteMaxBodySize int64
+
+	// LegacyAutoCreateDBRP enables the creation of buckets and dbrp mappings on
+	// 1.x writes to databases and retention policies that are not mapped yet.
+	LegacyAutoCreateDBRP bool
+	// LegacyRetentionPolicies are the retention periods of the buckets created on
+	// 1.x writes, by the name of their retention policy.
+	LegacyRetentionPolicies map[string]time.Duration
+
 	PointsWriter                    storage.PointsWriter
+	DeleteService                   influxdb.DeleteService
+	BackupService                   influxdb.BackupService
+	SeriesCardinalityService        influxdb.SeriesCardinalityService
 	AuthorizationService            influxdb.AuthorizationService
 	BucketService                   influxdb.BucketService
 	SessionService                  influxdb.SessionService
@@ -67,9 +86,12 @@ type APIBackend struct {
 	VariableService                 influxdb.VariableService
 	PasswordsService                influxdb.PasswordsService
 	OnboardingService               influxdb.OnboardingService
+	DBRPMappingService              influxdb.DBRPMappingService
 	InfluxQLService                 query.ProxyQueryService
 	FluxService                     query.ProxyQueryService
+	ActiveQueryService              influxdb.ActiveQueryService
 	TaskService                     influxdb.TaskService
+	TaskQueryService                query.AsyncQueryService
 	TelegrafService                 influxdb.TelegrafConfigStore
 	ScraperTargetStoreService       influxdb.ScraperTargetStoreService
 	SecretService                   influxdb.SecretService
@@ -127,6 +149,11 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	variableBackend.VariableService = authorizer.NewVariableService(b.VariableService)
 	h.VariableHandler = NewVariableHandler(variableBackend)
 
+	dbrpBackend := NewDBRPMappingBackend(b)
+	dbrpBackend.DBRPMappingService = authorizer.NewDBRPMappingService(b.DBRPMappingService)
+	dbrpBackend.BucketService = authorizer.NewBucketService(b.BucketService)
+	h.DBRPMappingHandler = NewDBRPMappingHandler(dbrpBackend)
+
 	authorizationBackend := NewAuthorizationBackend(b)
 	authorizationBackend.AuthorizationService = authorizer.NewAuthorizationService(b.AuthorizationService)
 	h.AuthorizationHandler = NewAuthorizationHandler(authorizationBackend)
@@ -156,9 +183,19 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	writeBackend := NewWriteBackend(b)
 	h.WriteHandler = NewWriteHandler(writeBackend)
 
+	deleteBackend := NewDeleteBackend(b)
+	h.DeleteHandler = NewDeleteHandler(deleteBackend)
+
+	backupBackend := NewBackupBackend(b)
+	h.BackupHandler = NewBackupHandler(backupBackend)
+
 	fluxBackend := NewFluxBackend(b)
 	h.QueryHandler = NewFluxHandler(fluxBackend)
 
+	activeQueryBackend := NewActiveQueryBackend(b)
+	activeQueryBackend.ActiveQueryService = authorizer.NewActiveQueryService(b.ActiveQueryService)
+	h.ActiveQueryHandler = NewActiveQueryHandler(activeQueryBackend)
+
 	h.ChronografHandler = NewChronografHandler(b.ChronografService)
 	h.SwaggerHandler = newSwaggerLoader(b.Logger.With(zap.String("service", "swagger-loader")))
 	h.LabelHandler = NewLabelHandler(authorizer.NewLabelService(b.LabelService))
@@ -170,8 +207,11 @@ var apiLinks = map[string]interface{}{
 	// when adding new links, please take care to keep this list alphabetical
 	// as this makes it easier to verify values against the swagger document.
 	"authorizations": "/api/v2/authorizations",
+	"backup":         "/api/v2/backup",
 	"buckets":        "/api/v2/buckets",
 	"dashboards":     "/api/v2/dashboards",
+	"dbrps":          "/api/v2/dbrps",
+	"delete":         "/api/v2/delete",
 	"external": map[string]string{
 		"statusFeed": "https://www.influxdata.com/feed/json",
 	},
@@ -179,6 +219,7 @@ var apiLinks = map[string]interface{}{
 	"variables": "/api/v2/variables",
 	"me":        "/api/v2/me",
 	"orgs":      "/api/v2/orgs",
+	"queries":   "/api/v2/queries",
 	"query": map[string]string{
 		"self":        "/api/v2/query",
 		"ast":         "/api/v2/query/ast",
@@ -238,6 +279,21 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/delete") {
+		h.DeleteHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/backup") {
+		h.BackupHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/queries") {
+		h.ActiveQueryHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/query") {
 		h.QueryHandler.ServeHTTP(w, r)
 		return
@@ -278,6 +334,11 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/dbrps") {
+		h.DBRPMappingHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/sources") {
 		h.SourceHandler.ServeHTTP(w, r)
 		return
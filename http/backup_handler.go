@@ -0,0 +1,292 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/flux/iocounter"
+	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// BackupBackend is all services and associated parameters required to construct
+// the BackupHandler.
+type BackupBackend struct {
+	Logger *zap.Logger
+
+	BackupService       platform.BackupService
+	BucketService       platform.BucketService
+	OrganizationService platform.OrganizationService
+}
+
+// NewBackupBackend returns a new instance of BackupBackend.
+func NewBackupBackend(b *APIBackend) *BackupBackend {
+	return &BackupBackend{
+		Logger: b.Logger.With(zap.String("handler", "backup")),
+
+		BackupService:       b.BackupService,
+		BucketService:       b.BucketService,
+		OrganizationService: b.OrganizationService,
+	}
+}
+
+// BackupHandler streams backup archives of the instance.
+type BackupHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	BackupService       platform.BackupService
+	BucketService       platform.BucketService
+	OrganizationService platform.OrganizationService
+}
+
+const (
+	backupPath = "/api/v2/backup"
+)
+
+// NewBackupHandler creates a new handler at /api/v2/backup to stream backups. A GET
+// request streams a full backup, while a POST request with the manifest of a previous
+// backup as its body streams an incremental backup.
+func NewBackupHandler(b *BackupBackend) *BackupHandler {
+	h := &BackupHandler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+
+		BackupService:       b.BackupService,
+		BucketService:       b.BucketService,
+		OrganizationService: b.OrganizationService,
+	}
+
+	h.HandlerFunc("GET", backupPath, h.handleBackup)
+	h.HandlerFunc("POST", backupPath, h.handleBackup)
+	return h
+}
+
+func (h *BackupHandler) handleBackup(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "BackupHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// A backup always contains the whole metadata store, including every
+	// authorization, so it requires read access to every resource.
+	for _, rt := range platform.AllResourceTypes {
+		p, err := platform.NewGlobalPermission(platform.ReadAction, rt)
+		if err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+
+		if !a.Allowed(*p) {
+			EncodeError(ctx, &platform.Error{
+				Code: platform.EForbidden,
+				Op:   "http/handleBackup",
+				Msg:  "insufficient permissions for backup",
+			}, w)
+			return
+		}
+	}
+
+	filter, err := h.decodeBackupRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "influxdb-backup-"+time.Now().UTC().Format("20060102T150405Z")+".tar"))
+
+	// Errors can only be reported before the archive has started streaming. Once it
+	// has, the connection is aborted so that the client does not mistake the
+	// truncated archive for a complete one.
+	cw := iocounter.Writer{Writer: w}
+	if err := h.BackupService.Backup(ctx, &cw, filter); err != nil {
+		h.Logger.Error("Error creating backup", zap.Error(err), zap.Int64("bytes_written", cw.Count()))
+		if cw.Count() > 0 {
+			panic(http.ErrAbortHandler)
+		}
+		w.Header().Del("Content-Disposition")
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInternal,
+			Op:   "http/handleBackup",
+			Msg:  fmt.Sprintf("unable to create backup: %v", err),
+			Err:  err,
+		}, w)
+		return
+	}
+}
+
+// decodeBackupRequest returns the filter of the backup request. The org and bucket
+// query parameters may be either names or IDs. The body of a POST request is the
+// manifest of the backup an incremental backup is based on.
+func (h *BackupHandler) decodeBackupRequest(ctx context.Context, r *http.Request) (platform.BackupFilter, error) {
+	qp := r.URL.Query()
+
+	var filter platform.BackupFilter
+	if r.Method == "POST" {
+		defer r.Body.Close()
+
+		filter.Since = &platform.BackupManifest{}
+		if err := json.NewDecoder(r.Body).Decode(filter.Since); err != nil {
+			return filter, &platform.Error{
+				Code: platform.EInvalid,
+				Op:   "http/decodeBackupRequest",
+				Msg:  "invalid backup manifest",
+				Err:  err,
+			}
+		}
+
+		// The incremental backup covers the same data as the backup it is based on.
+		if qp.Get("org") != "" || qp.Get("bucket") != "" {
+			return filter, &platform.Error{
+				Code: platform.EInvalid,
+				Op:   "http/decodeBackupRequest",
+				Msg:  "org and bucket cannot be set for an incremental backup",
+			}
+		}
+		return filter, nil
+	}
+
+	orgParam, bucketParam := qp.Get("org"), qp.Get("bucket")
+	if orgParam == "" {
+		if bucketParam != "" {
+			return filter, &platform.Error{
+				Code: platform.EInvalid,
+				Op:   "http/decodeBackupRequest",
+				Msg:  "org is required to backup a bucket",
+			}
+		}
+		return filter, nil
+	}
+
+	org, err := findOrganization(ctx, h.OrganizationService, orgParam)
+	if err != nil {
+		return filter, err
+	}
+	filter.OrgID = &org.ID
+
+	if bucketParam == "" {
+		return filter, nil
+	}
+
+	var bucket *platform.Bucket
+	if id, err := platform.IDFromString(bucketParam); err == nil {
+		b, err := h.BucketService.FindBucket(ctx, platform.BucketFilter{
+			OrganizationID: &org.ID,
+			ID:             id,
+		})
+		if err == nil {
+			bucket = b
+		} else if platform.ErrorCode(err) != platform.ENotFound {
+			return filter, err
+		}
+	}
+
+	if bucket == nil {
+		b, err := h.BucketService.FindBucket(ctx, platform.BucketFilter{
+			OrganizationID: &org.ID,
+			Name:           &bucketParam,
+		})
+		if err != nil {
+			return filter, err
+		}
+		bucket = b
+	}
+	filter.BucketID = &bucket.ID
+
+	return filter, nil
+}
+
+// findOrganization looks up an organization by ID, falling back to its name.
+func findOrganization(ctx context.Context, s platform.OrganizationService, org string) (*platform.Organization, error) {
+	if id, err := platform.IDFromString(org); err == nil {
+		o, err := s.FindOrganizationByID(ctx, *id)
+		if err == nil {
+			return o, nil
+		} else if platform.ErrorCode(err) != platform.ENotFound {
+			return nil, err
+		}
+	}
+
+	return s.FindOrganization(ctx, platform.OrganizationFilter{Name: &org})
+}
+
+// BackupService retrieves backups over HTTP from influxdb.
+type BackupService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ platform.BackupService = (*BackupService)(nil)
+
+// Backup writes a backup archive of the data matching the filter to w.
+func (s *BackupService) Backup(ctx context.Context, w io.Writer, filter platform.BackupFilter) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := newURL(s.Addr, backupPath)
+	if err != nil {
+		return err
+	}
+
+	method, body := "GET", io.Reader(nil)
+	if filter.Since != nil {
+		octets, err := json.Marshal(filter.Since)
+		if err != nil {
+			return err
+		}
+		method, body = "POST", bytes.NewReader(octets)
+	}
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return err
+	}
+
+	SetToken(s.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	if filter.Since != nil {
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		params := req.URL.Query()
+		if filter.OrgID != nil {
+			params.Set("org", filter.OrgID.String())
+		}
+		if filter.BucketID != nil {
+			params.Set("bucket", filter.BucketID.String())
+		}
+		req.URL.RawQuery = params.Encode()
+	}
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
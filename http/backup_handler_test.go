@@ -0,0 +1,258 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	platformtesting "github.com/influxdata/influxdb/testing"
+	"go.uber.org/zap"
+)
+
+func TestBackupHandler_handleBackup(t *testing.T) {
+	const (
+		orgID    = platform.ID(1)
+		bucketID = platform.ID(2)
+	)
+
+	type wants struct {
+		statusCode int
+		filter     platform.BackupFilter
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		query       string
+		body        string
+		permissions []platform.Permission
+		wants       wants
+	}{
+		{
+			name:        "full backup",
+			permissions: platform.OperPermissions(),
+			wants: wants{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:        "bucket backup",
+			query:       "?org=0000000000000001&bucket=0000000000000002",
+			permissions: platform.OperPermissions(),
+			wants: wants{
+				statusCode: http.StatusOK,
+				filter: platform.BackupFilter{
+					OrgID:    platformtesting.IDPtr(orgID),
+					BucketID: platformtesting.IDPtr(bucketID),
+				},
+			},
+		},
+		{
+			name:        "incremental backup",
+			method:      "POST",
+			body:        `{"orgID":"0000000000000001","generation":3,"tsmFiles":[{"name":"000000000000003-000000001.tsm","generation":3,"sequence":1}]}`,
+			permissions: platform.OperPermissions(),
+			wants: wants{
+				statusCode: http.StatusOK,
+				filter: platform.BackupFilter{
+					Since: &platform.BackupManifest{
+						OrgID:      platformtesting.IDPtr(orgID),
+						Generation: 3,
+						TSMFiles:   []platform.BackupTSMFile{{Name: "000000000000003-000000001.tsm", Generation: 3, Sequence: 1}},
+					},
+				},
+			},
+		},
+		{
+			name:        "incremental backup with bucket",
+			method:      "POST",
+			query:       "?org=0000000000000001&bucket=0000000000000002",
+			body:        `{"generation":3}`,
+			permissions: platform.OperPermissions(),
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:        "bucket without org",
+			query:       "?bucket=0000000000000002",
+			permissions: platform.OperPermissions(),
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:        "org owner",
+			query:       "?org=0000000000000001",
+			permissions: platform.OwnerPermissions(orgID),
+			wants: wants{
+				statusCode: http.StatusForbidden,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				called bool
+				filter platform.BackupFilter
+			)
+
+			orgSvc := mock.NewOrganizationService()
+			orgSvc.FindOrganizationByIDF = func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+				return &platform.Organization{ID: id, Name: "org"}, nil
+			}
+
+			bucketSvc := mock.NewBucketService()
+			bucketSvc.FindBucketFn = func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+				return &platform.Bucket{ID: *filter.ID, OrgID: *filter.OrganizationID, Name: "bucket"}, nil
+			}
+
+			h := NewBackupHandler(&BackupBackend{
+				Logger:              zap.NewNop(),
+				OrganizationService: orgSvc,
+				BucketService:       bucketSvc,
+				BackupService: &mock.BackupService{
+					BackupF: func(ctx context.Context, w io.Writer, f platform.BackupFilter) error {
+						called, filter = true, f
+						_, err := w.Write([]byte("archive"))
+						return err
+					},
+				},
+			})
+
+			method := tt.method
+			if method == "" {
+				method = "GET"
+			}
+
+			r := httptest.NewRequest(method, "http://any.url/api/v2/backup"+tt.query, strings.NewReader(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{
+				Status:      platform.Active,
+				OrgID:       orgID,
+				Permissions: tt.permissions,
+			}))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, tt.wants.statusCode; got != want {
+				t.Fatalf("unexpected status code: got %d, want %d: %s", got, want, w.Body.String())
+			}
+
+			if tt.wants.statusCode != http.StatusOK {
+				if called {
+					t.Fatalf("backup service should not have been called")
+				}
+				return
+			}
+
+			if got, want := w.Body.String(), "archive"; got != want {
+				t.Errorf("unexpected body: got %q, want %q", got, want)
+			}
+			if !reflect.DeepEqual(filter, tt.wants.filter) {
+				t.Errorf("unexpected filter: got %+v, want %+v", filter, tt.wants.filter)
+			}
+		})
+	}
+}
+
+func TestBackupHandler_handleBackup_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive string
+		abort   bool
+	}{
+		{
+			name: "before streaming",
+		},
+		{
+			name:    "while streaming",
+			archive: "partial archive",
+			abort:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewBackupHandler(&BackupBackend{
+				Logger: zap.NewNop(),
+				BackupService: &mock.BackupService{
+					BackupF: func(ctx context.Context, w io.Writer, f platform.BackupFilter) error {
+						if _, err := io.WriteString(w, tt.archive); err != nil {
+							return err
+						}
+						return errors.New("disk failure")
+					},
+				},
+			})
+
+			r := httptest.NewRequest("GET", "http://any.url/api/v2/backup", nil)
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{
+				Status:      platform.Active,
+				Permissions: platform.OperPermissions(),
+			}))
+			w := httptest.NewRecorder()
+
+			defer func() {
+				if got := recover(); tt.abort && got != http.ErrAbortHandler {
+					t.Fatalf("expected the handler to abort the connection, got %v", got)
+				} else if !tt.abort && got != nil {
+					panic(got)
+				}
+			}()
+			h.ServeHTTP(w, r)
+
+			if tt.abort {
+				t.Fatalf("expected the handler to abort the connection")
+			}
+			if got, want := w.Code, http.StatusInternalServerError; got != want {
+				t.Fatalf("unexpected status code: got %d, want %d", got, want)
+			}
+			if got := w.Header().Get("Content-Disposition"); got != "" {
+				t.Errorf("unexpected content disposition %q", got)
+			}
+			if !strings.Contains(w.Body.String(), "disk failure") {
+				t.Errorf("expected the error in the response, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestBackupService_Backup(t *testing.T) {
+	var org, bucket string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		org, bucket = r.URL.Query().Get("org"), r.URL.Query().Get("bucket")
+		w.Write([]byte("archive"))
+	}))
+	defer ts.Close()
+
+	s := &BackupService{
+		Addr: ts.URL,
+	}
+
+	var buf bytes.Buffer
+	filter := platform.BackupFilter{OrgID: platformtesting.IDPtr(1), BucketID: platformtesting.IDPtr(2)}
+	if err := s.Backup(context.Background(), &buf, filter); err != nil {
+		t.Fatalf("BackupService.Backup() error = %v", err)
+	}
+
+	if got, want := buf.String(), "archive"; got != want {
+		t.Errorf("BackupService.Backup() archive = %q, want %q", got, want)
+	}
+	if got, want := org, "0000000000000001"; got != want {
+		t.Errorf("BackupService.Backup() org = %v, want %v", got, want)
+	}
+	if got, want := bucket, "0000000000000002"; got != want {
+		t.Errorf("BackupService.Backup() bucket = %v, want %v", got, want)
+	}
+}
@@ -27,6 +27,7 @@ type BucketBackend struct {
 	LabelService               influxdb.LabelService
 	UserService                influxdb.UserService
 	OrganizationService        influxdb.OrganizationService
+	SeriesCardinalityService   influxdb.SeriesCardinalityService
 }
 
 // NewBucketBackend returns a new instance of BucketBackend.
@@ -40,6 +41,7 @@ func NewBucketBackend(b *APIBackend) *BucketBackend {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		OrganizationService:        b.OrganizationService,
+		SeriesCardinalityService:   b.SeriesCardinalityService,
 	}
 }
 
@@ -55,18 +57,20 @@ type BucketHandler struct {
 	LabelService               influxdb.LabelService
 	UserService                influxdb.UserService
 	OrganizationService        influxdb.OrganizationService
+	SeriesCardinalityService   influxdb.SeriesCardinalityService
 }
 
 const (
-	bucketsPath            = "/api/v2/buckets"
-	bucketsIDPath          = "/api/v2/buckets/:id"
-	bucketsIDLogPath       = "/api/v2/buckets/:id/logs"
-	bucketsIDMembersPath   = "/api/v2/buckets/:id/members"
-	bucketsIDMembersIDPath = "/api/v2/buckets/:id/members/:userID"
-	bucketsIDOwnersPath    = "/api/v2/buckets/:id/owners"
-	bucketsIDOwnersIDPath  = "/api/v2/buckets/:id/owners/:userID"
-	bucketsIDLabelsPath    = "/api/v2/buckets/:id/labels"
-	bucketsIDLabelsIDPath  = "/api/v2/buckets/:id/labels/:lid"
+	bucketsPath              = "/api/v2/buckets"
+	bucketsIDPath            = "/api/v2/buckets/:id"
+	bucketsIDLogPath         = "/api/v2/buckets/:id/logs"
+	bucketsIDCardinalityPath = "/api/v2/buckets/:id/cardinality"
+	bucketsIDMembersPath     = "/api/v2/buckets/:id/members"
+	bucketsIDMembersIDPath   = "/api/v2/buckets/:id/members/:userID"
+	bucketsIDOwnersPath      = "/api/v2/buckets/:id/owners"
+	bucketsIDOwnersIDPath    = "/api/v2/buckets/:id/owners/:userID"
+	bucketsIDLabelsPath      = "/api/v2/buckets/:id/labels"
+	bucketsIDLabelsIDPath    = "/api/v2/buckets/:id/labels/:lid"
 )
 
 // NewBucketHandler returns a new instance of BucketHandler.
@@ -81,12 +85,14 @@ func NewBucketHandler(b *BucketBackend) *BucketHandler {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		OrganizationService:        b.OrganizationService,
+		SeriesCardinalityService:   b.SeriesCardinalityService,
 	}
 
 	h.HandlerFunc("POST", bucketsPath, h.handlePostBucket)
 	h.HandlerFunc("GET", bucketsPath, h.handleGetBuckets)
 	h.HandlerFunc("GET", bucketsIDPath, h.handleGetBucket)
 	h.HandlerFunc("GET", bucketsIDLogPath, h.handleGetBucketLog)
+	h.HandlerFunc("GET", bucketsIDCardinalityPath, h.handleGetBucketCardinality)
 	h.HandlerFunc("PATCH", bucketsIDPath, h.handlePatchBucket)
 	h.HandlerFunc("DELETE", bucketsIDPath, h.handleDeleteBucket)
 
@@ -124,6 +130,12 @@ func NewBucketHandler(b *BucketBackend) *BucketHandler {
 	return h
 }
 
+// errNegativeMaxSeries is returned when the series limit of a bucket is negative.
+var errNegativeMaxSeries = &influxdb.Error{
+	Code: influxdb.EUnprocessableEntity,
+	Msg:  "max series must be greater than or equal to zero",
+}
+
 // bucket is used for serialization/deserialization with duration string syntax.
 type bucket struct {
 	ID                  influxdb.ID     `json:"id,omitempty"`
@@ -132,6 +144,7 @@ type bucket struct {
 	Name                string          `json:"name"`
 	RetentionPolicyName string          `json:"rp,omitempty"` // This to support v1 sources
 	RetentionRules      []retentionRule `json:"retentionRules"`
+	MaxSeries           int64           `json:"maxSeries,omitempty"`
 }
 
 // retentionRule is the retention rule action for a bucket.
@@ -158,6 +171,10 @@ func (b *bucket) toInfluxDB() (*influxdb.Bucket, error) {
 		}
 	}
 
+	if b.MaxSeries < 0 {
+		return nil, errNegativeMaxSeries
+	}
+
 	return &influxdb.Bucket{
 		ID:                  b.ID,
 		OrgID:               b.OrgID,
@@ -165,6 +182,7 @@ func (b *bucket) toInfluxDB() (*influxdb.Bucket, error) {
 		Name:                b.Name,
 		RetentionPolicyName: b.RetentionPolicyName,
 		RetentionPeriod:     d,
+		MaxSeries:           b.MaxSeries,
 	}, nil
 }
 
@@ -189,6 +207,7 @@ func newBucket(pb *influxdb.Bucket) *bucket {
 		Description:         pb.Description,
 		RetentionPolicyName: pb.RetentionPolicyName,
 		RetentionRules:      rules,
+		MaxSeries:           pb.MaxSeries,
 	}
 }
 
@@ -197,6 +216,7 @@ type bucketUpdate struct {
 	Name           *string         `json:"name,omitempty"`
 	Description    *string         `json:"description,omitempty"`
 	RetentionRules []retentionRule `json:"retentionRules,omitempty"`
+	MaxSeries      *int64          `json:"maxSeries,omitempty"`
 }
 
 func (b *bucketUpdate) toInfluxDB() (*influxdb.BucketUpdate, error) {
@@ -216,10 +236,15 @@ func (b *bucketUpdate) toInfluxDB() (*influxdb.BucketUpdate, error) {
 		}
 	}
 
+	if b.MaxSeries != nil && *b.MaxSeries < 0 {
+		return nil, errNegativeMaxSeries
+	}
+
 	return &influxdb.BucketUpdate{
 		Name:            b.Name,
 		Description:     b.Description,
 		RetentionPeriod: &d,
+		MaxSeries:       b.MaxSeries,
 	}, nil
 }
 
@@ -232,6 +257,7 @@ func newBucketUpdate(pb *influxdb.BucketUpdate) *bucketUpdate {
 		Name:           pb.Name,
 		Description:    pb.Description,
 		RetentionRules: []retentionRule{},
+		MaxSeries:      pb.MaxSeries,
 	}
 
 	if pb.RetentionPeriod != nil {
@@ -367,6 +393,67 @@ func (h *BucketHandler) handleGetBucket(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+type bucketCardinalityResponse struct {
+	Links     map[string]string `json:"links"`
+	BucketID  influxdb.ID       `json:"bucketID"`
+	OrgID     influxdb.ID       `json:"orgID"`
+	Series    int64             `json:"series"`
+	MaxSeries int64             `json:"maxSeries"`
+}
+
+// handleGetBucketCardinality is the HTTP handler for the GET /api/v2/buckets/:id/cardinality route.
+func (h *BucketHandler) handleGetBucketCardinality(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "BucketHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+
+	req, err := decodeGetBucketRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	b, err := h.BucketService.FindBucketByID(ctx, req.BucketID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	n, err := h.SeriesCardinalityService.BucketSeriesCardinality(ctx, b.OrgID, b.ID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// Buckets without a series limit use the limit of their organization.
+	max := b.MaxSeries
+	if max == 0 {
+		o, err := h.OrganizationService.FindOrganizationByID(ctx, b.OrgID)
+		if err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+		max = o.MaxSeries
+	}
+
+	res := &bucketCardinalityResponse{
+		Links: map[string]string{
+			"bucket": fmt.Sprintf("/api/v2/buckets/%s", b.ID),
+			"self":   fmt.Sprintf("/api/v2/buckets/%s/cardinality", b.ID),
+		},
+		BucketID:  b.ID,
+		OrgID:     b.OrgID,
+		Series:    n,
+		MaxSeries: max,
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
 type getBucketRequest struct {
 	BucketID influxdb.ID
 }
@@ -348,6 +348,173 @@ func TestService_handleGetBucket(t *testing.T) {
 	}
 }
 
+func TestService_handleGetBucketCardinality(t *testing.T) {
+	type fields struct {
+		BucketService            platform.BucketService
+		OrganizationService      platform.OrganizationService
+		SeriesCardinalityService platform.SeriesCardinalityService
+	}
+	type args struct {
+		id string
+	}
+	type wants struct {
+		statusCode  int
+		contentType string
+		body        string
+	}
+
+	orgService := &mock.OrganizationService{
+		FindOrganizationByIDF: func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+			return &platform.Organization{ID: id, Name: "org", MaxSeries: 1000}, nil
+		},
+	}
+	cardinalityService := &mock.SeriesCardinalityService{
+		BucketSeriesCardinalityF: func(ctx context.Context, orgID, bucketID platform.ID) (int64, error) {
+			return 42, nil
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "get the cardinality of a bucket",
+			fields: fields{
+				BucketService: &mock.BucketService{
+					FindBucketByIDFn: func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+						return &platform.Bucket{
+							ID:        id,
+							OrgID:     platformtesting.MustIDBase16("020f755c3c082001"),
+							Name:      "hello",
+							MaxSeries: 100,
+						}, nil
+					},
+				},
+				OrganizationService:      orgService,
+				SeriesCardinalityService: cardinalityService,
+			},
+			args: args{
+				id: "020f755c3c082000",
+			},
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body: `
+		{
+		  "links": {
+		    "bucket": "/api/v2/buckets/020f755c3c082000",
+		    "self": "/api/v2/buckets/020f755c3c082000/cardinality"
+		  },
+		  "bucketID": "020f755c3c082000",
+		  "orgID": "020f755c3c082001",
+		  "series": 42,
+		  "maxSeries": 100
+		}
+		`,
+			},
+		},
+		{
+			name: "get the cardinality of a bucket limited by its organization",
+			fields: fields{
+				BucketService: &mock.BucketService{
+					FindBucketByIDFn: func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+						return &platform.Bucket{
+							ID:    id,
+							OrgID: platformtesting.MustIDBase16("020f755c3c082001"),
+							Name:  "hello",
+						}, nil
+					},
+				},
+				OrganizationService:      orgService,
+				SeriesCardinalityService: cardinalityService,
+			},
+			args: args{
+				id: "020f755c3c082000",
+			},
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body: `
+		{
+		  "links": {
+		    "bucket": "/api/v2/buckets/020f755c3c082000",
+		    "self": "/api/v2/buckets/020f755c3c082000/cardinality"
+		  },
+		  "bucketID": "020f755c3c082000",
+		  "orgID": "020f755c3c082001",
+		  "series": 42,
+		  "maxSeries": 1000
+		}
+		`,
+			},
+		},
+		{
+			name: "not found",
+			fields: fields{
+				BucketService: &mock.BucketService{
+					FindBucketByIDFn: func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+						return nil, &platform.Error{
+							Code: platform.ENotFound,
+							Msg:  "bucket not found",
+						}
+					},
+				},
+				OrganizationService:      orgService,
+				SeriesCardinalityService: cardinalityService,
+			},
+			args: args{
+				id: "020f755c3c082000",
+			},
+			wants: wants{
+				statusCode: http.StatusNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucketBackend := NewMockBucketBackend()
+			bucketBackend.BucketService = tt.fields.BucketService
+			bucketBackend.OrganizationService = tt.fields.OrganizationService
+			bucketBackend.SeriesCardinalityService = tt.fields.SeriesCardinalityService
+			h := NewBucketHandler(bucketBackend)
+
+			r := httptest.NewRequest("GET", "http://any.url", nil)
+
+			r = r.WithContext(context.WithValue(
+				context.Background(),
+				httprouter.ParamsKey,
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: tt.args.id,
+					},
+				}))
+
+			w := httptest.NewRecorder()
+
+			h.handleGetBucketCardinality(w, r)
+
+			res := w.Result()
+			content := res.Header.Get("Content-Type")
+			body, _ := ioutil.ReadAll(res.Body)
+
+			if res.StatusCode != tt.wants.statusCode {
+				t.Errorf("%q. handleGetBucketCardinality() = %v, want %v", tt.name, res.StatusCode, tt.wants.statusCode)
+			}
+			if tt.wants.contentType != "" && content != tt.wants.contentType {
+				t.Errorf("%q. handleGetBucketCardinality() = %v, want %v", tt.name, content, tt.wants.contentType)
+			}
+			if eq, diff, _ := jsonEqual(string(body), tt.wants.body); tt.wants.body != "" && !eq {
+				t.Errorf("%q. handleGetBucketCardinality() = ***%s***", tt.name, diff)
+			}
+		})
+	}
+}
+
 func TestService_handlePostBucket(t *testing.T) {
 	type fields struct {
 		BucketService       platform.BucketService
@@ -0,0 +1,409 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const (
+	dbrpsPath = "/api/v2/dbrps"
+)
+
+type dbrpResponse struct {
+	*platform.DBRPMapping
+	Links map[string]string `json:"links"`
+}
+
+func newDBRPResponse(m *platform.DBRPMapping) *dbrpResponse {
+	return &dbrpResponse{
+		DBRPMapping: m,
+		Links: map[string]string{
+			"self":   dbrpPath(m.Cluster, m.Database, m.RetentionPolicy),
+			"org":    fmt.Sprintf("/api/v2/orgs/%s", m.OrganizationID),
+			"bucket": fmt.Sprintf("/api/v2/buckets/%s", m.BucketID),
+		},
+	}
+}
+
+type dbrpsResponse struct {
+	DBRPs []*dbrpResponse   `json:"dbrps"`
+	Links map[string]string `json:"links"`
+}
+
+func newDBRPsResponse(ms []*platform.DBRPMapping) *dbrpsResponse {
+	res := &dbrpsResponse{
+		DBRPs: make([]*dbrpResponse, 0, len(ms)),
+		Links: map[string]string{
+			"self": dbrpsPath,
+		},
+	}
+	for _, m := range ms {
+		res.DBRPs = append(res.DBRPs, newDBRPResponse(m))
+	}
+	return res
+}
+
+// DBRPMappingBackend is all services and associated parameters required to construct
+// the DBRPMappingHandler.
+type DBRPMappingBackend struct {
+	Logger *zap.Logger
+
+	DBRPMappingService platform.DBRPMappingService
+	BucketService      platform.BucketService
+}
+
+// NewDBRPMappingBackend returns a new instance of DBRPMappingBackend.
+func NewDBRPMappingBackend(b *APIBackend) *DBRPMappingBackend {
+	return &DBRPMappingBackend{
+		Logger: b.Logger.With(zap.String("handler", "dbrp")),
+
+		DBRPMappingService: b.DBRPMappingService,
+		BucketService:      b.BucketService,
+	}
+}
+
+// DBRPMappingHandler is the handler for the mappings of database and retention
+// policies to buckets.
+type DBRPMappingHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	DBRPMappingService platform.DBRPMappingService
+	BucketService      platform.BucketService
+}
+
+// NewDBRPMappingHandler returns a new instance of DBRPMappingHandler.
+func NewDBRPMappingHandler(b *DBRPMappingBackend) *DBRPMappingHandler {
+	h := &DBRPMappingHandler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+
+		DBRPMappingService: b.DBRPMappingService,
+		BucketService:      b.BucketService,
+	}
+
+	h.HandlerFunc("POST", dbrpsPath, h.handlePostDBRP)
+	h.HandlerFunc("GET", dbrpsPath, h.handleGetDBRPs)
+	h.HandlerFunc("GET", dbrpsPath+"/:cluster/:db/:rp", h.handleGetDBRP)
+	h.HandlerFunc("DELETE", dbrpsPath+"/:cluster/:db/:rp", h.handleDeleteDBRP)
+	return h
+}
+
+// handlePostDBRP is the HTTP handler for the POST /api/v2/dbrps route.
+func (h *DBRPMappingHandler) handlePostDBRP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	m, err := decodePostDBRPRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// Make sure the bucket exists and belongs to the organization of the mapping.
+	b, err := h.BucketService.FindBucketByID(ctx, m.BucketID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	if b.OrgID != m.OrganizationID {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("bucket %s does not belong to organization %s", m.BucketID, m.OrganizationID),
+		}, w)
+		return
+	}
+
+	if err := h.DBRPMappingService.Create(ctx, m); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newDBRPResponse(m)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodePostDBRPRequest(ctx context.Context, r *http.Request) (*platform.DBRPMapping, error) {
+	m := &platform.DBRPMapping{}
+	if err := json.NewDecoder(r.Body).Decode(m); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}
+	}
+
+	// Mappings are created for the 1.x compatible endpoints unless a cluster is given.
+	if m.Cluster == "" {
+		m.Cluster = LegacyCluster
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+
+	return m, nil
+}
+
+// handleGetDBRPs is the HTTP handler for the GET /api/v2/dbrps route.
+func (h *DBRPMappingHandler) handleGetDBRPs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := decodeGetDBRPsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	ms, _, err := h.DBRPMappingService.FindMany(ctx, filter)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newDBRPsResponse(ms)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodeGetDBRPsRequest(ctx context.Context, r *http.Request) (platform.DBRPMappingFilter, error) {
+	var filter platform.DBRPMappingFilter
+
+	qp := r.URL.Query()
+	if cluster := qp.Get("cluster"); cluster != "" {
+		filter.Cluster = &cluster
+	}
+	if db := qp.Get("db"); db != "" {
+		filter.Database = &db
+	}
+	if rp := qp.Get("rp"); rp != "" {
+		filter.RetentionPolicy = &rp
+	}
+	if s := qp.Get("default"); s != "" {
+		def, err := strconv.ParseBool(s)
+		if err != nil {
+			return filter, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("invalid default %q", s),
+				Err:  err,
+			}
+		}
+		filter.Default = &def
+	}
+
+	return filter, nil
+}
+
+// handleGetDBRP is the HTTP handler for the GET /api/v2/dbrps/:cluster/:db/:rp route.
+func (h *DBRPMappingHandler) handleGetDBRP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	m, err := h.DBRPMappingService.FindBy(ctx, params.ByName("cluster"), params.ByName("db"), params.ByName("rp"))
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newDBRPResponse(m)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handleDeleteDBRP is the HTTP handler for the DELETE /api/v2/dbrps/:cluster/:db/:rp route.
+func (h *DBRPMappingHandler) handleDeleteDBRP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	if err := h.DBRPMappingService.Delete(ctx, params.ByName("cluster"), params.ByName("db"), params.ByName("rp")); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DBRPMappingService connects to Influx via HTTP using tokens to manage dbrp mappings.
+type DBRPMappingService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ platform.DBRPMappingService = (*DBRPMappingService)(nil)
+
+// FindBy returns the dbrp mapping for the cluster, db and rp.
+func (s *DBRPMappingService) FindBy(ctx context.Context, cluster, db, rp string) (*platform.DBRPMapping, error) {
+	u, err := newURL(s.Addr, dbrpPath(cluster, db, rp))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var m platform.DBRPMapping
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Find returns the first dbrp mapping that matches filter.
+func (s *DBRPMappingService) Find(ctx context.Context, filter platform.DBRPMappingFilter) (*platform.DBRPMapping, error) {
+	ms, n, err := s.FindMany(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if n == 0 {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Op:   platform.OpFindDBRPMapping,
+			Err:  platform.ErrDBRPNotFound,
+		}
+	}
+
+	return ms[0], nil
+}
+
+// FindMany returns a list of dbrp mappings that match filter and the total count of matching dbrp mappings.
+func (s *DBRPMappingService) FindMany(ctx context.Context, filter platform.DBRPMappingFilter, opt ...platform.FindOptions) ([]*platform.DBRPMapping, int, error) {
+	u, err := newURL(s.Addr, dbrpsPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	qp := u.Query()
+	if filter.Cluster != nil {
+		qp.Set("cluster", *filter.Cluster)
+	}
+	if filter.Database != nil {
+		qp.Set("db", *filter.Database)
+	}
+	if filter.RetentionPolicy != nil {
+		qp.Set("rp", *filter.RetentionPolicy)
+	}
+	if filter.Default != nil {
+		qp.Set("default", strconv.FormatBool(*filter.Default))
+	}
+	u.RawQuery = qp.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, 0, err
+	}
+
+	var res struct {
+		DBRPs []*platform.DBRPMapping `json:"dbrps"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, 0, err
+	}
+
+	return res.DBRPs, len(res.DBRPs), nil
+}
+
+// Create creates a new dbrp mapping.
+func (s *DBRPMappingService) Create(ctx context.Context, m *platform.DBRPMapping) error {
+	u, err := newURL(s.Addr, dbrpsPath)
+	if err != nil {
+		return err
+	}
+
+	octets, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckErrorStatus(http.StatusCreated, resp); err != nil {
+		return err
+	}
+
+	return json.NewDecoder(resp.Body).Decode(m)
+}
+
+// Delete removes a dbrp mapping.
+func (s *DBRPMappingService) Delete(ctx context.Context, cluster, db, rp string) error {
+	u, err := newURL(s.Addr, dbrpPath(cluster, db, rp))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckErrorStatus(http.StatusNoContent, resp)
+}
+
+func dbrpPath(cluster, db, rp string) string {
+	return path.Join(dbrpsPath, cluster, db, rp)
+}
@@ -0,0 +1,318 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// DeleteBackend is all services and associated parameters required to construct
+// the DeleteHandler.
+type DeleteBackend struct {
+	Logger *zap.Logger
+
+	DeleteService       platform.DeleteService
+	BucketService       platform.BucketService
+	OrganizationService platform.OrganizationService
+}
+
+// NewDeleteBackend returns a new instance of DeleteBackend.
+func NewDeleteBackend(b *APIBackend) *DeleteBackend {
+	return &DeleteBackend{
+		Logger: b.Logger.With(zap.String("handler", "delete")),
+
+		DeleteService:       b.DeleteService,
+		BucketService:       b.BucketService,
+		OrganizationService: b.OrganizationService,
+	}
+}
+
+// DeleteHandler receives a delete request with a predicate and sends it to storage.
+type DeleteHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	DeleteService       platform.DeleteService
+	BucketService       platform.BucketService
+	OrganizationService platform.OrganizationService
+}
+
+const (
+	deletePath = "/api/v2/delete"
+)
+
+// NewDeleteHandler creates a new handler at /api/v2/delete to receive delete requests.
+func NewDeleteHandler(b *DeleteBackend) *DeleteHandler {
+	h := &DeleteHandler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+
+		DeleteService:       b.DeleteService,
+		BucketService:       b.BucketService,
+		OrganizationService: b.OrganizationService,
+	}
+
+	h.HandlerFunc("POST", deletePath, h.handleDelete)
+	return h
+}
+
+func (h *DeleteHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "DeleteHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	req, err := decodeDeleteRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	logger := h.Logger.With(zap.String("org", req.Org), zap.String("bucket", req.Bucket))
+
+	var org *platform.Organization
+	if id, err := platform.IDFromString(req.Org); err == nil {
+		// Decoded ID successfully. Make sure it's a real org.
+		o, err := h.OrganizationService.FindOrganizationByID(ctx, *id)
+		if err == nil {
+			org = o
+		} else if platform.ErrorCode(err) != platform.ENotFound {
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+	if org == nil {
+		o, err := h.OrganizationService.FindOrganization(ctx, platform.OrganizationFilter{Name: &req.Org})
+		if err != nil {
+			logger.Info("Failed to find organization", zap.Error(err))
+			EncodeError(ctx, err, w)
+			return
+		}
+
+		org = o
+	}
+
+	var bucket *platform.Bucket
+	if id, err := platform.IDFromString(req.Bucket); err == nil {
+		// Decoded ID successfully. Make sure it's a real bucket.
+		b, err := h.BucketService.FindBucket(ctx, platform.BucketFilter{
+			OrganizationID: &org.ID,
+			ID:             id,
+		})
+		if err == nil {
+			bucket = b
+		} else if platform.ErrorCode(err) != platform.ENotFound {
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+
+	if bucket == nil {
+		b, err := h.BucketService.FindBucket(ctx, platform.BucketFilter{
+			OrganizationID: &org.ID,
+			Name:           &req.Bucket,
+		})
+		if err != nil {
+			EncodeError(ctx, &platform.Error{
+				Op:  "http/handleDelete",
+				Err: err,
+			}, w)
+			return
+		}
+
+		bucket = b
+	}
+
+	p, err := platform.NewPermissionAtID(bucket.ID, platform.WriteAction, platform.BucketsResourceType, org.ID)
+	if err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInternal,
+			Op:   "http/handleDelete",
+			Msg:  fmt.Sprintf("unable to create permission for bucket: %v", err),
+			Err:  err,
+		}, w)
+		return
+	}
+
+	if !a.Allowed(*p) {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EForbidden,
+			Op:   "http/handleDelete",
+			Msg:  "insufficient permissions for delete",
+		}, w)
+		return
+	}
+
+	if err := h.DeleteService.DeleteBucketRangePredicate(ctx, org.ID, bucket.ID, req.Start.UnixNano(), req.Stop.UnixNano(), req.Predicate); err != nil {
+		logger.Error("Error deleting data", zap.Error(err))
+		if platform.ErrorCode(err) == platform.EInvalid {
+			EncodeError(ctx, err, w)
+			return
+		}
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInternal,
+			Op:   "http/handleDelete",
+			Msg:  fmt.Sprintf("unable to delete data: %v", err),
+			Err:  err,
+		}, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeDeleteRequest(ctx context.Context, r *http.Request) (*deleteRequest, error) {
+	qp := r.URL.Query()
+
+	body := deleteRequestBody{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeDeleteRequest",
+			Msg:  "invalid request body",
+			Err:  err,
+		}
+	}
+
+	req := &deleteRequest{
+		Org:       qp.Get("org"),
+		Bucket:    qp.Get("bucket"),
+		Start:     body.Start,
+		Stop:      body.Stop,
+		Predicate: body.Predicate,
+	}
+
+	return req, req.Validate()
+}
+
+// deleteRequestBody is the JSON body of a delete request.
+type deleteRequestBody struct {
+	Start     time.Time `json:"start"`
+	Stop      time.Time `json:"stop"`
+	Predicate string    `json:"predicate,omitempty"`
+}
+
+type deleteRequest struct {
+	Org       string
+	Bucket    string
+	Start     time.Time
+	Stop      time.Time
+	Predicate string
+}
+
+func (r *deleteRequest) Validate() error {
+	if r.Org == "" {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeDeleteRequest",
+			Msg:  "org is required",
+		}
+	}
+
+	if r.Bucket == "" {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeDeleteRequest",
+			Msg:  "bucket is required",
+		}
+	}
+
+	if r.Start.IsZero() || r.Stop.IsZero() {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeDeleteRequest",
+			Msg:  "start and stop are required",
+		}
+	}
+
+	if r.Stop.Before(r.Start) {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeDeleteRequest",
+			Msg:  "stop must not be before start",
+		}
+	}
+
+	return nil
+}
+
+// DeleteService sends delete requests over HTTP to influxdb.
+type DeleteService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ platform.DeleteService = (*DeleteService)(nil)
+
+// DeleteBucketRangePredicate removes the data in the bucket between min and max
+// for the series matching the predicate.
+func (s *DeleteService) DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID platform.ID, min, max int64, predicate string) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := newURL(s.Addr, deletePath)
+	if err != nil {
+		return err
+	}
+
+	octets, err := json.Marshal(deleteRequestBody{
+		Start:     time.Unix(0, min).UTC(),
+		Stop:      time.Unix(0, max).UTC(),
+		Predicate: predicate,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	org, err := orgID.Encode()
+	if err != nil {
+		return err
+	}
+
+	bucket, err := bucketID.Encode()
+	if err != nil {
+		return err
+	}
+
+	params := req.URL.Query()
+	params.Set("org", string(org))
+	params.Set("bucket", string(bucket))
+	req.URL.RawQuery = params.Encode()
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckError(resp)
+}
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	platformtesting "github.com/influxdata/influxdb/testing"
+	"go.uber.org/zap"
+)
+
+func TestDeleteHandler_handleDelete(t *testing.T) {
+	const orgID = platform.ID(1)
+
+	type wants struct {
+		statusCode int
+		min, max   int64
+		predicate  string
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		permissions []platform.Permission
+		wants       wants
+	}{
+		{
+			name: "delete with predicate",
+			body: `{"start":"2009-01-02T23:00:00Z","stop":"2009-01-02T23:00:10Z","predicate":"host = 'a'"}`,
+			permissions: []platform.Permission{
+				{Action: platform.WriteAction, Resource: platform.Resource{Type: platform.BucketsResourceType, OrgID: platformtesting.IDPtr(orgID)}},
+			},
+			wants: wants{
+				statusCode: http.StatusNoContent,
+				min:        time.Date(2009, 1, 2, 23, 0, 0, 0, time.UTC).UnixNano(),
+				max:        time.Date(2009, 1, 2, 23, 0, 10, 0, time.UTC).UnixNano(),
+				predicate:  "host = 'a'",
+			},
+		},
+		{
+			name: "missing time range",
+			body: `{"predicate":"host = 'a'"}`,
+			permissions: []platform.Permission{
+				{Action: platform.WriteAction, Resource: platform.Resource{Type: platform.BucketsResourceType, OrgID: platformtesting.IDPtr(orgID)}},
+			},
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "stop before start",
+			body: `{"start":"2009-01-02T23:00:10Z","stop":"2009-01-02T23:00:00Z"}`,
+			permissions: []platform.Permission{
+				{Action: platform.WriteAction, Resource: platform.Resource{Type: platform.BucketsResourceType, OrgID: platformtesting.IDPtr(orgID)}},
+			},
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "read only token",
+			body: `{"start":"2009-01-02T23:00:00Z","stop":"2009-01-02T23:00:10Z"}`,
+			permissions: []platform.Permission{
+				{Action: platform.ReadAction, Resource: platform.Resource{Type: platform.BucketsResourceType, OrgID: platformtesting.IDPtr(orgID)}},
+			},
+			wants: wants{
+				statusCode: http.StatusForbidden,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				called    bool
+				min, max  int64
+				predicate string
+			)
+
+			orgSvc := mock.NewOrganizationService()
+			orgSvc.FindOrganizationByIDF = func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+				return &platform.Organization{ID: id, Name: "org"}, nil
+			}
+
+			bucketSvc := mock.NewBucketService()
+			bucketSvc.FindBucketFn = func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+				return &platform.Bucket{ID: *filter.ID, OrgID: *filter.OrganizationID, Name: "bucket"}, nil
+			}
+
+			h := NewDeleteHandler(&DeleteBackend{
+				Logger:              zap.NewNop(),
+				OrganizationService: orgSvc,
+				BucketService:       bucketSvc,
+				DeleteService: &mock.DeleteService{
+					DeleteBucketRangePredicateF: func(ctx context.Context, o, b platform.ID, mn, mx int64, pred string) error {
+						called = true
+						min, max, predicate = mn, mx, pred
+						return nil
+					},
+				},
+			})
+
+			r := httptest.NewRequest("POST", "http://any.url/api/v2/delete?org=0000000000000001&bucket=0000000000000002", strings.NewReader(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{
+				Status:      platform.Active,
+				OrgID:       orgID,
+				Permissions: tt.permissions,
+			}))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, tt.wants.statusCode; got != want {
+				t.Fatalf("unexpected status code: got %d, want %d: %s", got, want, w.Body.String())
+			}
+
+			if tt.wants.statusCode != http.StatusNoContent {
+				if called {
+					t.Fatalf("delete service should not have been called")
+				}
+				return
+			}
+
+			if got, want := min, tt.wants.min; got != want {
+				t.Errorf("unexpected min: got %d, want %d", got, want)
+			}
+			if got, want := max, tt.wants.max; got != want {
+				t.Errorf("unexpected max: got %d, want %d", got, want)
+			}
+			if got, want := predicate, tt.wants.predicate; got != want {
+				t.Errorf("unexpected predicate: got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDeleteService_DeleteBucketRangePredicate(t *testing.T) {
+	var (
+		org, bucket *platform.ID
+		body        deleteRequestBody
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		org, _ = platform.IDFromString(r.URL.Query().Get("org"))
+		bucket, _ = platform.IDFromString(r.URL.Query().Get("bucket"))
+		defer r.Body.Close()
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	s := &DeleteService{
+		Addr: ts.URL,
+	}
+	if err := s.DeleteBucketRangePredicate(context.Background(), 1, 2, 10, 20, "host = 'a'"); err != nil {
+		t.Fatalf("DeleteService.DeleteBucketRangePredicate() error = %v", err)
+	}
+
+	if got, want := *org, platform.ID(1); got != want {
+		t.Errorf("DeleteService.DeleteBucketRangePredicate() org = %v, want %v", got, want)
+	}
+	if got, want := *bucket, platform.ID(2); got != want {
+		t.Errorf("DeleteService.DeleteBucketRangePredicate() bucket = %v, want %v", got, want)
+	}
+	if got, want := body.Start.UnixNano(), int64(10); got != want {
+		t.Errorf("DeleteService.DeleteBucketRangePredicate() start = %v, want %v", got, want)
+	}
+	if got, want := body.Stop.UnixNano(), int64(20); got != want {
+		t.Errorf("DeleteService.DeleteBucketRangePredicate() stop = %v, want %v", got, want)
+	}
+	if got, want := body.Predicate, "host = 'a'"; got != want {
+		t.Errorf("DeleteService.DeleteBucketRangePredicate() predicate = %v, want %v", got, want)
+	}
+}
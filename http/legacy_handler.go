@@ -0,0 +1,526 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/flux/iocounter"
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/http/metric"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/influxql"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// LegacyCluster is the cluster of the dbrp mappings used by the InfluxDB 1.x
+// compatible endpoints.
+const LegacyCluster = "default"
+
+const (
+	legacyWritePath = "/write"
+	legacyQueryPath = "/query"
+)
+
+// LegacyBackend is all services and associated parameters required to construct
+// the LegacyHandler.
+type LegacyBackend struct {
+	Logger             *zap.Logger
+	WriteEventRecorder metric.EventRecorder
+	QueryEventRecorder metric.EventRecorder
+
+	AuthorizationService platform.AuthorizationService
+	DBRPMappingService   platform.DBRPMappingService
+	BucketService        platform.BucketService
+	OrganizationService  platform.OrganizationService
+	PointsWriter         storage.PointsWriter
+	InfluxQLService      query.ProxyQueryService
+
+	WriteMaxBodySize int64
+
+	// AutoCreateDBRP enables the creation of a bucket and a dbrp mapping on writes
+	// to a database and retention policy that are not mapped yet.
+	AutoCreateDBRP bool
+	// RetentionPolicies are the retention periods of the buckets created for the
+	// retention policies of the same name. Buckets of other retention policies
+	// retain their data forever.
+	RetentionPolicies map[string]time.Duration
+}
+
+// NewLegacyBackend returns a new instance of LegacyBackend.
+func NewLegacyBackend(b *APIBackend) *LegacyBackend {
+	return &LegacyBackend{
+		Logger:             b.Logger.With(zap.String("handler", "legacy")),
+		WriteEventRecorder: b.WriteEventRecorder,
+		QueryEventRecorder: b.QueryEventRecorder,
+
+		AuthorizationService: b.AuthorizationService,
+		DBRPMappingService:   b.DBRPMappingService,
+		BucketService:        b.BucketService,
+		OrganizationService:  b.OrganizationService,
+		PointsWriter:         b.PointsWriter,
+		InfluxQLService:      b.InfluxQLService,
+
+		WriteMaxBodySize: b.WriteMaxBodySize,
+
+		AutoCreateDBRP:    b.LegacyAutoCreateDBRP,
+		RetentionPolicies: b.LegacyRetentionPolicies,
+	}
+}
+
+// LegacyHandler serves the InfluxDB 1.x compatible /write and /query endpoints.
+// Databases and retention policies are resolved to buckets with the dbrp mappings
+// of LegacyCluster, and requests are authenticated with tokens given either in the
+// Authorization header, as the password of basic authentication, or as the p query
+// parameter.
+type LegacyHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	AuthorizationService platform.AuthorizationService
+	DBRPMappingService   platform.DBRPMappingService
+	BucketService        platform.BucketService
+	InfluxQLService      query.ProxyQueryService
+
+	AutoCreateDBRP    bool
+	RetentionPolicies map[string]time.Duration
+
+	EventRecorder metric.EventRecorder
+
+	WriteHandler *WriteHandler
+}
+
+// NewLegacyHandler returns a new instance of LegacyHandler.
+func NewLegacyHandler(b *LegacyBackend) *LegacyHandler {
+	h := &LegacyHandler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+
+		AuthorizationService: b.AuthorizationService,
+		DBRPMappingService:   b.DBRPMappingService,
+		BucketService:        b.BucketService,
+		InfluxQLService:      b.InfluxQLService,
+		EventRecorder:        b.QueryEventRecorder,
+
+		AutoCreateDBRP:    b.AutoCreateDBRP,
+		RetentionPolicies: b.RetentionPolicies,
+
+		WriteHandler: NewWriteHandler(&WriteBackend{
+			Logger:              b.Logger.With(zap.String("handler", "write")),
+			WriteEventRecorder:  b.WriteEventRecorder,
+			PointsWriter:        b.PointsWriter,
+			BucketService:       b.BucketService,
+			OrganizationService: b.OrganizationService,
+			MaxBodySize:         b.WriteMaxBodySize,
+		}),
+	}
+
+	h.HandlerFunc("POST", legacyWritePath, h.handleWrite)
+	h.HandlerFunc("GET", legacyQueryPath, h.handleQuery)
+	h.HandlerFunc("POST", legacyQueryPath, h.handleQuery)
+	return h
+}
+
+// ServeHTTP authenticates the request and delegates it to the handler of its route.
+func (h *LegacyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	token, err := getLegacyToken(r)
+	if err != nil {
+		UnauthorizedError(ctx, w)
+		return
+	}
+
+	a, err := h.AuthorizationService.FindAuthorizationByToken(ctx, token)
+	if err != nil {
+		UnauthorizedError(ctx, w)
+		return
+	}
+
+	r = r.WithContext(pcontext.SetAuthorizer(ctx, a))
+	h.Router.ServeHTTP(w, r)
+}
+
+// getLegacyToken returns the token of a 1.x request. Besides the token scheme of
+// the Authorization header, 1.x clients may give the token as the password of
+// basic authentication or as the p query parameter.
+func getLegacyToken(r *http.Request) (string, error) {
+	if token, err := GetToken(r); err == nil {
+		return token, nil
+	}
+	if _, password, ok := r.BasicAuth(); ok {
+		return password, nil
+	}
+	if password := r.URL.Query().Get("p"); password != "" {
+		return password, nil
+	}
+	return "", ErrAuthHeaderMissing
+}
+
+// findDBRPMapping returns the mapping of the database and retention policy, or the
+// default mapping of the database if rp is empty.
+func (h *LegacyHandler) findDBRPMapping(ctx context.Context, db, rp string) (*platform.DBRPMapping, error) {
+	if db == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/findDBRPMapping",
+			Msg:  "database is required",
+		}
+	}
+
+	cluster := LegacyCluster
+	filter := platform.DBRPMappingFilter{
+		Cluster:  &cluster,
+		Database: &db,
+	}
+	if rp != "" {
+		filter.RetentionPolicy = &rp
+	} else {
+		defaultRP := true
+		filter.Default = &defaultRP
+	}
+
+	m, err := h.DBRPMappingService.Find(ctx, filter)
+	if err != nil || m == nil {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Op:   "http/findDBRPMapping",
+			Msg:  fmt.Sprintf("database not found: %q", db),
+			Err:  err,
+		}
+	}
+	return m, nil
+}
+
+// legacyPrecisions maps the precisions of 1.x writes to their line protocol precision.
+var legacyPrecisions = map[string]string{
+	"":   "ns",
+	"n":  "ns",
+	"ns": "ns",
+	"u":  "us",
+	"us": "us",
+	"ms": "ms",
+	"s":  "s",
+}
+
+// handleWrite resolves the bucket of the database and retention policy of the
+// request, and writes its body to the bucket as the WriteHandler does.
+func (h *LegacyHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "LegacyHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	qp := r.URL.Query()
+
+	precision, ok := legacyPrecisions[qp.Get("precision")]
+	if !ok {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/handleLegacyWrite",
+			Msg:  "invalid precision; valid precision units are n, ns, u, us, ms, and s",
+		}, w)
+		return
+	}
+
+	m, err := h.findDBRPMapping(ctx, qp.Get("db"), qp.Get("rp"))
+	if err != nil && h.AutoCreateDBRP && platform.ErrorCode(err) == platform.ENotFound {
+		m, err = h.createDBRPMapping(ctx, qp.Get("db"), qp.Get("rp"))
+	}
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	qp.Set("org", m.OrganizationID.String())
+	qp.Set("bucket", m.BucketID.String())
+	qp.Set("precision", precision)
+
+	// The request is copied so that its URL can be rewritten for the WriteHandler.
+	r = r.WithContext(ctx)
+	u := *r.URL
+	u.RawQuery = qp.Encode()
+	r.URL = &u
+
+	h.WriteHandler.handleWrite(w, r)
+}
+
+// legacyDefaultRP is the retention policy of writes to a database that does not
+// have a default retention policy yet, as 1.x creates it with a database.
+const legacyDefaultRP = "autogen"
+
+// createDBRPMapping creates a bucket named db/rp in the organization of the
+// authorization of the request, and maps the database and retention policy to it.
+// The mapping is the default of the database if the database does not have a
+// default mapping yet.
+func (h *LegacyHandler) createDBRPMapping(ctx context.Context, db, rp string) (*platform.DBRPMapping, error) {
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	auth, ok := a.(*platform.Authorization)
+	if !ok {
+		return nil, platform.ErrAuthorizerNotSupported
+	}
+
+	p, err := platform.NewPermission(platform.WriteAction, platform.BucketsResourceType, auth.OrgID)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInternal,
+			Op:   "http/createDBRPMapping",
+			Msg:  fmt.Sprintf("unable to create permission for buckets: %v", err),
+			Err:  err,
+		}
+	}
+	if !auth.Allowed(*p) {
+		return nil, &platform.Error{
+			Code: platform.EForbidden,
+			Op:   "http/createDBRPMapping",
+			Msg:  fmt.Sprintf("insufficient permissions to create a bucket for database %q", db),
+		}
+	}
+
+	cluster := LegacyCluster
+	isDefault := true
+	if _, err := h.DBRPMappingService.Find(ctx, platform.DBRPMappingFilter{
+		Cluster:  &cluster,
+		Database: &db,
+		Default:  &isDefault,
+	}); err == nil {
+		isDefault = false
+	} else if platform.ErrorCode(err) != platform.ENotFound {
+		return nil, err
+	}
+	if rp == "" {
+		rp = legacyDefaultRP
+	}
+
+	b, err := h.findOrCreateBucket(ctx, auth.OrgID, db+"/"+rp, h.RetentionPolicies[rp])
+	if err != nil {
+		return nil, err
+	}
+
+	m := &platform.DBRPMapping{
+		Cluster:         LegacyCluster,
+		Database:        db,
+		RetentionPolicy: rp,
+		Default:         isDefault,
+		OrganizationID:  b.OrgID,
+		BucketID:        b.ID,
+	}
+	if err := h.DBRPMappingService.Create(ctx, m); err != nil {
+		return nil, err
+	}
+
+	h.Logger.Info("Created dbrp mapping",
+		zap.String("database", db),
+		zap.String("retention_policy", rp),
+		zap.Stringer("bucket_id", b.ID),
+	)
+	return m, nil
+}
+
+// findOrCreateBucket creates the bucket, or returns the bucket of the same name if
+// it already exists, such as when concurrent writes create the same mapping.
+func (h *LegacyHandler) findOrCreateBucket(ctx context.Context, orgID platform.ID, name string, retention time.Duration) (*platform.Bucket, error) {
+	b := &platform.Bucket{
+		OrgID:           orgID,
+		Name:            name,
+		RetentionPeriod: retention,
+	}
+	err := h.BucketService.CreateBucket(ctx, b)
+	if err == nil {
+		return b, nil
+	}
+	if platform.ErrorCode(err) != platform.EConflict {
+		return nil, err
+	}
+
+	return h.BucketService.FindBucket(ctx, platform.BucketFilter{
+		OrganizationID: &orgID,
+		Name:           &name,
+	})
+}
+
+// handleQuery runs the InfluxQL query of the request, and writes its results in
+// the 1.x JSON or CSV format.
+func (h *LegacyHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "LegacyHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+
+	var orgID platform.ID
+	var requestBytes int
+	sw := newStatusResponseWriter(w)
+	w = sw
+	defer func() {
+		h.EventRecorder.Record(ctx, metric.Event{
+			OrgID:         orgID,
+			Endpoint:      r.URL.Path,
+			RequestBytes:  requestBytes,
+			ResponseBytes: sw.responseBytes,
+			Status:        sw.code(),
+		})
+	}()
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		encodeLegacyQueryError(ctx, err, w)
+		return
+	}
+	auth, ok := a.(*platform.Authorization)
+	if !ok {
+		encodeLegacyQueryError(ctx, platform.ErrAuthorizerNotSupported, w)
+		return
+	}
+
+	req, n, err := h.decodeQueryRequest(ctx, r, auth)
+	if err != nil {
+		encodeLegacyQueryError(ctx, err, w)
+		return
+	}
+	orgID = req.Request.OrganizationID
+	requestBytes = n
+
+	req.Dialect.(*influxql.Dialect).SetHeaders(w)
+
+	cw := iocounter.Writer{Writer: w}
+	if _, err := h.InfluxQLService.Query(ctx, &cw, req); err != nil {
+		if cw.Count() == 0 {
+			// Only record the error headers IFF nothing has been written to w.
+			encodeLegacyQueryError(ctx, err, w)
+			return
+		}
+		h.Logger.Info("Error writing response to client",
+			zap.String("handler", "legacy"),
+			zap.Error(err),
+		)
+	}
+}
+
+// legacyEpochs maps the epoch parameter of 1.x queries to the format of timestamps.
+var legacyEpochs = map[string]influxql.TimeFormat{
+	"":   influxql.RFC3339Nano,
+	"h":  influxql.Hour,
+	"m":  influxql.Minute,
+	"s":  influxql.Second,
+	"ms": influxql.Millisecond,
+	"u":  influxql.Microsecond,
+	"µ":  influxql.Microsecond,
+	"ns": influxql.Nanosecond,
+	"n":  influxql.Nanosecond,
+}
+
+func (h *LegacyHandler) decodeQueryRequest(ctx context.Context, r *http.Request, auth *platform.Authorization) (*query.ProxyRequest, int, error) {
+	q := r.FormValue("q")
+	if q == "" {
+		return nil, 0, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeLegacyQueryRequest",
+			Msg:  `missing required parameter "q"`,
+		}
+	}
+
+	dialect := &influxql.Dialect{
+		Encoding: influxql.JSON,
+	}
+	switch r.Header.Get("Accept") {
+	case "application/csv", "text/csv":
+		dialect.Encoding = influxql.CSV
+	default:
+		if r.FormValue("pretty") == "true" {
+			dialect.Encoding = influxql.JSONPretty
+		}
+	}
+
+	epoch, ok := legacyEpochs[r.FormValue("epoch")]
+	if !ok {
+		return nil, 0, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeLegacyQueryRequest",
+			Msg:  "invalid epoch; valid epochs are h, m, s, ms, u, and ns",
+		}
+	}
+	dialect.TimeFormat = epoch
+
+	// The query runs in the organization of the authorization. The default database of
+	// the query must map to a bucket of that organization the authorization can read.
+	db, rp := r.FormValue("db"), r.FormValue("rp")
+	if db != "" {
+		m, err := h.findDBRPMapping(ctx, db, rp)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := authorizeLegacyQuery(auth, m); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// Databases referenced by the statements of the query are resolved as the
+	// authorization of the request.
+	compiler := influxql.NewCompiler(authorizer.NewDBRPMappingService(h.DBRPMappingService))
+	compiler.Cluster = LegacyCluster
+	compiler.DB = db
+	compiler.RP = rp
+	compiler.Query = q
+
+	return &query.ProxyRequest{
+		Request: query.Request{
+			Authorization:  auth,
+			OrganizationID: auth.OrgID,
+			Compiler:       compiler,
+		},
+		Dialect: dialect,
+	}, len(q), nil
+}
+
+// authorizeLegacyQuery checks that the bucket of the mapping is in the organization
+// of the authorization, and that the authorization can read it.
+func authorizeLegacyQuery(auth *platform.Authorization, m *platform.DBRPMapping) error {
+	if m.OrganizationID != auth.OrgID {
+		return &platform.Error{
+			Code: platform.EForbidden,
+			Op:   "http/decodeLegacyQueryRequest",
+			Msg:  fmt.Sprintf("database %q is not in the organization of the authorization", m.Database),
+		}
+	}
+
+	p, err := platform.NewPermissionAtID(m.BucketID, platform.ReadAction, platform.BucketsResourceType, m.OrganizationID)
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EInternal,
+			Op:   "http/decodeLegacyQueryRequest",
+			Msg:  fmt.Sprintf("unable to create permission for bucket: %v", err),
+			Err:  err,
+		}
+	}
+	if !auth.Allowed(*p) {
+		return &platform.Error{
+			Code: platform.EForbidden,
+			Op:   "http/decodeLegacyQueryRequest",
+			Msg:  fmt.Sprintf("insufficient permissions to read database %q", m.Database),
+		}
+	}
+	return nil
+}
+
+// encodeLegacyQueryError writes err in the error format of the 1.x query response.
+func encodeLegacyQueryError(ctx context.Context, err error, w http.ResponseWriter) {
+	code := platform.ErrorCode(err)
+	httpCode, ok := statusCodePlatformError[code]
+	if !ok {
+		httpCode = http.StatusBadRequest
+	}
+	w.Header().Set(PlatformErrorCodeHeader, code)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpCode)
+	_ = json.NewEncoder(w).Encode(&influxql.Response{Err: err.Error()})
+}
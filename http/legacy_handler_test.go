@@ -0,0 +1,500 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/influxql"
+	querymock "github.com/influxdata/influxdb/query/mock"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+func newTestLegacyHandler(t *testing.T, written *[]models.Point, queries *[]*query.ProxyRequest) *LegacyHandler {
+	t.Helper()
+
+	const (
+		orgID    = platform.ID(1)
+		bucketID = platform.ID(2)
+	)
+
+	authSvc := mock.NewAuthorizationService()
+	authSvc.FindAuthorizationByTokenFn = func(ctx context.Context, token string) (*platform.Authorization, error) {
+		switch token {
+		case "mytoken":
+			return &platform.Authorization{
+				Status:      platform.Active,
+				OrgID:       orgID,
+				Permissions: platform.OperPermissions(),
+			}, nil
+		case "writetoken":
+			p, err := platform.NewPermissionAtID(bucketID, platform.WriteAction, platform.BucketsResourceType, orgID)
+			if err != nil {
+				return nil, err
+			}
+			return &platform.Authorization{
+				Status:      platform.Active,
+				OrgID:       orgID,
+				Permissions: []platform.Permission{*p},
+			}, nil
+		}
+		return nil, &platform.Error{Code: platform.ENotFound, Msg: "authorization not found"}
+	}
+
+	dbrpSvc := mock.NewDBRPMappingService()
+	dbrpSvc.FindFn = func(ctx context.Context, filter platform.DBRPMappingFilter) (*platform.DBRPMapping, error) {
+		if *filter.Cluster == LegacyCluster && *filter.Database == "otherdb" {
+			return &platform.DBRPMapping{
+				Cluster:         LegacyCluster,
+				Database:        "otherdb",
+				RetentionPolicy: "autogen",
+				Default:         true,
+				OrganizationID:  platform.ID(3),
+				BucketID:        platform.ID(4),
+			}, nil
+		}
+		if *filter.Cluster != LegacyCluster || *filter.Database != "mydb" {
+			return nil, nil
+		}
+		if filter.RetentionPolicy != nil && *filter.RetentionPolicy != "autogen" {
+			return nil, nil
+		}
+		return &platform.DBRPMapping{
+			Cluster:         LegacyCluster,
+			Database:        "mydb",
+			RetentionPolicy: "autogen",
+			Default:         true,
+			OrganizationID:  orgID,
+			BucketID:        bucketID,
+		}, nil
+	}
+
+	orgSvc := mock.NewOrganizationService()
+	orgSvc.FindOrganizationByIDF = func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+		return &platform.Organization{ID: id, Name: "org"}, nil
+	}
+
+	bucketSvc := mock.NewBucketService()
+	bucketSvc.FindBucketFn = func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+		return &platform.Bucket{ID: *filter.ID, OrgID: *filter.OrganizationID, Name: "bucket"}, nil
+	}
+
+	return NewLegacyHandler(&LegacyBackend{
+		Logger:             zap.NewNop(),
+		WriteEventRecorder: &eventRecorder{},
+		QueryEventRecorder: &eventRecorder{},
+
+		AuthorizationService: authSvc,
+		DBRPMappingService:   dbrpSvc,
+		BucketService:        bucketSvc,
+		OrganizationService:  orgSvc,
+		PointsWriter: writePointsFunc(func(ctx context.Context, points []models.Point) error {
+			*written = append(*written, points...)
+			return nil
+		}),
+		InfluxQLService: &querymock.ProxyQueryService{
+			QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
+				*queries = append(*queries, req)
+				_, err := io.WriteString(w, `{"results":[{"statement_id":0}]}`)
+				return flux.Statistics{}, err
+			},
+		},
+	})
+}
+
+func TestLegacyHandler_handleWrite(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		setAuth    func(r *http.Request)
+		statusCode int
+		written    int
+	}{
+		{
+			name:       "token",
+			url:        "/write?db=mydb&precision=s",
+			setAuth:    func(r *http.Request) { SetToken("mytoken", r) },
+			statusCode: http.StatusNoContent,
+			written:    1,
+		},
+		{
+			name:       "basic auth",
+			url:        "/write?db=mydb&rp=autogen",
+			setAuth:    func(r *http.Request) { r.SetBasicAuth("me", "mytoken") },
+			statusCode: http.StatusNoContent,
+			written:    1,
+		},
+		{
+			name:       "query parameters",
+			url:        "/write?db=mydb&u=me&p=mytoken",
+			setAuth:    func(r *http.Request) {},
+			statusCode: http.StatusNoContent,
+			written:    1,
+		},
+		{
+			name:       "invalid token",
+			url:        "/write?db=mydb",
+			setAuth:    func(r *http.Request) { r.SetBasicAuth("me", "wrong") },
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "no token",
+			url:        "/write?db=mydb",
+			setAuth:    func(r *http.Request) {},
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "unknown database",
+			url:        "/write?db=other",
+			setAuth:    func(r *http.Request) { SetToken("mytoken", r) },
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "unknown retention policy",
+			url:        "/write?db=mydb&rp=other",
+			setAuth:    func(r *http.Request) { SetToken("mytoken", r) },
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "invalid precision",
+			url:        "/write?db=mydb&precision=h",
+			setAuth:    func(r *http.Request) { SetToken("mytoken", r) },
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				written []models.Point
+				queries []*query.ProxyRequest
+			)
+			h := newTestLegacyHandler(t, &written, &queries)
+
+			r := httptest.NewRequest("POST", "http://any.url"+tt.url, strings.NewReader("cpu,host=a value=1 1"))
+			tt.setAuth(r)
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, tt.statusCode; got != want {
+				t.Fatalf("unexpected status code: got %d, want %d: %s", got, want, w.Body.String())
+			}
+			if got, want := len(written), tt.written; got != want {
+				t.Fatalf("unexpected number of points written: got %d, want %d", got, want)
+			}
+			if tt.written == 0 {
+				return
+			}
+
+			encoded := tsdb.EncodeName(1, 2)
+			if got, want := string(written[0].Name()), string(encoded[:]); got != want {
+				t.Errorf("unexpected measurement name: got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestLegacyHandler_handleWrite_autoCreateDBRP(t *testing.T) {
+	type wants struct {
+		statusCode int
+		bucket     string
+		retention  time.Duration
+		mapping    platform.DBRPMapping
+	}
+
+	tests := []struct {
+		name  string
+		url   string
+		wants wants
+	}{
+		{
+			name: "default retention policy",
+			url:  "/write?db=newdb",
+			wants: wants{
+				statusCode: http.StatusNoContent,
+				bucket:     "newdb/autogen",
+				mapping: platform.DBRPMapping{
+					Cluster:         LegacyCluster,
+					Database:        "newdb",
+					RetentionPolicy: "autogen",
+					Default:         true,
+					OrganizationID:  1,
+					BucketID:        10,
+				},
+			},
+		},
+		{
+			name: "registered retention policy",
+			url:  "/write?db=newdb&rp=week",
+			wants: wants{
+				statusCode: http.StatusNoContent,
+				bucket:     "newdb/week",
+				retention:  7 * 24 * time.Hour,
+				mapping: platform.DBRPMapping{
+					Cluster:         LegacyCluster,
+					Database:        "newdb",
+					RetentionPolicy: "week",
+					Default:         true,
+					OrganizationID:  1,
+					BucketID:        10,
+				},
+			},
+		},
+		{
+			name: "database with a default retention policy",
+			url:  "/write?db=mydb&rp=other",
+			wants: wants{
+				statusCode: http.StatusNoContent,
+				bucket:     "mydb/other",
+				mapping: platform.DBRPMapping{
+					Cluster:         LegacyCluster,
+					Database:        "mydb",
+					RetentionPolicy: "other",
+					Default:         false,
+					OrganizationID:  1,
+					BucketID:        10,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				written []models.Point
+				queries []*query.ProxyRequest
+			)
+			h := newTestLegacyHandler(t, &written, &queries)
+
+			ctx := context.Background()
+			dbrpSvc := inmem.NewService()
+			if err := dbrpSvc.Create(ctx, &platform.DBRPMapping{
+				Cluster:         LegacyCluster,
+				Database:        "mydb",
+				RetentionPolicy: "autogen",
+				Default:         true,
+				OrganizationID:  1,
+				BucketID:        2,
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			var created []*platform.Bucket
+			bucketSvc := mock.NewBucketService()
+			bucketSvc.CreateBucketFn = func(ctx context.Context, b *platform.Bucket) error {
+				b.ID = 10
+				created = append(created, b)
+				return nil
+			}
+
+			h.DBRPMappingService = dbrpSvc
+			h.BucketService = bucketSvc
+			h.AutoCreateDBRP = true
+			h.RetentionPolicies = map[string]time.Duration{"week": 7 * 24 * time.Hour}
+
+			r := httptest.NewRequest("POST", "http://any.url"+tt.url, strings.NewReader("cpu,host=a value=1 1"))
+			SetToken("mytoken", r)
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, tt.wants.statusCode; got != want {
+				t.Fatalf("unexpected status code: got %d, want %d: %s", got, want, w.Body.String())
+			}
+			if len(created) != 1 {
+				t.Fatalf("expected one bucket to be created, got %d", len(created))
+			}
+			if got, want := created[0].Name, tt.wants.bucket; got != want {
+				t.Errorf("unexpected bucket name: got %q, want %q", got, want)
+			}
+			if got, want := created[0].RetentionPeriod, tt.wants.retention; got != want {
+				t.Errorf("unexpected bucket retention: got %s, want %s", got, want)
+			}
+
+			m, err := dbrpSvc.FindBy(ctx, tt.wants.mapping.Cluster, tt.wants.mapping.Database, tt.wants.mapping.RetentionPolicy)
+			if err != nil {
+				t.Fatalf("failed to find dbrp mapping: %v", err)
+			}
+			if !m.Equal(&tt.wants.mapping) {
+				t.Errorf("unexpected dbrp mapping: got %+v, want %+v", m, tt.wants.mapping)
+			}
+			if len(written) != 1 {
+				t.Fatalf("expected one point to be written, got %d", len(written))
+			}
+		})
+	}
+}
+
+func TestLegacyHandler_handleQuery(t *testing.T) {
+	type wants struct {
+		statusCode  int
+		contentType string
+		db          string
+		rp          string
+		query       string
+		dialect     influxql.Dialect
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		accept string
+		token  string
+		wants  wants
+	}{
+		{
+			name:   "get",
+			method: "GET",
+			url:    "/query?db=mydb&q=SELECT+*+FROM+cpu",
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "application/json",
+				db:          "mydb",
+				query:       "SELECT * FROM cpu",
+			},
+		},
+		{
+			name:   "post form",
+			method: "POST",
+			url:    "/query",
+			body:   "db=mydb&rp=autogen&q=SELECT+*+FROM+cpu&epoch=ms&pretty=true",
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "application/json",
+				db:          "mydb",
+				rp:          "autogen",
+				query:       "SELECT * FROM cpu",
+				dialect:     influxql.Dialect{Encoding: influxql.JSONPretty, TimeFormat: influxql.Millisecond},
+			},
+		},
+		{
+			name:   "csv",
+			method: "GET",
+			url:    "/query?q=SELECT+*+FROM+mydb..cpu",
+			accept: "application/csv",
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "text/csv",
+				query:       "SELECT * FROM mydb..cpu",
+				dialect:     influxql.Dialect{Encoding: influxql.CSV},
+			},
+		},
+		{
+			name:   "missing query",
+			method: "GET",
+			url:    "/query?db=mydb",
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:   "invalid epoch",
+			method: "GET",
+			url:    "/query?db=mydb&q=SELECT+*+FROM+cpu&epoch=d",
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:   "unknown database",
+			method: "GET",
+			url:    "/query?db=other&q=SELECT+*+FROM+cpu",
+			wants: wants{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name:   "database of another organization",
+			method: "GET",
+			url:    "/query?db=otherdb&q=SELECT+*+FROM+cpu",
+			wants: wants{
+				statusCode: http.StatusForbidden,
+			},
+		},
+		{
+			name:   "no read permission",
+			method: "GET",
+			url:    "/query?db=mydb&q=SELECT+*+FROM+cpu",
+			token:  "writetoken",
+			wants: wants{
+				statusCode: http.StatusForbidden,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				written []models.Point
+				queries []*query.ProxyRequest
+			)
+			h := newTestLegacyHandler(t, &written, &queries)
+
+			r := httptest.NewRequest(tt.method, "http://any.url"+tt.url, strings.NewReader(tt.body))
+			if tt.body != "" {
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			token := tt.token
+			if token == "" {
+				token = "mytoken"
+			}
+			r.SetBasicAuth("me", token)
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, tt.wants.statusCode; got != want {
+				t.Fatalf("unexpected status code: got %d, want %d: %s", got, want, w.Body.String())
+			}
+
+			if tt.wants.statusCode != http.StatusOK {
+				if len(queries) != 0 {
+					t.Fatalf("query service should not have been called")
+				}
+				if !strings.Contains(w.Body.String(), `"error":`) {
+					t.Errorf("expected a 1.x error response, got %s", w.Body.String())
+				}
+				return
+			}
+
+			if got, want := w.Header().Get("Content-Type"), tt.wants.contentType; got != want {
+				t.Errorf("unexpected content type: got %q, want %q", got, want)
+			}
+			if len(queries) != 1 {
+				t.Fatalf("expected one query, got %d", len(queries))
+			}
+
+			req := queries[0]
+			if got, want := req.Request.OrganizationID, platform.ID(1); got != want {
+				t.Errorf("unexpected organization: got %s, want %s", got, want)
+			}
+			c, ok := req.Request.Compiler.(*influxql.Compiler)
+			if !ok {
+				t.Fatalf("unexpected compiler type %T", req.Request.Compiler)
+			}
+			if c.Cluster != LegacyCluster || c.DB != tt.wants.db || c.RP != tt.wants.rp || c.Query != tt.wants.query {
+				t.Errorf("unexpected compiler: got %+v", c)
+			}
+			if got, want := *req.Dialect.(*influxql.Dialect), tt.wants.dialect; got != want {
+				t.Errorf("unexpected dialect: got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
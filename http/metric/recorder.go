@@ -18,4 +18,5 @@ type Event struct {
 	RequestBytes  int
 	ResponseBytes int
 	Status        int
+	DroppedPoints int
 }
@@ -9,9 +9,10 @@ import (
 
 // PlatformHandler is a collection of all the service handlers.
 type PlatformHandler struct {
-	AssetHandler *AssetHandler
-	DocsHandler  http.HandlerFunc
-	APIHandler   http.Handler
+	AssetHandler  *AssetHandler
+	DocsHandler   http.HandlerFunc
+	APIHandler    http.Handler
+	LegacyHandler http.Handler
 }
 
 func setCORSResponseHeaders(w http.ResponseWriter, r *http.Request) {
@@ -40,9 +41,10 @@ func NewPlatformHandler(b *APIBackend) *PlatformHandler {
 	assetHandler.Path = b.AssetsPath
 
 	return &PlatformHandler{
-		AssetHandler: assetHandler,
-		DocsHandler:  Redoc("/api/v2/swagger.json"),
-		APIHandler:   h,
+		AssetHandler:  assetHandler,
+		DocsHandler:   Redoc("/api/v2/swagger.json"),
+		APIHandler:    h,
+		LegacyHandler: NewLegacyHandler(NewLegacyBackend(b)),
 	}
 }
 
@@ -58,6 +60,12 @@ func (h *PlatformHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Serve the InfluxDB 1.x compatible endpoints, which authenticate requests themselves.
+	if r.URL.Path == legacyWritePath || r.URL.Path == legacyQueryPath {
+		h.LegacyHandler.ServeHTTP(w, r)
+		return
+	}
+
 	// Serve the chronograf assets for any basepath that does not start with addressable parts
 	// of the platform API.
 	if !strings.HasPrefix(r.URL.Path, "/v1") &&
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/lang"
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/authorizer"
@@ -21,6 +22,7 @@ import (
 	"github.com/influxdata/influxdb/kv"
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/task/backend"
+	"github.com/influxdata/influxdb/task/backend/executor"
 	"github.com/influxdata/influxdb/task/options"
 	"github.com/julienschmidt/httprouter"
 	"go.uber.org/zap"
@@ -38,6 +40,9 @@ type TaskBackend struct {
 	LabelService               platform.LabelService
 	UserService                platform.UserService
 	BucketService              platform.BucketService
+
+	// QueryService executes the scripts of the tasks being tested.
+	QueryService query.AsyncQueryService
 }
 
 // NewTaskBackend returns a new instance of TaskBackend.
@@ -51,6 +56,7 @@ func NewTaskBackend(b *APIBackend) *TaskBackend {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		BucketService:              b.BucketService,
+		QueryService:               b.TaskQueryService,
 	}
 }
 
@@ -66,6 +72,7 @@ type TaskHandler struct {
 	LabelService               platform.LabelService
 	UserService                platform.UserService
 	BucketService              platform.BucketService
+	QueryService               query.AsyncQueryService
 }
 
 const (
@@ -80,6 +87,8 @@ const (
 	tasksIDRunsIDPath      = "/api/v2/tasks/:id/runs/:rid"
 	tasksIDRunsIDLogsPath  = "/api/v2/tasks/:id/runs/:rid/logs"
 	tasksIDRunsIDRetryPath = "/api/v2/tasks/:id/runs/:rid/retry"
+	tasksIDBackfillPath    = "/api/v2/tasks/:id/backfill"
+	tasksIDBackfillIDPath  = "/api/v2/tasks/:id/backfill/:bid"
 	tasksIDLabelsPath      = "/api/v2/tasks/:id/labels"
 	tasksIDLabelsIDPath    = "/api/v2/tasks/:id/labels/:lid"
 )
@@ -97,6 +106,7 @@ func NewTaskHandler(b *TaskBackend) *TaskHandler {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		BucketService:              b.BucketService,
+		QueryService:               b.QueryService,
 	}
 
 	h.HandlerFunc("GET", tasksPath, h.handleGetTasks)
@@ -105,6 +115,9 @@ func NewTaskHandler(b *TaskBackend) *TaskHandler {
 	h.HandlerFunc("GET", tasksIDPath, h.handleGetTask)
 	h.HandlerFunc("PATCH", tasksIDPath, h.handleUpdateTask)
 	h.HandlerFunc("DELETE", tasksIDPath, h.handleDeleteTask)
+	// httprouter does not allow a static path next to the :id wildcard,
+	// so POST /api/v2/tasks/test is routed through tasksIDPath.
+	h.HandlerFunc("POST", tasksIDPath, h.handlePostTaskID)
 
 	h.HandlerFunc("GET", tasksIDLogsPath, h.handleGetLogs)
 	h.HandlerFunc("GET", tasksIDRunsIDLogsPath, h.handleGetLogs)
@@ -137,6 +150,10 @@ func NewTaskHandler(b *TaskBackend) *TaskHandler {
 	h.HandlerFunc("POST", tasksIDRunsIDRetryPath, h.handleRetryRun)
 	h.HandlerFunc("DELETE", tasksIDRunsIDPath, h.handleCancelRun)
 
+	h.HandlerFunc("GET", tasksIDBackfillPath, h.handleGetBackfills)
+	h.HandlerFunc("POST", tasksIDBackfillPath, h.handlePostBackfill)
+	h.HandlerFunc("DELETE", tasksIDBackfillIDPath, h.handleCancelBackfill)
+
 	labelBackend := &LabelBackend{
 		Logger:       b.Logger.With(zap.String("handler", "label")),
 		LabelService: b.LabelService,
@@ -264,6 +281,42 @@ func newRunsResponse(rs []*platform.Run, taskID platform.ID) runsResponse {
 	return r
 }
 
+type backfillResponse struct {
+	Links map[string]string `json:"links"`
+	platform.Backfill
+}
+
+func newBackfillResponse(b platform.Backfill) backfillResponse {
+	return backfillResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/backfill/%s", b.TaskID, b.ID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", b.TaskID),
+			"runs": fmt.Sprintf("/api/v2/tasks/%s/runs", b.TaskID),
+		},
+		Backfill: b,
+	}
+}
+
+type backfillsResponse struct {
+	Links     map[string]string  `json:"links"`
+	Backfills []backfillResponse `json:"backfills"`
+}
+
+func newBackfillsResponse(bs []*platform.Backfill, taskID platform.ID) backfillsResponse {
+	r := backfillsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/backfill", taskID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", taskID),
+		},
+		Backfills: make([]backfillResponse, len(bs)),
+	}
+
+	for i := range bs {
+		r.Backfills[i] = newBackfillResponse(*bs[i])
+	}
+	return r
+}
+
 func (h *TaskHandler) handleGetTasks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -754,6 +807,129 @@ func decodeDeleteTaskRequest(ctx context.Context, r *http.Request) (*deleteTaskR
 	}, nil
 }
 
+// handlePostTaskID handles the POST requests of tasksIDPath, which are only
+// allowed on /api/v2/tasks/test.
+func (h *TaskHandler) handlePostTaskID(w http.ResponseWriter, r *http.Request) {
+	params := httprouter.ParamsFromContext(r.Context())
+	if params.ByName("id") != "test" {
+		w.Header().Set("Allow", "GET, PATCH, DELETE")
+		methodNotAllowedHandler(w, r)
+		return
+	}
+	h.handleTestTask(w, r)
+}
+
+// handleTestTask executes the script of a task as a run would, without
+// writing the data of its to() calls, and returns the tables it output.
+// Other functions with side effects are executed, see executor.DryRun.
+func (h *TaskHandler) handleTestTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeTestTaskRequest(ctx, r)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	tc := platform.TaskCreate{OrganizationID: req.OrganizationID, Organization: req.Organization}
+	if err := h.populateTaskCreateOrg(ctx, &tc); err != nil {
+		err = &platform.Error{
+			Err: err,
+			Msg: "could not identify organization",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EUnauthorized,
+			Msg:  "failed to get authorizer",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var auth *platform.Authorization
+	switch a := a.(type) {
+	case *platform.Authorization:
+		auth = a
+	case *platform.Session:
+		auth = a.EphemeralAuth(tc.OrganizationID)
+	default:
+		EncodeError(ctx, platform.ErrAuthorizerNotSupported, w)
+		return
+	}
+
+	now := time.Now()
+	if req.Now != nil {
+		now = *req.Now
+	}
+
+	res, err := executor.DryRun(ctx, h.QueryService, auth, tc.OrganizationID, req.Flux, now)
+	if err != nil {
+		err = &platform.Error{
+			Err: err,
+			Msg: "failed to test task",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newTestTaskResponse(res)); err != nil {
+		logEncodingError(h.logger, r, err)
+		return
+	}
+}
+
+type testTaskRequest struct {
+	Flux           string      `json:"flux"`
+	OrganizationID platform.ID `json:"orgID,omitempty"`
+	Organization   string      `json:"org,omitempty"`
+	Now            *time.Time  `json:"now,omitempty"`
+}
+
+func decodeTestTaskRequest(ctx context.Context, r *http.Request) (*testTaskRequest, error) {
+	var req testTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Flux == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "you must provide a flux script",
+		}
+	}
+
+	return &req, nil
+}
+
+// TestTaskResult is the outcome of the test of a task script.
+type TestTaskResult struct {
+	Options options.Options `json:"options"`
+	Now     time.Time       `json:"now"`
+	// Tables are the annotated CSV tables output by the script.
+	Tables     string          `json:"tables"`
+	Statistics flux.Statistics `json:"statistics"`
+}
+
+func newTestTaskResponse(res *executor.DryRunResult) *TestTaskResult {
+	return &TestTaskResult{
+		Options:    res.Options,
+		Now:        res.Now,
+		Tables:     res.Tables,
+		Statistics: res.Statistics,
+	}
+}
+
 func (h *TaskHandler) handleGetLogs(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -1053,6 +1229,161 @@ func decodeForceRunRequest(ctx context.Context, r *http.Request) (forceRunReques
 	}, nil
 }
 
+func (h *TaskHandler) handlePostBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostBackfillRequest(ctx, r)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	b, err := h.TaskService.BackfillTask(ctx, req.TaskID, req.Start, req.Stop)
+	if err != nil {
+		err := &platform.Error{
+			Err: err,
+			Msg: "failed to backfill task",
+		}
+		if err.Err == backend.ErrTaskNotFound {
+			err.Code = platform.ENotFound
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+	if err := encodeResponse(ctx, w, http.StatusCreated, newBackfillResponse(*b)); err != nil {
+		logEncodingError(h.logger, r, err)
+		return
+	}
+}
+
+type postBackfillRequest struct {
+	TaskID      platform.ID
+	Start, Stop int64
+}
+
+func decodePostBackfillRequest(ctx context.Context, r *http.Request) (*postBackfillRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("id")
+	if tid == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "you must provide a task ID",
+		}
+	}
+
+	var ti platform.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+
+	var req struct {
+		Start string `json:"start"`
+		Stop  string `json:"stop"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Start == "" || req.Stop == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "you must provide a start and a stop time",
+		}
+	}
+	start, err := time.Parse(time.RFC3339, req.Start)
+	if err != nil {
+		return nil, err
+	}
+	stop, err := time.Parse(time.RFC3339, req.Stop)
+	if err != nil {
+		return nil, err
+	}
+
+	return &postBackfillRequest{
+		TaskID: ti,
+		Start:  start.Unix(),
+		Stop:   stop.Unix(),
+	}, nil
+}
+
+func (h *TaskHandler) handleGetBackfills(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	var taskID platform.ID
+	if err := taskID.DecodeFromString(params.ByName("id")); err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	backfills, err := h.TaskService.FindBackfills(ctx, taskID)
+	if err != nil {
+		err := &platform.Error{
+			Err: err,
+			Msg: "failed to find backfills",
+		}
+		if err.Err == backend.ErrTaskNotFound {
+			err.Code = platform.ENotFound
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newBackfillsResponse(backfills, taskID)); err != nil {
+		logEncodingError(h.logger, r, err)
+		return
+	}
+}
+
+func (h *TaskHandler) handleCancelBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	var taskID, backfillID platform.ID
+	if err := taskID.DecodeFromString(params.ByName("id")); err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+	if err := backfillID.DecodeFromString(params.ByName("bid")); err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.TaskService.CancelBackfill(ctx, taskID, backfillID); err != nil {
+		err := &platform.Error{
+			Err: err,
+			Msg: "failed to cancel backfill",
+		}
+		if err.Err == backend.ErrTaskNotFound {
+			err.Code = platform.ENotFound
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *TaskHandler) handleGetRun(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -1824,6 +2155,172 @@ func (t TaskService) ForceRun(ctx context.Context, taskID platform.ID, scheduled
 	return &rs.Run, nil
 }
 
+// BackfillTask queues a run for each time within start and stop that the task is scheduled for.
+func (t TaskService) BackfillTask(ctx context.Context, taskID platform.ID, start, stop int64) (*platform.Backfill, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := newURL(t.Addr, taskIDBackfillPath(taskID))
+	if err != nil {
+		return nil, err
+	}
+
+	body := fmt.Sprintf(`{"start": %q, "stop": %q}`,
+		time.Unix(start, 0).UTC().Format(time.RFC3339),
+		time.Unix(stop, 0).UTC().Format(time.RFC3339),
+	)
+	req, err := http.NewRequest("POST", u.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(t.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	bs := &backfillResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(bs); err != nil {
+		return nil, err
+	}
+	return &bs.Backfill, nil
+}
+
+// FindBackfills returns the backfills of a task.
+func (t TaskService) FindBackfills(ctx context.Context, taskID platform.ID) ([]*platform.Backfill, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := newURL(t.Addr, taskIDBackfillPath(taskID))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(t.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var bs backfillsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&bs); err != nil {
+		return nil, err
+	}
+
+	backfills := make([]*platform.Backfill, len(bs.Backfills))
+	for i := range bs.Backfills {
+		backfills[i] = &bs.Backfills[i].Backfill
+	}
+	return backfills, nil
+}
+
+// CancelBackfill removes the queued runs of a backfill, and cancels its runs in progress.
+func (t TaskService) CancelBackfill(ctx context.Context, taskID, backfillID platform.ID) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := newURL(t.Addr, path.Join(taskIDBackfillPath(taskID), backfillID.String()))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	SetToken(t.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckError(resp)
+}
+
+// TestTask executes the task script for the organization at now, without writing
+// the data of its to() calls. If now is zero, the script is executed at the
+// current time of the server. Other functions of the script with side effects,
+// such as sql.to() or http.post(), are executed.
+func (t TaskService) TestTask(ctx context.Context, orgID platform.ID, org, script string, now time.Time) (*TestTaskResult, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := newURL(t.Addr, path.Join(tasksPath, "test"))
+	if err != nil {
+		return nil, err
+	}
+
+	tr := testTaskRequest{
+		Flux:           script,
+		OrganizationID: orgID,
+		Organization:   org,
+	}
+	if !now.IsZero() {
+		tr.Now = &now
+	}
+	b, err := json.Marshal(tr)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(t.Token, req)
+	tracing.InjectToHTTPRequest(span, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var res TestTaskResult
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
 func cancelPath(taskID, runID platform.ID) string {
 	return path.Join(taskID.String(), runID.String())
 }
@@ -1868,6 +2365,10 @@ func taskIDRunsPath(id platform.ID) string {
 	return path.Join(tasksPath, id.String(), "runs")
 }
 
+func taskIDBackfillPath(id platform.ID) string {
+	return path.Join(tasksPath, id.String(), "backfill")
+}
+
 func taskIDRunIDPath(taskID, runID platform.ID) string {
 	return path.Join(tasksPath, taskID.String(), "runs", runID.String())
 }
@@ -13,11 +13,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/influxdata/flux"
 	platform "github.com/influxdata/influxdb"
 	pcontext "github.com/influxdata/influxdb/context"
 	"github.com/influxdata/influxdb/inmem"
 	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/query"
 	_ "github.com/influxdata/influxdb/query/builtin"
+	querymock "github.com/influxdata/influxdb/query/mock"
 	"github.com/influxdata/influxdb/task/backend"
 	platformtesting "github.com/influxdata/influxdb/testing"
 	"github.com/julienschmidt/httprouter"
@@ -1213,6 +1216,67 @@ func TestTaskHandler_CreateTaskWithOrgName(t *testing.T) {
 	}
 }
 
+func TestTaskHandler_TestTask(t *testing.T) {
+	authz := &platform.Authorization{ID: 1, OrgID: 2, UserID: 3, Permissions: platform.OperPermissions()}
+	now := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	taskBackend := NewMockTaskBackend(t)
+	taskBackend.QueryService = &querymock.AsyncQueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.Query, error) {
+			if !query.IsDryRun(ctx) {
+				t.Error("expected the task to be tested without writing data")
+			}
+			if req.OrganizationID != authz.OrgID || req.Authorization != authz {
+				t.Errorf("unexpected request %+v", req)
+			}
+			q := querymock.NewQuery()
+			q.Cancel()
+			return q, nil
+		},
+	}
+	h := NewTaskHandler(taskBackend)
+
+	const script = `option task = {name: "x", every: 1m} from(bucket: "a") |> range(start: -1m) |> to(bucket: "b")`
+	b, err := json.Marshal(testTaskRequest{Flux: script, OrganizationID: authz.OrgID, Now: &now})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest("POST", "http://localhost:9999/api/v2/tasks/test", bytes.NewReader(b)).WithContext(
+		pcontext.SetAuthorizer(context.Background(), authz),
+	)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status OK, got %v: %s", res.StatusCode, body)
+	}
+
+	var got TestTaskResult
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Options.Name != "x" || got.Options.Every.String() != "1m" || !got.Now.Equal(now) {
+		t.Fatalf("unexpected result %+v", got)
+	}
+
+	// Any other POST on a task is not allowed.
+	r = httptest.NewRequest("POST", "http://localhost:9999/api/v2/tasks/0000000000000001", nil).WithContext(
+		pcontext.SetAuthorizer(context.Background(), authz),
+	)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected status method not allowed, got %v", w.Code)
+	}
+}
+
 func TestTaskHandler_Sessions(t *testing.T) {
 	// Common setup to get a working base for using tasks.
 	i := inmem.NewService()
@@ -3,10 +3,13 @@ package http
 import (
 	"compress/gzip"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/influxdata/influxdb/http/metric"
@@ -30,6 +33,8 @@ type WriteBackend struct {
 	PointsWriter        storage.PointsWriter
 	BucketService       platform.BucketService
 	OrganizationService platform.OrganizationService
+
+	MaxBodySize int64
 }
 
 // NewWriteBackend returns a new instance of WriteBackend.
@@ -41,6 +46,8 @@ func NewWriteBackend(b *APIBackend) *WriteBackend {
 		PointsWriter:        b.PointsWriter,
 		BucketService:       b.BucketService,
 		OrganizationService: b.OrganizationService,
+
+		MaxBodySize: b.WriteMaxBodySize,
 	}
 }
 
@@ -56,14 +63,32 @@ type WriteHandler struct {
 	PointsWriter storage.PointsWriter
 
 	EventRecorder metric.EventRecorder
+
+	// MaxBodySize is the maximum size in bytes of the body of a write request, after
+	// decompression. Zero means the size is not limited.
+	MaxBodySize int64
+
+	// BatchSize is the size in bytes of the batches of line protocol the body is
+	// parsed and written in. Zero means DefaultWriteBatchSize.
+	BatchSize int
 }
 
+// DefaultWriteBatchSize is the default size in bytes of the batches of line protocol
+// the body of a write request is parsed and written in.
+const DefaultWriteBatchSize = 1 << 20
+
 const (
 	writePath            = "/api/v2/write"
 	errInvalidGzipHeader = "gzipped HTTP body contains an invalid header"
 	errInvalidPrecision  = "invalid precision; valid precision units are ns, us, ms, and s"
+
+	// writeRetryAfter is the number of seconds a client should wait before retrying
+	// a write which failed because the storage engine is busy.
+	writeRetryAfter = 1
 )
 
+var errWriteBodyTooLarge = errors.New("request body is too large")
+
 // NewWriteHandler creates a new handler at /api/v2/write to receive line protocol.
 func NewWriteHandler(b *WriteBackend) *WriteHandler {
 	h := &WriteHandler{
@@ -74,6 +99,7 @@ func NewWriteHandler(b *WriteBackend) *WriteHandler {
 		BucketService:       b.BucketService,
 		OrganizationService: b.OrganizationService,
 		EventRecorder:       b.WriteEventRecorder,
+		MaxBodySize:         b.MaxBodySize,
 	}
 
 	h.HandlerFunc("POST", writePath, h.handleWrite)
@@ -90,7 +116,7 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 	// TODO(desa): I really don't like how we're recording the usage metrics here
 	// Ideally this will be moved when we solve https://github.com/influxdata/influxdb/issues/13403
 	var orgID platform.ID
-	var requestBytes int
+	var requestBytes, droppedPoints int
 	sw := newStatusResponseWriter(w)
 	w = sw
 	defer func() {
@@ -100,6 +126,7 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 			RequestBytes:  requestBytes,
 			ResponseBytes: sw.responseBytes,
 			Status:        sw.code(),
+			DroppedPoints: droppedPoints,
 		})
 	}()
 
@@ -207,50 +234,300 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.MaxBodySize > 0 && r.ContentLength > h.MaxBodySize {
+		encodeWriteBodyTooLarge(w, h.MaxBodySize)
+		return
+	}
+
 	// TODO(jeff): we should be publishing with the org and bucket instead of
 	// parsing, rewriting, and publishing, but the interface isn't quite there yet.
 	// be sure to remove this when it is there!
-	data, err := ioutil.ReadAll(in)
-	if err != nil {
-		logger.Error("Error reading body", zap.Error(err))
-		EncodeError(ctx, &platform.Error{
-			Code: platform.EInternal,
-			Op:   "http/handleWrite",
-			Msg:  fmt.Sprintf("unable to read data: %v", err),
-			Err:  err,
-		}, w)
-		return
+	encoded := tsdb.EncodeName(org.ID, bucket.ID)
+	batcher := &lineBatcher{
+		writer:    h.PointsWriter,
+		logger:    logger,
+		mm:        models.EscapeMeasurement(encoded[:]),
+		now:       time.Now(),
+		precision: req.Precision,
+		retention: bucket.RetentionPeriod,
+		rejected:  newRejectedLines(),
 	}
-	requestBytes = len(data)
+	rejected := batcher.rejected
+
+	// The body is parsed and written in batches of whole lines rather than being
+	// read into memory at once. Lines which cannot be parsed, and points which
+	// cannot be written, are rejected while every other point is written.
+	body := &writeBodyReader{r: in, max: h.MaxBodySize}
+	lines := models.NewLineReader(body, h.batchSize())
+	var written int
+	for {
+		block, first, err := lines.Next()
+		requestBytes = int(body.n)
+		if err == io.EOF {
+			break
+		} else if err == errWriteBodyTooLarge {
+			encodeWriteBodyTooLarge(w, h.MaxBodySize)
+			return
+		} else if err != nil {
+			logger.Error("Error reading body", zap.Error(err))
+			EncodeError(ctx, &platform.Error{
+				Code: platform.EInternal,
+				Op:   "http/handleWrite",
+				Msg:  fmt.Sprintf("unable to read data: %v", err),
+				Err:  err,
+			}, w)
+			return
+		}
 
-	encoded := tsdb.EncodeName(org.ID, bucket.ID)
-	mm := models.EscapeMeasurement(encoded[:])
-	points, err := models.ParsePointsWithPrecision(data, mm, time.Now(), req.Precision)
-	if err != nil {
-		logger.Error("Error parsing points", zap.Error(err))
-		EncodeError(ctx, &platform.Error{
-			Code: platform.EInvalid,
-			Op:   "http/handleWrite",
-			Msg:  fmt.Sprintf("unable to parse points: %v", err),
-			Err:  err,
-		}, w)
-		return
+		n, err := batcher.write(ctx, block, first)
+		written += n
+		droppedPoints = rejected.points
+		if err == storage.ErrCacheFull {
+			w.Header().Set("Retry-After", strconv.Itoa(writeRetryAfter))
+			EncodeError(ctx, err, w)
+			return
+		} else if err != nil {
+			logger.Error("Error writing points", zap.Error(err))
+			EncodeError(ctx, &platform.Error{
+				Code: platform.EInternal,
+				Op:   "http/handleWrite",
+				Msg:  fmt.Sprintf("unable to write points to database: %v", err),
+				Err:  err,
+			}, w)
+			return
+		}
 	}
 
-	if err := h.PointsWriter.WritePoints(ctx, points); err != nil {
-		logger.Error("Error writing points", zap.Error(err))
-		EncodeError(ctx, &platform.Error{
-			Code: platform.EInternal,
-			Op:   "http/handleWrite",
-			Msg:  fmt.Sprintf("unable to write points to database: %v", err),
-			Err:  err,
-		}, w)
+	if rejected.points > 0 {
+		encodeWriteError(w, rejected.code(), rejected.message(written), rejected.errors())
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *WriteHandler) batchSize() int {
+	if h.BatchSize > 0 {
+		return h.BatchSize
+	}
+	return DefaultWriteBatchSize
+}
+
+// lineBatcher parses and writes the batches of lines of a write request to a bucket,
+// recording the lines which are rejected.
+type lineBatcher struct {
+	writer    storage.PointsWriter
+	logger    *zap.Logger
+	mm        []byte
+	now       time.Time
+	precision string
+	retention time.Duration
+	rejected  *rejectedLines
+}
+
+// write parses and writes the points of a block of lines starting at line first. It
+// returns the number of points written.
+func (b *lineBatcher) write(ctx context.Context, block []byte, first int) (int, error) {
+	rejected := b.rejected
+
+	points, lines, lineErrs := models.ParseLinesWithPrecision(block, b.mm, b.now, b.precision)
+	for _, err := range lineErrs {
+		err.Line += first - 1
+		rejected.addParseError(err)
+	}
+	for i := range lines {
+		lines[i] += first - 1
+	}
+
+	if b.retention > 0 {
+		min := b.now.Add(-b.retention)
+
+		j := 0
+		for i, pt := range points {
+			if pt.Time().Before(min) {
+				rejected.add(lines[i], fmt.Sprintf("timestamp %s is outside of the retention period of the bucket", pt.Time().UTC().Format(time.RFC3339Nano)))
+				continue
+			}
+			points[j], lines[j] = pt, lines[i]
+			j++
+		}
+		points, lines = points[:j], lines[:j]
+	}
+
+	if len(points) == 0 {
+		return 0, nil
+	}
+
+	err := b.writer.WritePoints(ctx, points)
+	pwe, ok := err.(tsdb.PartialWriteError)
+	if !ok {
+		if err != nil {
+			return 0, err
+		}
+		return len(points), nil
+	}
+
+	// The points which were not dropped have been written.
+	b.logger.Info("Points dropped from write", zap.String("reason", pwe.Reason), zap.Int("dropped", pwe.Dropped))
+	if len(pwe.DroppedPoints) == 0 {
+		// The dropped points are unknown, so their lines cannot be reported.
+		rejected.addUnknown(pwe.Dropped, pwe.Reason)
+		return len(points) - pwe.Dropped, nil
+	}
+
+	pointLines := make(map[models.Point]int, len(points))
+	for i, pt := range points {
+		pointLines[pt] = lines[i]
+	}
+	for _, dp := range pwe.DroppedPoints {
+		rejected.add(pointLines[dp.Point], dp.Reason)
+	}
+	return len(points) - len(pwe.DroppedPoints), nil
+}
+
+// writeBodyReader counts the bytes read from the body of a write request and, if max
+// is positive, fails rather than returning more than max bytes.
+type writeBodyReader struct {
+	r   io.Reader
+	n   int64
+	max int64
+}
+
+func (r *writeBodyReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	if r.max > 0 && r.n > r.max {
+		n -= int(r.n - r.max)
+		r.n = r.max
+		return n, errWriteBodyTooLarge
+	}
+	return n, err
+}
+
+// writeBodyTooLargeError is the response to a write request with a body larger than
+// the maximum body size.
+type writeBodyTooLargeError struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	MaxLength int64  `json:"maxLength"`
+}
+
+// encodeWriteBodyTooLarge writes the error response of a write request with a body
+// larger than max bytes. The points of the body read before exceeding the limit may
+// have been written.
+func encodeWriteBodyTooLarge(w http.ResponseWriter, max int64) {
+	w.Header().Set(PlatformErrorCodeHeader, platform.EInvalid)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+	b, _ := json.Marshal(&writeBodyTooLargeError{
+		Code:      platform.EInvalid,
+		Message:   errWriteBodyTooLarge.Error(),
+		MaxLength: max,
+	})
+	_, _ = w.Write(b)
+}
+
+// writeLineError is a line of a write request which was rejected.
+type writeLineError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// writeError is the response to a write request of which some lines were rejected.
+type writeError struct {
+	Code    string           `json:"code"`
+	Message string           `json:"message"`
+	Op      string           `json:"op"`
+	Line    int              `json:"line"`
+	Errors  []writeLineError `json:"errors"`
+}
+
+// rejectedLines collects the rejected lines of a write request and the reason
+// each line was first rejected for.
+type rejectedLines struct {
+	reasons map[int]string
+	points  int
+	invalid bool
+
+	// unknown is the reason points were dropped from lines which are unknown.
+	unknown string
+}
+
+func newRejectedLines() *rejectedLines {
+	return &rejectedLines{reasons: make(map[int]string)}
+}
+
+// add rejects a point parsed from line.
+func (r *rejectedLines) add(line int, reason string) {
+	r.points++
+	if _, ok := r.reasons[line]; !ok {
+		r.reasons[line] = reason
+	}
+}
+
+// addParseError rejects a line which could not be parsed.
+func (r *rejectedLines) addParseError(err *models.LineError) {
+	r.invalid = true
+	r.add(err.Line, err.Error())
+}
+
+// addUnknown rejects n points of lines which are unknown.
+func (r *rejectedLines) addUnknown(n int, reason string) {
+	r.points += n
+	if r.unknown == "" {
+		r.unknown = reason
+	}
+}
+
+func (r *rejectedLines) len() int { return len(r.reasons) }
+
+// message returns the message of the response to a write request which wrote n points.
+func (r *rejectedLines) message(n int) string {
+	if r.unknown != "" {
+		return fmt.Sprintf("partial write: %d points written, %d lines rejected: %s", n, r.len(), r.unknown)
+	}
+	return fmt.Sprintf("partial write: %d points written, %d lines rejected", n, r.len())
+}
+
+// code returns EInvalid if any line could not be parsed, as the request itself is
+// malformed, or EUnprocessableEntity if only its points were rejected.
+func (r *rejectedLines) code() string {
+	if r.invalid {
+		return platform.EInvalid
+	}
+	return platform.EUnprocessableEntity
+}
+
+// errors returns the errors of the rejected lines, ordered by line.
+func (r *rejectedLines) errors() []writeLineError {
+	errs := make([]writeLineError, 0, len(r.reasons))
+	for line, reason := range r.reasons {
+		errs = append(errs, writeLineError{Line: line, Message: reason})
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+	return errs
+}
+
+// encodeWriteError writes the error response of a write request which rejected
+// some lines. The Line field holds the first rejected line.
+func encodeWriteError(w http.ResponseWriter, code, msg string, errs []writeLineError) {
+	e := &writeError{
+		Code:    code,
+		Message: msg,
+		Op:      "http/handleWrite",
+		Errors:  errs,
+	}
+	if len(errs) > 0 {
+		e.Line = errs[0].Line
+	}
+
+	w.Header().Set(PlatformErrorCodeHeader, code)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCodePlatformError[code])
+	b, _ := json.Marshal(e)
+	_, _ = w.Write(b)
+}
+
 func decodeWriteRequest(ctx context.Context, r *http.Request) (*postWriteRequest, error) {
 	qp := r.URL.Query()
 	p := qp.Get("precision")
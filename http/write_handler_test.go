@@ -3,14 +3,23 @@ package http
 import (
 	"compress/gzip"
 	"context"
+	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/http/metric"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
 )
 
 func TestWriteService_Write(t *testing.T) {
@@ -69,3 +78,190 @@ func TestWriteService_Write(t *testing.T) {
 		})
 	}
 }
+
+type writePointsFunc func(context.Context, []models.Point) error
+
+func (f writePointsFunc) WritePoints(ctx context.Context, points []models.Point) error {
+	return f(ctx, points)
+}
+
+type eventRecorder struct {
+	events []metric.Event
+}
+
+func (r *eventRecorder) Record(ctx context.Context, e metric.Event) {
+	r.events = append(r.events, e)
+}
+
+func TestWriteHandler_handleWrite(t *testing.T) {
+	type wants struct {
+		statusCode    int
+		code          string
+		errors        []writeLineError
+		written       int
+		droppedPoints int
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		retention   time.Duration
+		batchSize   int
+		maxBodySize int64
+		writeErr    func(points []models.Point) error
+		wants       wants
+	}{
+		{
+			name: "all lines written",
+			body: "m,t=a f=1 1\nm,t=b f=2 2\n",
+			wants: wants{
+				statusCode: http.StatusNoContent,
+				written:    2,
+			},
+		},
+		{
+			name: "invalid lines rejected",
+			body: "m,t=a f=1 1\nm,t=a f= 2\n\nm,t=b f=2 3\nm,t=c\n",
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+				code:       platform.EInvalid,
+				errors: []writeLineError{
+					{Line: 2, Message: "unable to parse 'm,t=a f= 2': missing field value"},
+					{Line: 5, Message: "unable to parse 'm,t=c': missing fields"},
+				},
+				written:       2,
+				droppedPoints: 2,
+			},
+		},
+		{
+			name:      "invalid lines rejected across batches",
+			body:      "m,t=a f=1 1\nm,t=a f= 2\n\nm,t=b f=\"multi\nline\" 3\nm,t=c\nm,t=d f=4 4\n",
+			batchSize: 8,
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+				code:       platform.EInvalid,
+				errors: []writeLineError{
+					{Line: 2, Message: "unable to parse 'm,t=a f= 2': missing field value"},
+					{Line: 6, Message: "unable to parse 'm,t=c': missing fields"},
+				},
+				written:       3,
+				droppedPoints: 2,
+			},
+		},
+		{
+			name:        "body too large",
+			body:        "m,t=a f=1 1\nm,t=b f=2 2\n",
+			maxBodySize: 12,
+			wants: wants{
+				statusCode: http.StatusRequestEntityTooLarge,
+			},
+		},
+		{
+			name:      "points outside of retention period rejected",
+			body:      "m,t=a f=1 1\nm,t=b f=2\n",
+			retention: time.Hour,
+			wants: wants{
+				statusCode: http.StatusUnprocessableEntity,
+				code:       platform.EUnprocessableEntity,
+				errors: []writeLineError{
+					{Line: 1, Message: "timestamp 1970-01-01T00:00:00.000000001Z is outside of the retention period of the bucket"},
+				},
+				written:       1,
+				droppedPoints: 1,
+			},
+		},
+		{
+			name: "points dropped by storage rejected",
+			body: "m,t=a f=1 1\nm,t=b f=\"a\" 2\nm,t=c f=3 3\n",
+			writeErr: func(points []models.Point) error {
+				return tsdb.PartialWriteError{
+					Reason:        "field type conflict",
+					Dropped:       1,
+					DroppedPoints: []tsdb.DroppedPoint{{Point: points[1], Reason: "field type conflict"}},
+				}
+			},
+			wants: wants{
+				statusCode: http.StatusUnprocessableEntity,
+				code:       platform.EUnprocessableEntity,
+				errors: []writeLineError{
+					{Line: 2, Message: "field type conflict"},
+				},
+				written:       3,
+				droppedPoints: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgSvc := mock.NewOrganizationService()
+			orgSvc.FindOrganizationByIDF = func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+				return &platform.Organization{ID: id, Name: "org"}, nil
+			}
+
+			bucketSvc := mock.NewBucketService()
+			bucketSvc.FindBucketFn = func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+				return &platform.Bucket{ID: *filter.ID, OrgID: *filter.OrganizationID, Name: "bucket", RetentionPeriod: tt.retention}, nil
+			}
+
+			var written int
+			recorder := &eventRecorder{}
+			h := NewWriteHandler(&WriteBackend{
+				Logger:             zap.NewNop(),
+				WriteEventRecorder: recorder,
+				PointsWriter: writePointsFunc(func(ctx context.Context, points []models.Point) error {
+					written += len(points)
+					if tt.writeErr != nil {
+						return tt.writeErr(points)
+					}
+					return nil
+				}),
+				BucketService:       bucketSvc,
+				OrganizationService: orgSvc,
+				MaxBodySize:         tt.maxBodySize,
+			})
+			h.BatchSize = tt.batchSize
+
+			r := httptest.NewRequest("POST", "http://any.url/api/v2/write?org=0000000000000001&bucket=0000000000000002", strings.NewReader(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{
+				Status:      platform.Active,
+				OrgID:       1,
+				Permissions: platform.OperPermissions(),
+			}))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, tt.wants.statusCode; got != want {
+				t.Fatalf("unexpected status code: got %d, want %d: %s", got, want, w.Body.String())
+			}
+			if got, want := written, tt.wants.written; got != want {
+				t.Errorf("unexpected number of points written: got %d, want %d", got, want)
+			}
+			if len(recorder.events) != 1 {
+				t.Fatalf("expected one event to be recorded, got %d", len(recorder.events))
+			}
+			if got, want := recorder.events[0].DroppedPoints, tt.wants.droppedPoints; got != want {
+				t.Errorf("unexpected number of dropped points: got %d, want %d", got, want)
+			}
+
+			if tt.wants.statusCode == http.StatusNoContent || tt.wants.statusCode == http.StatusRequestEntityTooLarge {
+				return
+			}
+
+			var resp writeError
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := resp.Code, tt.wants.code; got != want {
+				t.Errorf("unexpected error code: got %q, want %q", got, want)
+			}
+			if got, want := resp.Line, tt.wants.errors[0].Line; got != want {
+				t.Errorf("unexpected line: got %d, want %d", got, want)
+			}
+			if !reflect.DeepEqual(resp.Errors, tt.wants.errors) {
+				t.Errorf("unexpected line errors: got %+v, want %+v", resp.Errors, tt.wants.errors)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package inmem
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"path"
 
@@ -10,7 +9,7 @@ import (
 )
 
 var (
-	errDBRPMappingNotFound = fmt.Errorf("dbrp mapping not found")
+	errDBRPMappingNotFound = platform.ErrDBRPNotFound
 )
 
 func encodeDBRPMappingKey(cluster, db, rp string) string {
@@ -124,18 +123,44 @@ func (s *Service) Create(ctx context.Context, m *platform.DBRPMapping) error {
 	existing, err := s.loadDBRPMapping(ctx, m.Cluster, m.Database, m.RetentionPolicy)
 	if err != nil {
 		if err == errDBRPMappingNotFound {
+			if err := s.unsetDefaultDBRPMapping(ctx, m); err != nil {
+				return err
+			}
 			return s.PutDBRPMapping(ctx, m)
 		}
 		return err
 	}
 
 	if !existing.Equal(m) {
-		return errors.New("dbrp mapping already exists")
+		return platform.ErrDBRPAlreadyExists
 	}
 
 	return s.PutDBRPMapping(ctx, m)
 }
 
+// unsetDefaultDBRPMapping makes the previous default mapping of the database of m
+// a regular mapping if m is the new default.
+func (s *Service) unsetDefaultDBRPMapping(ctx context.Context, m *platform.DBRPMapping) error {
+	if !m.Default {
+		return nil
+	}
+
+	defaults, err := s.filterDBRPMappings(ctx, func(d *platform.DBRPMapping) bool {
+		return d.Cluster == m.Cluster && d.Database == m.Database && d.Default
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, d := range defaults {
+		d.Default = false
+		if err := s.PutDBRPMapping(ctx, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // PutDBRPMapping sets dbrpMapping with the current ID.
 func (s *Service) PutDBRPMapping(ctx context.Context, m *platform.DBRPMapping) error {
 	k := encodeDBRPMappingKey(m.Cluster, m.Database, m.RetentionPolicy)
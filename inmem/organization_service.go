@@ -230,6 +230,10 @@ func (s *Service) UpdateOrganization(ctx context.Context, id platform.ID, upd pl
 		o.Name = *upd.Name
 	}
 
+	if upd.MaxSeries != nil {
+		o.MaxSeries = *upd.MaxSeries
+	}
+
 	s.organizationKV.Store(o.ID.String(), o)
 
 	return o, nil
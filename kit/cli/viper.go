@@ -83,6 +83,14 @@ func BindOptions(cmd *cobra.Command, opts []Opt) {
 			cmd.Flags().IntVar(destP, o.Flag, d, o.Desc)
 			mustBindPFlag(o.Flag, cmd)
 			*destP = viper.GetInt(o.Flag)
+		case *int64:
+			var d int64
+			if o.Default != nil {
+				d = o.Default.(int64)
+			}
+			cmd.Flags().Int64Var(destP, o.Flag, d, o.Desc)
+			mustBindPFlag(o.Flag, cmd)
+			*destP = viper.GetInt64(o.Flag)
 		case *bool:
 			var d bool
 			if o.Default != nil {
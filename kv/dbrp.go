@@ -0,0 +1,368 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	influxdb "github.com/influxdata/influxdb"
+)
+
+var (
+	dbrpBucket      = []byte("dbrpv1")
+	dbrpIndexBucket = []byte("dbrpdefaultindexv1")
+)
+
+var _ influxdb.DBRPMappingService = (*Service)(nil)
+
+func (s *Service) initializeDBRPMappings(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(dbrpBucket); err != nil {
+		return err
+	}
+	if _, err := tx.Bucket(dbrpIndexBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// dbrpKey is the key of the mapping of the cluster, database and retention policy.
+// Names of clusters, databases and retention policies never contain a slash.
+func dbrpKey(cluster, db, rp string) []byte {
+	return []byte(strings.Join([]string{cluster, db, rp}, "/"))
+}
+
+// dbrpDefaultKey is the key of the default mapping of the cluster and database in
+// the index.
+func dbrpDefaultKey(cluster, db string) []byte {
+	return []byte(strings.Join([]string{cluster, db}, "/"))
+}
+
+// FindBy returns the dbrp mapping for the cluster, db and rp.
+func (s *Service) FindBy(ctx context.Context, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+	var m *influxdb.DBRPMapping
+	err := s.kv.View(ctx, func(tx Tx) error {
+		dbrp, err := s.findDBRPMappingBy(ctx, tx, cluster, db, rp)
+		if err != nil {
+			return err
+		}
+		m = dbrp
+		return nil
+	})
+
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpFindDBRPMappingBy,
+			Err: err,
+		}
+	}
+
+	return m, nil
+}
+
+func (s *Service) findDBRPMappingBy(ctx context.Context, tx Tx, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+	b, err := tx.Bucket(dbrpBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := b.Get(dbrpKey(cluster, db, rp))
+	if IsNotFound(err) {
+		return nil, influxdb.ErrDBRPNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var m influxdb.DBRPMapping
+	if err := json.Unmarshal(v, &m); err != nil {
+		return nil, &influxdb.Error{
+			Err: err,
+		}
+	}
+
+	return &m, nil
+}
+
+// findDefaultDBRPMapping returns the default mapping of the cluster and database.
+func (s *Service) findDefaultDBRPMapping(ctx context.Context, tx Tx, cluster, db string) (*influxdb.DBRPMapping, error) {
+	idx, err := tx.Bucket(dbrpIndexBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	rp, err := idx.Get(dbrpDefaultKey(cluster, db))
+	if IsNotFound(err) {
+		return nil, influxdb.ErrDBRPNotFound
+	}
+	if err != nil {
+		return nil, UnexpectedIndexError(err)
+	}
+
+	return s.findDBRPMappingBy(ctx, tx, cluster, db, string(rp))
+}
+
+// Find returns the first dbrp mapping that matches filter.
+func (s *Service) Find(ctx context.Context, filter influxdb.DBRPMappingFilter) (*influxdb.DBRPMapping, error) {
+	var m *influxdb.DBRPMapping
+	err := s.kv.View(ctx, func(tx Tx) error {
+		dbrp, err := s.findDBRPMapping(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		m = dbrp
+		return nil
+	})
+
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpFindDBRPMapping,
+			Err: err,
+		}
+	}
+
+	return m, nil
+}
+
+func (s *Service) findDBRPMapping(ctx context.Context, tx Tx, filter influxdb.DBRPMappingFilter) (*influxdb.DBRPMapping, error) {
+	// Filters by key, or by the default of a database, are looked up directly.
+	if filter.Cluster != nil && filter.Database != nil {
+		if filter.RetentionPolicy != nil {
+			m, err := s.findDBRPMappingBy(ctx, tx, *filter.Cluster, *filter.Database, *filter.RetentionPolicy)
+			if err != nil {
+				return nil, err
+			}
+			if filter.Default != nil && *filter.Default != m.Default {
+				return nil, influxdb.ErrDBRPNotFound
+			}
+			return m, nil
+		}
+		if filter.Default != nil && *filter.Default {
+			return s.findDefaultDBRPMapping(ctx, tx, *filter.Cluster, *filter.Database)
+		}
+	}
+
+	ms, err := s.findDBRPMappings(ctx, tx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(ms) == 0 {
+		return nil, influxdb.ErrDBRPNotFound
+	}
+
+	return ms[0], nil
+}
+
+// FindMany returns a list of dbrp mappings that match filter and the total count of matching dbrp mappings.
+func (s *Service) FindMany(ctx context.Context, filter influxdb.DBRPMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.DBRPMapping, int, error) {
+	ms := []*influxdb.DBRPMapping{}
+	err := s.kv.View(ctx, func(tx Tx) error {
+		dbrps, err := s.findDBRPMappings(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		ms = dbrps
+		return nil
+	})
+
+	if err != nil {
+		return nil, 0, &influxdb.Error{
+			Op:  influxdb.OpFindDBRPMappings,
+			Err: err,
+		}
+	}
+
+	n := len(ms)
+	if len(opt) > 0 {
+		ms = pageDBRPMappings(ms, opt[0])
+	}
+
+	return ms, n, nil
+}
+
+func pageDBRPMappings(ms []*influxdb.DBRPMapping, opt influxdb.FindOptions) []*influxdb.DBRPMapping {
+	if opt.Offset > 0 {
+		if opt.Offset >= len(ms) {
+			return []*influxdb.DBRPMapping{}
+		}
+		ms = ms[opt.Offset:]
+	}
+	if opt.Limit > 0 && opt.Limit < len(ms) {
+		ms = ms[:opt.Limit]
+	}
+	return ms
+}
+
+func (s *Service) findDBRPMappings(ctx context.Context, tx Tx, filter influxdb.DBRPMappingFilter) ([]*influxdb.DBRPMapping, error) {
+	ms := []*influxdb.DBRPMapping{}
+
+	// Mappings are sorted by cluster, database and retention policy, so the scan
+	// may start at the first mapping of the cluster or database of the filter.
+	var prefix string
+	if filter.Cluster != nil {
+		prefix = *filter.Cluster + "/"
+		if filter.Database != nil {
+			prefix += *filter.Database + "/"
+		}
+	}
+
+	b, err := tx.Bucket(dbrpBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	cur, err := b.Cursor()
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := cur.Seek([]byte(prefix)); k != nil && strings.HasPrefix(string(k), prefix); k, v = cur.Next() {
+		m := &influxdb.DBRPMapping{}
+		if err := json.Unmarshal(v, m); err != nil {
+			return nil, err
+		}
+		if filterDBRPMappingFn(filter)(m) {
+			ms = append(ms, m)
+		}
+	}
+
+	return ms, nil
+}
+
+func filterDBRPMappingFn(filter influxdb.DBRPMappingFilter) func(m *influxdb.DBRPMapping) bool {
+	return func(m *influxdb.DBRPMapping) bool {
+		return (filter.Cluster == nil || *filter.Cluster == m.Cluster) &&
+			(filter.Database == nil || *filter.Database == m.Database) &&
+			(filter.RetentionPolicy == nil || *filter.RetentionPolicy == m.RetentionPolicy) &&
+			(filter.Default == nil || *filter.Default == m.Default)
+	}
+}
+
+// Create creates a new dbrp mapping. Creating a mapping identical to an existing
+// one is not an error. If the mapping is the default of its database, it replaces
+// the previous default mapping of the database.
+func (s *Service) Create(ctx context.Context, m *influxdb.DBRPMapping) error {
+	if err := m.Validate(); err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   influxdb.OpCreateDBRPMapping,
+			Msg:  err.Error(),
+		}
+	}
+
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		return s.createDBRPMapping(ctx, tx, m)
+	})
+	if err != nil {
+		return &influxdb.Error{
+			Op:  influxdb.OpCreateDBRPMapping,
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) createDBRPMapping(ctx context.Context, tx Tx, m *influxdb.DBRPMapping) error {
+	existing, err := s.findDBRPMappingBy(ctx, tx, m.Cluster, m.Database, m.RetentionPolicy)
+	if err == nil {
+		if !existing.Equal(m) {
+			return influxdb.ErrDBRPAlreadyExists
+		}
+		return nil
+	}
+	if influxdb.ErrorCode(err) != influxdb.ENotFound {
+		return err
+	}
+
+	if m.Default {
+		previous, err := s.findDefaultDBRPMapping(ctx, tx, m.Cluster, m.Database)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+			return err
+		}
+		if previous != nil {
+			previous.Default = false
+			if err := s.putDBRPMapping(ctx, tx, previous); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.putDBRPMapping(ctx, tx, m)
+}
+
+// putDBRPMapping stores the mapping, and indexes it if it is the default of its database.
+func (s *Service) putDBRPMapping(ctx context.Context, tx Tx, m *influxdb.DBRPMapping) error {
+	v, err := json.Marshal(m)
+	if err != nil {
+		return &influxdb.Error{
+			Err: err,
+		}
+	}
+
+	b, err := tx.Bucket(dbrpBucket)
+	if err != nil {
+		return err
+	}
+	if err := b.Put(dbrpKey(m.Cluster, m.Database, m.RetentionPolicy), v); err != nil {
+		return err
+	}
+
+	if !m.Default {
+		return nil
+	}
+
+	idx, err := tx.Bucket(dbrpIndexBucket)
+	if err != nil {
+		return err
+	}
+	if err := idx.Put(dbrpDefaultKey(m.Cluster, m.Database), []byte(m.RetentionPolicy)); err != nil {
+		return UnexpectedIndexError(err)
+	}
+	return nil
+}
+
+// Delete removes a dbrp mapping. Deleting a mapping that does not exist is not an error.
+func (s *Service) Delete(ctx context.Context, cluster, db, rp string) error {
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		return s.deleteDBRPMapping(ctx, tx, cluster, db, rp)
+	})
+	if err != nil {
+		return &influxdb.Error{
+			Op:  influxdb.OpDeleteDBRPMapping,
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) deleteDBRPMapping(ctx context.Context, tx Tx, cluster, db, rp string) error {
+	m, err := s.findDBRPMappingBy(ctx, tx, cluster, db, rp)
+	if influxdb.ErrorCode(err) == influxdb.ENotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	b, err := tx.Bucket(dbrpBucket)
+	if err != nil {
+		return err
+	}
+	if err := b.Delete(dbrpKey(cluster, db, rp)); err != nil {
+		return err
+	}
+
+	if !m.Default {
+		return nil
+	}
+
+	idx, err := tx.Bucket(dbrpIndexBucket)
+	if err != nil {
+		return err
+	}
+	if err := idx.Delete(dbrpDefaultKey(cluster, db)); err != nil {
+		return UnexpectedIndexError(err)
+	}
+	return nil
+}
@@ -401,6 +401,10 @@ func (s *Service) updateOrganization(ctx context.Context, tx Tx, id influxdb.ID,
 		}
 	}
 
+	if upd.MaxSeries != nil {
+		o.MaxSeries = *upd.MaxSeries
+	}
+
 	if err := s.appendOrganizationEventToLog(ctx, tx, o.ID, organizationUpdatedEvent); err != nil {
 		return nil, &influxdb.Error{
 			Err: err,
@@ -1,9 +1,11 @@
 package kv
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -52,8 +54,47 @@ var (
 		Msg:  "run already queued",
 		Code: influxdb.EConflict,
 	}
+
+	// ErrBackfillNotFound error when backfill cant be found
+	ErrBackfillNotFound = &influxdb.Error{
+		Code: influxdb.ENotFound,
+		Msg:  "backfill not found",
+	}
+
+	ErrInvalidBackfillRange = &influxdb.Error{
+		Msg:  "backfill stop must not be before its start",
+		Code: influxdb.EInvalid,
+	}
+
+	ErrBackfillTooLarge = &influxdb.Error{
+		Msg:  fmt.Sprintf("cannot backfill more than %d runs at once", maxBackfillRuns),
+		Code: influxdb.EInvalid,
+	}
+
+	ErrTriggerTaskNotFound = &influxdb.Error{
+		Msg:  "trigger task not found",
+		Code: influxdb.EInvalid,
+	}
+
+	ErrTriggerTaskOrgMismatch = &influxdb.Error{
+		Msg:  "trigger task must belong to the same organization",
+		Code: influxdb.EInvalid,
+	}
+
+	ErrTaskTriggerCycle = &influxdb.Error{
+		Msg:  "task trigger would create a cycle",
+		Code: influxdb.EInvalid,
+	}
+
+	ErrTaskTriggersTasks = &influxdb.Error{
+		Msg:  "cannot delete a task triggering other tasks",
+		Code: influxdb.EConflict,
+	}
 )
 
+// maxBackfillRuns is the maximum number of runs queued by a single backfill.
+const maxBackfillRuns = 10000
+
 func ErrInternalTaskServiceError(err error) *influxdb.Error {
 	return &influxdb.Error{
 		Code: influxdb.EInternal,
@@ -99,8 +140,11 @@ func ErrTaskOptionParse(err error) *influxdb.Error {
 //   <taskID>/<runID>: run data storage
 //   <taskID>/manualRuns: list of runs to run manually
 //   <taskID>/latestCompleted: run data for the latest completed run of a task
+//   <taskID>/backfills: list of the backfills of a task
 // taskIndexBucket
 //   <orgID>/<taskID>: index for tasks by org
+// taskTriggerIndexBucket
+//   <triggerTaskID>/<taskID>: index for tasks by the task triggering them
 
 // We may want to add a <taskName>/<taskID> index to allow us to look up tasks by task name.
 
@@ -108,6 +152,8 @@ var (
 	taskBucket      = []byte("tasksv1")
 	taskRunBucket   = []byte("taskRunsv1")
 	taskIndexBucket = []byte("taskIndexsv1")
+
+	taskTriggerIndexBucket = []byte("taskTriggerIndexv1")
 )
 
 var _ influxdb.TaskService = (*Service)(nil)
@@ -123,6 +169,9 @@ func (s *Service) initializeTasks(ctx context.Context, tx Tx) error {
 	if _, err := tx.Bucket(taskIndexBucket); err != nil {
 		return err
 	}
+	if _, err := tx.Bucket(taskTriggerIndexBucket); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -574,6 +623,12 @@ func (s *Service) createTask(ctx context.Context, tx Tx, tc influxdb.TaskCreate)
 		task.Offset = opt.Offset.String()
 	}
 
+	if tc.TriggerTaskID.Valid() {
+		if err := s.setTaskTrigger(ctx, tx, task, tc.TriggerTaskID); err != nil {
+			return nil, err
+		}
+	}
+
 	taskBucket, err := tx.Bucket(taskBucket)
 	if err != nil {
 		return nil, ErrUnexpectedTaskBucketErr(err)
@@ -683,6 +738,12 @@ func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd inf
 		task.LatestCompleted = *upd.LatestCompleted
 	}
 
+	if upd.TriggerTaskID != nil {
+		if err := s.setTaskTrigger(ctx, tx, task, *upd.TriggerTaskID); err != nil {
+			return nil, err
+		}
+	}
+
 	task.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
 	// save the updated task
 	bucket, err := tx.Bucket(taskBucket)
@@ -740,6 +801,20 @@ func (s *Service) deleteTask(ctx context.Context, tx Tx, id influxdb.ID) error {
 		return err
 	}
 
+	// the tasks triggered by the task would never run again
+	triggered, err := s.triggeredTaskIDs(ctx, tx, task.ID)
+	if err != nil {
+		return err
+	}
+	if len(triggered) > 0 {
+		return ErrTaskTriggersTasks
+	}
+
+	// remove the trigger index
+	if err := s.setTaskTrigger(ctx, tx, task, influxdb.InvalidID()); err != nil {
+		return err
+	}
+
 	// remove the orgs index
 	orgKey, err := taskOrgKey(task.OrganizationID, task.ID)
 	if err != nil {
@@ -760,6 +835,16 @@ func (s *Service) deleteTask(ctx context.Context, tx Tx, id influxdb.ID) error {
 		return ErrUnexpectedTaskBucketErr(err)
 	}
 
+	// remove the backfills
+	backfillsKey, err := taskBackfillsKey(task.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := runBucket.Delete(backfillsKey); err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+
 	// remove the runs
 	runs, _, err := s.findRuns(ctx, tx, influxdb.RunFilter{Task: task.ID})
 	if err != nil {
@@ -1105,6 +1190,429 @@ func (s *Service) forceRun(ctx context.Context, tx Tx, taskID influxdb.ID, sched
 	return r, nil
 }
 
+// BackfillTask queues a run for each time within the unix timestamps start and stop that the task is scheduled for.
+// The times already queued are skipped.
+func (s *Service) BackfillTask(ctx context.Context, taskID influxdb.ID, start, stop int64) (*influxdb.Backfill, error) {
+	var b *influxdb.Backfill
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		backfill, err := s.backfillTask(ctx, tx, taskID, start, stop)
+		if err != nil {
+			return err
+		}
+		b = backfill
+		return nil
+	})
+	return b, err
+}
+
+func (s *Service) backfillTask(ctx context.Context, tx Tx, taskID influxdb.ID, start, stop int64) (*influxdb.Backfill, error) {
+	if stop < start {
+		return nil, ErrInvalidBackfillRange
+	}
+
+	task, err := s.findTaskByID(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	times, err := scheduledTimes(task, start, stop)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := s.manualRuns(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	queued := make(map[string]bool, len(runs))
+	for _, run := range runs {
+		queued[run.ScheduledFor] = true
+	}
+
+	requestedAt := time.Now().UTC().Format(time.RFC3339)
+	b := &influxdb.Backfill{
+		ID:          s.IDGenerator.ID(),
+		TaskID:      taskID,
+		Status:      influxdb.BackfillStatusActive,
+		Start:       time.Unix(start, 0).UTC().Format(time.RFC3339),
+		Stop:        time.Unix(stop, 0).UTC().Format(time.RFC3339),
+		RequestedAt: requestedAt,
+	}
+	for _, t := range times {
+		scheduledFor := t.Format(time.RFC3339)
+		if queued[scheduledFor] {
+			continue
+		}
+		runs = append(runs, &influxdb.Run{
+			ID:           s.IDGenerator.ID(),
+			TaskID:       taskID,
+			Status:       backend.RunScheduled.String(),
+			RequestedAt:  requestedAt,
+			ScheduledFor: scheduledFor,
+			BackfillID:   b.ID,
+			Log:          []influxdb.Log{},
+		})
+		b.Runs++
+	}
+	b.Queued = b.Runs
+	if b.Runs == 0 {
+		b.Status = influxdb.BackfillStatusDone
+	}
+
+	if err := s.putManualRuns(ctx, tx, taskID, runs); err != nil {
+		return nil, err
+	}
+
+	backfills, err := s.backfills(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.putBackfills(ctx, tx, taskID, append(backfills, b)); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// scheduledTimes returns the times within the unix timestamps start and stop that the task is scheduled for.
+func scheduledTimes(task *influxdb.Task, start, stop int64) ([]time.Time, error) {
+	sch, err := cron.Parse(task.EffectiveCron())
+	if err != nil {
+		return nil, ErrTaskTimeParse(err)
+	}
+
+	from := time.Unix(start, 0).UTC().Add(-time.Second)
+	// Align to the every option, as runs created by createNextRun are.
+	if strings.HasPrefix(task.EffectiveCron(), "@every ") {
+		every := options.Duration{}
+		if err := every.Parse(strings.TrimPrefix(task.EffectiveCron(), "@every ")); err != nil {
+			return nil, ErrTaskTimeParse(err)
+		}
+		everyDur, err := every.DurationFrom(from)
+		if err != nil {
+			return nil, ErrTaskTimeParse(err)
+		}
+		from = from.Truncate(everyDur)
+	}
+
+	var times []time.Time
+	end := time.Unix(stop, 0).UTC()
+	for t := sch.Next(from); !t.IsZero() && !t.After(end); t = sch.Next(t) {
+		if len(times) == maxBackfillRuns {
+			return nil, ErrBackfillTooLarge
+		}
+		times = append(times, t.UTC())
+	}
+	return times, nil
+}
+
+// FindBackfills returns the backfills of a task.
+func (s *Service) FindBackfills(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Backfill, error) {
+	var backfills []*influxdb.Backfill
+	err := s.kv.View(ctx, func(tx Tx) error {
+		bs, err := s.findBackfills(ctx, tx, taskID)
+		if err != nil {
+			return err
+		}
+		backfills = bs
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return backfills, nil
+}
+
+func (s *Service) findBackfills(ctx context.Context, tx Tx, taskID influxdb.ID) ([]*influxdb.Backfill, error) {
+	if _, err := s.findTaskByID(ctx, tx, taskID); err != nil {
+		return nil, err
+	}
+
+	backfills, err := s.backfills(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[influxdb.ID]*influxdb.Backfill, len(backfills))
+	for _, b := range backfills {
+		b.Queued, b.Running = 0, 0
+		byID[b.ID] = b
+	}
+
+	// count the runs of each backfill not yet finished
+	queued, err := s.manualRuns(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	for _, run := range queued {
+		if b, ok := byID[run.BackfillID]; ok {
+			b.Queued++
+		}
+	}
+
+	running, err := s.currentlyRunning(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	for _, run := range running {
+		if b, ok := byID[run.BackfillID]; ok {
+			b.Running++
+		}
+	}
+
+	for _, b := range backfills {
+		if b.Status == influxdb.BackfillStatusActive && b.Queued+b.Running == 0 {
+			b.Status = influxdb.BackfillStatusDone
+		}
+	}
+
+	return backfills, nil
+}
+
+// CancelBackfill removes the queued runs of a backfill.
+// The runs of the backfill in progress are left to the scheduler to cancel.
+func (s *Service) CancelBackfill(ctx context.Context, taskID, backfillID influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		return s.cancelBackfill(ctx, tx, taskID, backfillID)
+	})
+}
+
+func (s *Service) cancelBackfill(ctx context.Context, tx Tx, taskID, backfillID influxdb.ID) error {
+	backfills, err := s.backfills(ctx, tx, taskID)
+	if err != nil {
+		return err
+	}
+
+	var found bool
+	for _, b := range backfills {
+		if b.ID == backfillID {
+			b.Status = influxdb.BackfillStatusCanceled
+			found = true
+		}
+	}
+	if !found {
+		return ErrBackfillNotFound
+	}
+
+	runs, err := s.manualRuns(ctx, tx, taskID)
+	if err != nil {
+		return err
+	}
+
+	remaining := runs[:0]
+	for _, run := range runs {
+		if run.BackfillID != backfillID {
+			remaining = append(remaining, run)
+		}
+	}
+
+	if err := s.putManualRuns(ctx, tx, taskID, remaining); err != nil {
+		return err
+	}
+	return s.putBackfills(ctx, tx, taskID, backfills)
+}
+
+func (s *Service) backfills(ctx context.Context, tx Tx, taskID influxdb.ID) ([]*influxdb.Backfill, error) {
+	b, err := tx.Bucket(taskRunBucket)
+	if err != nil {
+		return nil, ErrUnexpectedTaskBucketErr(err)
+	}
+	key, err := taskBackfillsKey(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	backfills := []*influxdb.Backfill{}
+	val, err := b.Get(key)
+	if err != nil {
+		if err == ErrKeyNotFound {
+			return backfills, nil
+		}
+		return nil, ErrUnexpectedTaskBucketErr(err)
+	}
+	if err := json.Unmarshal(val, &backfills); err != nil {
+		return nil, ErrInternalTaskServiceError(err)
+	}
+
+	return backfills, nil
+}
+
+func (s *Service) putBackfills(ctx context.Context, tx Tx, taskID influxdb.ID, backfills []*influxdb.Backfill) error {
+	b, err := tx.Bucket(taskRunBucket)
+	if err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+	key, err := taskBackfillsKey(taskID)
+	if err != nil {
+		return err
+	}
+
+	val, err := json.Marshal(backfills)
+	if err != nil {
+		return ErrInternalTaskServiceError(err)
+	}
+	if err := b.Put(key, val); err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+	return nil
+}
+
+func (s *Service) putManualRuns(ctx context.Context, tx Tx, taskID influxdb.ID, runs []*influxdb.Run) error {
+	b, err := tx.Bucket(taskRunBucket)
+	if err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+	key, err := taskManualRunKey(taskID)
+	if err != nil {
+		return err
+	}
+
+	val, err := json.Marshal(runs)
+	if err != nil {
+		return ErrInternalTaskServiceError(err)
+	}
+	if err := b.Put(key, val); err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+	return nil
+}
+
+// setTaskTrigger sets the task triggering the task, or removes its trigger if triggerID is invalid.
+// The task must be saved afterwards.
+func (s *Service) setTaskTrigger(ctx context.Context, tx Tx, task *influxdb.Task, triggerID influxdb.ID) error {
+	// walk up the chain of the triggers of the trigger task, which must not lead back to the task
+	seen := make(map[influxdb.ID]bool)
+	for id := triggerID; id.Valid() && !seen[id]; {
+		if id == task.ID {
+			return ErrTaskTriggerCycle
+		}
+		seen[id] = true
+
+		t, err := s.findTaskByID(ctx, tx, id)
+		if err != nil {
+			if err == backend.ErrTaskNotFound && id == triggerID {
+				return ErrTriggerTaskNotFound
+			}
+			return err
+		}
+		if t.OrganizationID != task.OrganizationID {
+			return ErrTriggerTaskOrgMismatch
+		}
+		id = t.TriggerTaskID
+	}
+
+	b, err := tx.Bucket(taskTriggerIndexBucket)
+	if err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+
+	if task.TriggerTaskID.Valid() {
+		key, err := taskTriggerKey(task.TriggerTaskID, task.ID)
+		if err != nil {
+			return err
+		}
+		if err := b.Delete(key); err != nil {
+			return ErrUnexpectedTaskBucketErr(err)
+		}
+	}
+
+	if triggerID.Valid() {
+		key, err := taskTriggerKey(triggerID, task.ID)
+		if err != nil {
+			return err
+		}
+		taskKey, err := taskKey(task.ID)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(key, taskKey); err != nil {
+			return ErrUnexpectedTaskBucketErr(err)
+		}
+	}
+
+	task.TriggerTaskID = triggerID
+	return nil
+}
+
+// triggeredTaskIDs returns the IDs of the tasks triggered by the task.
+func (s *Service) triggeredTaskIDs(ctx context.Context, tx Tx, taskID influxdb.ID) ([]influxdb.ID, error) {
+	b, err := tx.Bucket(taskTriggerIndexBucket)
+	if err != nil {
+		return nil, ErrUnexpectedTaskBucketErr(err)
+	}
+
+	c, err := b.Cursor()
+	if err != nil {
+		return nil, ErrUnexpectedTaskBucketErr(err)
+	}
+
+	prefix, err := taskKey(taskID)
+	if err != nil {
+		return nil, err
+	}
+	prefix = append(prefix, '/')
+
+	var ids []influxdb.ID
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		var id influxdb.ID
+		if err := id.Decode(v); err != nil {
+			return nil, ErrInvalidTaskID
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// queueTriggeredRuns queues a run of the active tasks triggered by the task of the run,
+// scheduled for the same time.
+func (s *Service) queueTriggeredRuns(ctx context.Context, tx Tx, run *influxdb.Run) error {
+	ids, err := s.triggeredTaskIDs(ctx, tx, run.TaskID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		task, err := s.findTaskByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if task.Status != string(backend.TaskActive) {
+			continue
+		}
+
+		runs, err := s.manualRuns(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		queued := false
+		for _, r := range runs {
+			if r.ScheduledFor == run.ScheduledFor {
+				queued = true
+				break
+			}
+		}
+		if queued {
+			continue
+		}
+
+		runs = append(runs, &influxdb.Run{
+			ID:           s.IDGenerator.ID(),
+			TaskID:       id,
+			Status:       backend.RunScheduled.String(),
+			RequestedAt:  time.Now().UTC().Format(time.RFC3339),
+			ScheduledFor: run.ScheduledFor,
+			Log:          []influxdb.Log{},
+		})
+		if err := s.putManualRuns(ctx, tx, id, runs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // CreateNextRun creates the earliest needed run scheduled no later than the given Unix timestamp now.
 // Internally, the Store should rely on the underlying task's StoreTaskMeta to create the next run.
 func (s *Service) CreateNextRun(ctx context.Context, taskID influxdb.ID, now int64) (backend.RunCreation, error) {
@@ -1200,6 +1708,11 @@ func (s *Service) createNextRun(ctx context.Context, tx Tx, taskID influxdb.ID,
 		return rc, nil
 	}
 
+	// a triggered task only runs its queued runs
+	if task.TriggerTaskID.Valid() {
+		return backend.RunCreation{}, backend.RunNotYetDueError{DueAt: math.MaxInt64}
+	}
+
 	// get the latest completed and the latest currently running run's time
 	// the earliest it could have been completed is "created at"
 	latestCompleted, err := time.Parse(time.RFC3339, task.CreatedAt)
@@ -1353,7 +1866,7 @@ func (s *Service) currentlyRunning(ctx context.Context, tx Tx, taskID influxdb.I
 		if k == nil || !strings.HasPrefix(string(k), string(taskKey)) {
 			break
 		}
-		if strings.HasSuffix(string(k), "manualRuns") || strings.HasSuffix(string(k), "latestCompleted") {
+		if strings.HasSuffix(string(k), "manualRuns") || strings.HasSuffix(string(k), "latestCompleted") || strings.HasSuffix(string(k), "backfills") {
 			k, v = c.Next()
 			continue
 		}
@@ -1471,6 +1984,12 @@ func (s *Service) finishRun(ctx context.Context, tx Tx, taskID, runID influxdb.I
 		}
 	}
 
+	if r.Status == backend.RunSuccess.String() {
+		if err := s.queueTriggeredRuns(ctx, tx, r); err != nil {
+			return nil, err
+		}
+	}
+
 	// remove run
 	key, err := taskRunKey(taskID, runID)
 	if err != nil {
@@ -1508,6 +2027,11 @@ func (s *Service) nextDueRun(ctx context.Context, tx Tx, taskID influxdb.ID) (in
 		return 0, err
 	}
 
+	// a triggered task is never due on its own schedule
+	if task.TriggerTaskID.Valid() {
+		return math.MaxInt64, nil
+	}
+
 	latestCompleted, err := time.Parse(time.RFC3339, task.LatestCompleted)
 	if err != nil {
 		return 0, err
@@ -1573,6 +2097,44 @@ func (s *Service) updateRunState(ctx context.Context, tx Tx, taskID, runID influ
 	return nil
 }
 
+// UpdateRunStatistics sets the statistics of the query of the run.
+func (s *Service) UpdateRunStatistics(ctx context.Context, taskID, runID influxdb.ID, stats influxdb.RunStatistics) error {
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		return s.updateRunStatistics(ctx, tx, taskID, runID, stats)
+	})
+	return err
+}
+
+func (s *Service) updateRunStatistics(ctx context.Context, tx Tx, taskID, runID influxdb.ID, stats influxdb.RunStatistics) error {
+	// find run
+	run, err := s.findRunByID(ctx, tx, taskID, runID)
+	if err != nil {
+		return err
+	}
+	run.Statistics = &stats
+
+	// save run
+	b, err := tx.Bucket(taskRunBucket)
+	if err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+
+	runBytes, err := json.Marshal(run)
+	if err != nil {
+		return ErrInternalTaskServiceError(err)
+	}
+
+	runKey, err := taskRunKey(taskID, run.ID)
+	if err != nil {
+		return err
+	}
+	if err := b.Put(runKey, runBytes); err != nil {
+		return ErrUnexpectedTaskBucketErr(err)
+	}
+
+	return nil
+}
+
 // AddRunLog adds a log line to the run.
 func (s *Service) AddRunLog(ctx context.Context, taskID, runID influxdb.ID, when time.Time, log string) error {
 	err := s.kv.Update(ctx, func(tx Tx) error {
@@ -1679,6 +2241,27 @@ func taskManualRunKey(taskID influxdb.ID) ([]byte, error) {
 	return []byte(string(encodedID) + "/manualRuns"), nil
 }
 
+func taskBackfillsKey(taskID influxdb.ID) ([]byte, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, ErrInvalidTaskID
+	}
+	return []byte(string(encodedID) + "/backfills"), nil
+}
+
+func taskTriggerKey(triggerID, taskID influxdb.ID) ([]byte, error) {
+	encodedTriggerID, err := triggerID.Encode()
+	if err != nil {
+		return nil, ErrInvalidTaskID
+	}
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, ErrInvalidTaskID
+	}
+
+	return []byte(string(encodedTriggerID) + "/" + string(encodedID)), nil
+}
+
 func taskOrgKey(orgID, taskID influxdb.ID) ([]byte, error) {
 	encodedOrgID, err := orgID.Encode()
 	if err != nil {
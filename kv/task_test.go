@@ -3,9 +3,14 @@ package kv_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
 	"github.com/influxdata/influxdb/kv"
 	_ "github.com/influxdata/influxdb/query/builtin"
+	"github.com/influxdata/influxdb/task/backend"
 	"github.com/influxdata/influxdb/task/servicetest"
 )
 
@@ -71,3 +76,334 @@ func TestBoltTaskService(t *testing.T) {
 		"transactional",
 	)
 }
+
+func TestTaskService_Backfill(t *testing.T) {
+	store, close, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	service := kv.NewService(store)
+	ctx := context.Background()
+	if err := service.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing task service: %v", err)
+	}
+
+	u := &influxdb.User{Name: t.Name() + "-user"}
+	if err := service.CreateUser(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	o := &influxdb.Organization{Name: t.Name() + "-org"}
+	if err := service.CreateOrganization(ctx, o); err != nil {
+		t.Fatal(err)
+	}
+	authz := &influxdb.Authorization{
+		OrgID:       o.ID,
+		UserID:      u.ID,
+		Permissions: influxdb.OperPermissions(),
+	}
+	if err := service.CreateAuthorization(ctx, authz); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx = icontext.SetAuthorizer(ctx, authz)
+	task, err := service.CreateTask(ctx, influxdb.TaskCreate{
+		OrganizationID: o.ID,
+		Token:          authz.Token,
+		Flux:           `option task = {name: "downsample", every: 1h} from(bucket: "a") |> range(start: -1h) |> to(bucket: "b", orgID: "000000000000000a")`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at := func(s string) int64 {
+		t.Helper()
+		ts, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts.Unix()
+	}
+	scheduledFor := func() []string {
+		t.Helper()
+		runs, err := service.ManualRuns(ctx, task.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var times []string
+		for _, r := range runs {
+			times = append(times, r.ScheduledFor)
+		}
+		return times
+	}
+
+	// The runs of the first backfill are aligned to the schedule of the task.
+	first, err := service.BackfillTask(ctx, task.ID, at("2019-01-01T00:30:00Z"), at("2019-01-01T03:00:00Z"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Runs != 3 || first.Status != influxdb.BackfillStatusActive {
+		t.Fatalf("unexpected backfill %+v", first)
+	}
+
+	// The times already queued are skipped.
+	second, err := service.BackfillTask(ctx, task.ID, at("2019-01-01T02:00:00Z"), at("2019-01-01T04:00:00Z"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Runs != 1 {
+		t.Fatalf("expected 1 run queued, got %d", second.Runs)
+	}
+
+	exp := []string{"2019-01-01T01:00:00Z", "2019-01-01T02:00:00Z", "2019-01-01T03:00:00Z", "2019-01-01T04:00:00Z"}
+	if got := scheduledFor(); !cmp.Equal(got, exp) {
+		t.Fatalf("unexpected queued runs -got/+exp\n%s", cmp.Diff(got, exp))
+	}
+
+	// Start the first run of the first backfill.
+	rc, err := service.CreateNextRun(ctx, task.ID, at("2019-01-01T00:00:00Z"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.Created.Now != at("2019-01-01T01:00:00Z") {
+		t.Fatalf("unexpected run created for %d", rc.Created.Now)
+	}
+
+	if err := service.CancelBackfill(ctx, task.ID, first.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := scheduledFor(), []string{"2019-01-01T04:00:00Z"}; !cmp.Equal(got, exp) {
+		t.Fatalf("unexpected queued runs -got/+exp\n%s", cmp.Diff(got, exp))
+	}
+
+	backfills, err := service.FindBackfills(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backfills) != 2 {
+		t.Fatalf("expected 2 backfills, got %d", len(backfills))
+	}
+	if b := backfills[0]; b.ID != first.ID || b.Status != influxdb.BackfillStatusCanceled || b.Queued != 0 || b.Running != 1 {
+		t.Fatalf("unexpected first backfill %+v", b)
+	}
+	if b := backfills[1]; b.ID != second.ID || b.Status != influxdb.BackfillStatusActive || b.Queued != 1 || b.Running != 0 {
+		t.Fatalf("unexpected second backfill %+v", b)
+	}
+
+	if _, err := service.BackfillTask(ctx, task.ID, at("2019-01-02T00:00:00Z"), at("2019-01-01T00:00:00Z")); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected an invalid range error, got %v", err)
+	}
+	if err := service.CancelBackfill(ctx, task.ID, influxdb.ID(1)); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestTaskService_Trigger(t *testing.T) {
+	store, close, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	service := kv.NewService(store)
+	ctx := context.Background()
+	if err := service.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing task service: %v", err)
+	}
+
+	u := &influxdb.User{Name: t.Name() + "-user"}
+	if err := service.CreateUser(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	o := &influxdb.Organization{Name: t.Name() + "-org"}
+	if err := service.CreateOrganization(ctx, o); err != nil {
+		t.Fatal(err)
+	}
+	authz := &influxdb.Authorization{
+		OrgID:       o.ID,
+		UserID:      u.ID,
+		Permissions: influxdb.OperPermissions(),
+	}
+	if err := service.CreateAuthorization(ctx, authz); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx = icontext.SetAuthorizer(ctx, authz)
+	createTask := func(name string, triggerID influxdb.ID) *influxdb.Task {
+		t.Helper()
+		task, err := service.CreateTask(ctx, influxdb.TaskCreate{
+			OrganizationID: o.ID,
+			Token:          authz.Token,
+			Flux:           `option task = {name: "` + name + `", every: 1h} from(bucket: "a") |> range(start: -1h) |> to(bucket: "b", orgID: "000000000000000a")`,
+			TriggerTaskID:  triggerID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return task
+	}
+	raw := createTask("raw", 0)
+	rollup := createTask("rollup", raw.ID)
+	hourly := createTask("hourly", rollup.ID)
+
+	if rollup.TriggerTaskID != raw.ID {
+		t.Fatalf("expected rollup to be triggered by %s, got %s", raw.ID, rollup.TriggerTaskID)
+	}
+
+	// A trigger must not lead back to the task.
+	for _, id := range []influxdb.ID{raw.ID, hourly.ID} {
+		if _, err := service.UpdateTask(ctx, raw.ID, influxdb.TaskUpdate{TriggerTaskID: &id}); err != kv.ErrTaskTriggerCycle {
+			t.Fatalf("expected a cycle error, got %v", err)
+		}
+	}
+	missing := influxdb.ID(1)
+	if _, err := service.UpdateTask(ctx, raw.ID, influxdb.TaskUpdate{TriggerTaskID: &missing}); err != kv.ErrTriggerTaskNotFound {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	// A triggered task does not run on its own schedule.
+	if _, err := service.CreateNextRun(ctx, rollup.ID, time.Now().Add(24*time.Hour).Unix()); err == nil {
+		t.Fatal("expected no run of the triggered task to be due")
+	}
+
+	finishRun := func(scheduledFor time.Time, status backend.RunStatus) {
+		t.Helper()
+		if _, err := service.ForceRun(ctx, raw.ID, scheduledFor.Unix()); err != nil {
+			t.Fatal(err)
+		}
+		rc, err := service.CreateNextRun(ctx, raw.ID, scheduledFor.Unix())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := service.UpdateRunState(ctx, raw.ID, rc.Created.RunID, time.Now(), status); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := service.FinishRun(ctx, raw.ID, rc.Created.RunID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	scheduledFor := time.Date(2019, 1, 1, 1, 0, 0, 0, time.UTC)
+	finishRun(scheduledFor, backend.RunSuccess)
+	finishRun(scheduledFor.Add(time.Hour), backend.RunFail)
+
+	// Only the successful run of the upstream task queued a run of the tasks it triggers.
+	runs, err := service.ManualRuns(ctx, rollup.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 || runs[0].ScheduledFor != scheduledFor.Format(time.RFC3339) {
+		t.Fatalf("unexpected queued runs of the triggered task %+v", runs)
+	}
+	if runs, err := service.ManualRuns(ctx, hourly.ID); err != nil || len(runs) != 0 {
+		t.Fatalf("expected no queued runs of the task triggered by rollup, got %+v (%v)", runs, err)
+	}
+
+	rc, err := service.CreateNextRun(ctx, rollup.ID, scheduledFor.Unix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.Created.Now != scheduledFor.Unix() {
+		t.Fatalf("expected the triggered run to be scheduled for %v, got %v", scheduledFor, time.Unix(rc.Created.Now, 0))
+	}
+
+	// A task triggering other tasks cannot be deleted until their trigger is removed.
+	if err := service.DeleteTask(ctx, raw.ID); influxdb.ErrorCode(err) != influxdb.EConflict {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+	rollup, err = service.UpdateTask(ctx, rollup.ID, influxdb.TaskUpdate{TriggerTaskID: new(influxdb.ID)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rollup.TriggerTaskID.Valid() {
+		t.Fatalf("expected the trigger of rollup to be removed, got %s", rollup.TriggerTaskID)
+	}
+	if err := service.DeleteTask(ctx, raw.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTaskService_RunStatistics(t *testing.T) {
+	store, close, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	service := kv.NewService(store)
+	ctx := context.Background()
+	if err := service.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing task service: %v", err)
+	}
+
+	u := &influxdb.User{Name: t.Name() + "-user"}
+	if err := service.CreateUser(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	o := &influxdb.Organization{Name: t.Name() + "-org"}
+	if err := service.CreateOrganization(ctx, o); err != nil {
+		t.Fatal(err)
+	}
+	authz := &influxdb.Authorization{
+		OrgID:       o.ID,
+		UserID:      u.ID,
+		Permissions: influxdb.OperPermissions(),
+	}
+	if err := service.CreateAuthorization(ctx, authz); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx = icontext.SetAuthorizer(ctx, authz)
+	task, err := service.CreateTask(ctx, influxdb.TaskCreate{
+		OrganizationID: o.ID,
+		Token:          authz.Token,
+		Flux:           `option task = {name: "a task", every: 1h} from(bucket: "a") |> range(start: -1h) |> to(bucket: "b", orgID: "000000000000000a")`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scheduledFor := time.Date(2019, 1, 1, 1, 0, 0, 0, time.UTC)
+	if _, err := service.ForceRun(ctx, task.ID, scheduledFor.Unix()); err != nil {
+		t.Fatal(err)
+	}
+	rc, err := service.CreateNextRun(ctx, task.ID, scheduledFor.Unix())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats := influxdb.RunStatistics{
+		CompileDuration: time.Millisecond,
+		ExecuteDuration: time.Second,
+		MaxAllocated:    1024,
+		PointsRead:      100,
+		RowsWritten:     10,
+	}
+	if err := service.UpdateRunStatistics(ctx, task.ID, rc.Created.RunID, stats); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.UpdateRunState(ctx, task.ID, rc.Created.RunID, time.Now(), backend.RunSuccess); err != nil {
+		t.Fatal(err)
+	}
+
+	run, err := service.FindRunByID(ctx, task.ID, rc.Created.RunID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(run.Statistics, &stats) {
+		t.Fatalf("unexpected run statistics -got/+exp\n%s", cmp.Diff(run.Statistics, &stats))
+	}
+
+	// The finished run keeps its statistics, for the system bucket.
+	run, err = service.FinishRun(ctx, task.ID, rc.Created.RunID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(run.Statistics, &stats) {
+		t.Fatalf("unexpected statistics of the finished run -got/+exp\n%s", cmp.Diff(run.Statistics, &stats))
+	}
+
+	if err := service.UpdateRunStatistics(ctx, task.ID, influxdb.ID(1), stats); err == nil {
+		t.Fatal("expected setting the statistics of a missing run to fail")
+	}
+}
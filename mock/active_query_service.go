@@ -0,0 +1,44 @@
+package mock
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+var _ platform.ActiveQueryService = (*ActiveQueryService)(nil)
+
+// ActiveQueryService is a mock implementation of platform.ActiveQueryService.
+type ActiveQueryService struct {
+	FindActiveQueryByIDFn func(ctx context.Context, id platform.ID) (*platform.ActiveQuery, error)
+	FindActiveQueriesFn   func(ctx context.Context, filter platform.ActiveQueryFilter) ([]*platform.ActiveQuery, error)
+	CancelActiveQueryFn   func(ctx context.Context, id platform.ID) error
+}
+
+// NewActiveQueryService returns a mock of ActiveQueryService where its methods will return zero values.
+func NewActiveQueryService() *ActiveQueryService {
+	return &ActiveQueryService{
+		FindActiveQueryByIDFn: func(ctx context.Context, id platform.ID) (*platform.ActiveQuery, error) {
+			return nil, nil
+		},
+		FindActiveQueriesFn: func(ctx context.Context, filter platform.ActiveQueryFilter) ([]*platform.ActiveQuery, error) {
+			return nil, nil
+		},
+		CancelActiveQueryFn: func(ctx context.Context, id platform.ID) error { return nil },
+	}
+}
+
+// FindActiveQueryByID returns a single active query by ID.
+func (s *ActiveQueryService) FindActiveQueryByID(ctx context.Context, id platform.ID) (*platform.ActiveQuery, error) {
+	return s.FindActiveQueryByIDFn(ctx, id)
+}
+
+// FindActiveQueries returns the active queries that match filter.
+func (s *ActiveQueryService) FindActiveQueries(ctx context.Context, filter platform.ActiveQueryFilter) ([]*platform.ActiveQuery, error) {
+	return s.FindActiveQueriesFn(ctx, filter)
+}
+
+// CancelActiveQuery cancels the query.
+func (s *ActiveQueryService) CancelActiveQuery(ctx context.Context, id platform.ID) error {
+	return s.CancelActiveQueryFn(ctx, id)
+}
@@ -0,0 +1,18 @@
+package mock
+
+import (
+	"context"
+	"io"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+// BackupService creates backups of the data and metadata of an instance.
+type BackupService struct {
+	BackupF func(ctx context.Context, w io.Writer, filter platform.BackupFilter) error
+}
+
+// Backup calls the mocked BackupF function with arguments.
+func (s *BackupService) Backup(ctx context.Context, w io.Writer, filter platform.BackupFilter) error {
+	return s.BackupF(ctx, w, filter)
+}
@@ -0,0 +1,17 @@
+package mock
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+// DeleteService removes data from a bucket.
+type DeleteService struct {
+	DeleteBucketRangePredicateF func(ctx context.Context, orgID, bucketID platform.ID, min, max int64, predicate string) error
+}
+
+// DeleteBucketRangePredicate calls the mocked DeleteBucketRangePredicateF function with arguments.
+func (s *DeleteService) DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID platform.ID, min, max int64, predicate string) error {
+	return s.DeleteBucketRangePredicateF(ctx, orgID, bucketID, min, max, predicate)
+}
@@ -0,0 +1,17 @@
+package mock
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+// SeriesCardinalityService provides the number of series stored in buckets.
+type SeriesCardinalityService struct {
+	BucketSeriesCardinalityF func(ctx context.Context, orgID, bucketID platform.ID) (int64, error)
+}
+
+// BucketSeriesCardinality calls the mocked BucketSeriesCardinalityF function with arguments.
+func (s *SeriesCardinalityService) BucketSeriesCardinality(ctx context.Context, orgID, bucketID platform.ID) (int64, error) {
+	return s.BucketSeriesCardinalityF(ctx, orgID, bucketID)
+}
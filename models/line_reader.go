@@ -0,0 +1,96 @@
+package models
+
+import (
+	"bytes"
+	"io"
+)
+
+// LineReader reads line protocol from an io.Reader in blocks of whole lines, so that
+// large inputs can be parsed and written incrementally instead of being read into
+// memory at once. Quoted string field values spanning multiple lines are never split
+// across blocks.
+type LineReader struct {
+	r    io.Reader
+	size int
+
+	buf  []byte // data read but not yet returned
+	line int    // number of the first line of buf
+	err  error
+}
+
+// NewLineReader returns a LineReader reading blocks of about size bytes from r.
+func NewLineReader(r io.Reader, size int) *LineReader {
+	if size <= 0 {
+		size = 1
+	}
+	return &LineReader{
+		r:    r,
+		size: size,
+		line: 1,
+	}
+}
+
+// Next returns the next block of lines and the 1-based number of its first line. Every
+// block but the last ends with a complete line, and blocks are about size bytes unless
+// a line is longer. The block is not modified by later calls to Next. Next returns
+// io.EOF once the input has been read entirely.
+func (r *LineReader) Next() ([]byte, int, error) {
+	for {
+		if len(r.buf) >= r.size {
+			if n := lastLineEnd(r.buf); n > 0 {
+				line := r.line
+				return r.next(n), line, nil
+			}
+		}
+
+		if r.err == io.EOF && len(r.buf) > 0 {
+			line := r.line
+			return r.next(len(r.buf)), line, nil
+		} else if r.err != nil {
+			return nil, r.line, r.err
+		}
+
+		// Grow the buffer if it is full, as the block may be within a line longer
+		// than size bytes.
+		if len(r.buf) == cap(r.buf) {
+			buf := make([]byte, len(r.buf), 2*len(r.buf)+r.size)
+			copy(buf, r.buf)
+			r.buf = buf
+		}
+
+		n, err := r.r.Read(r.buf[len(r.buf):cap(r.buf)])
+		r.buf = r.buf[:len(r.buf)+n]
+		if err != nil {
+			r.err = err
+		}
+	}
+}
+
+// next returns the first n bytes of the buffer as a block, and moves the rest of the
+// buffer to a new one so that the block is never overwritten.
+func (r *LineReader) next(n int) []byte {
+	block := r.buf[:n]
+	r.line += bytes.Count(block, []byte{'\n'})
+
+	rest := r.buf[n:]
+	r.buf = make([]byte, len(rest), r.size+len(rest))
+	copy(r.buf, rest)
+	return block
+}
+
+// lastLineEnd returns the position following the newline ending the last complete
+// line of buf, or zero if buf does not contain a complete line. Newlines in the last
+// two bytes of buf are ignored, as scanLine might treat them differently once more
+// data is read.
+func lastLineEnd(buf []byte) int {
+	var end int
+	for pos := 0; pos < len(buf); {
+		i, _ := scanLine(buf, pos)
+		if i+2 >= len(buf) {
+			break
+		}
+		pos = i + 1
+		end = pos
+	}
+	return end
+}
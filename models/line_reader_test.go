@@ -0,0 +1,80 @@
+package models_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+)
+
+func TestLineReader(t *testing.T) {
+	const input = "# comment\n" +
+		"cpu,host=a value=1 1\n" +
+		"\n" +
+		"cpu,host=b value=\n" +
+		"log,host=a msg=\"multi\nline\\\" string\",level=1i 2\n" +
+		"cpu,host=c value=3 3\n" +
+		"cpu,host=d value=\\\n4\n" +
+		"log,host=b msg=\"last\" 5"
+
+	type result struct {
+		points []string
+		lines  []int
+		errs   []int
+	}
+
+	parse := func(block []byte, first int, res *result) {
+		points, lines, errs := models.ParseLinesWithPrecision(block, nil, time.Unix(0, 0), "n")
+		for i, pt := range points {
+			res.points = append(res.points, pt.String())
+			res.lines = append(res.lines, first+lines[i]-1)
+		}
+		for _, err := range errs {
+			res.errs = append(res.errs, first+err.Line-1)
+		}
+	}
+
+	var exp result
+	parse([]byte(input), 1, &exp)
+
+	for size := 1; size <= len(input)+1; size++ {
+		var (
+			got result
+			buf bytes.Buffer
+		)
+
+		r := models.NewLineReader(iotest.HalfReader(strings.NewReader(input)), size)
+		for {
+			block, first, err := r.Next()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("size %d: unexpected error: %v", size, err)
+			}
+			buf.Write(block)
+			parse(block, first, &got)
+		}
+
+		if buf.String() != input {
+			t.Fatalf("size %d: blocks do not add up to the input: got %q", size, buf.String())
+		}
+		if !reflect.DeepEqual(got, exp) {
+			t.Fatalf("size %d: got %+v, exp %+v", size, got, exp)
+		}
+	}
+}
+
+func TestLineReader_Error(t *testing.T) {
+	r := models.NewLineReader(iotest.TimeoutReader(strings.NewReader("cpu value=1 1\ncpu value=2 2\n")), 16)
+	if _, _, err := r.Next(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := r.Next(); err != iotest.ErrTimeout {
+		t.Fatalf("got error %v, exp %v", err, iotest.ErrTimeout)
+	}
+}
@@ -186,6 +186,8 @@ func (t FieldType) String() string {
 		return "String"
 	case Empty:
 		return "Empty"
+	case Unsigned:
+		return "Unsigned"
 	default:
 		return "<unknown>"
 	}
@@ -379,15 +381,76 @@ func ParsePointsWithPrecision(buf []byte, mm []byte, defaultTime time.Time, prec
 
 func parsePointsWithPrecision(buf []byte, mm []byte, defaultTime time.Time, precision string, rewrite bool) (_ []Point, err error) {
 	points := make([]Point, 0, bytes.Count(buf, []byte{'\n'})+1)
+	var failed []string
+	scanLines(buf, func(line int, block []byte) {
+		points, err = parsePointsAppend(points, block, mm, defaultTime, precision, rewrite)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("unable to parse '%s': %v", string(block), err))
+		}
+	})
+	if len(failed) > 0 {
+		return points, fmt.Errorf("%s", strings.Join(failed, "\n"))
+	}
+
+	return points, nil
+}
+
+// LineError is an error parsing a line of line protocol.
+type LineError struct {
+	// Line is the 1-based number of the line within the parsed buffer.
+	Line int
+
+	// Text is the content of the line.
+	Text string
+
+	Err error
+}
+
+// Error returns a string representation of the error.
+func (e *LineError) Error() string {
+	return fmt.Sprintf("unable to parse '%s': %v", e.Text, e.Err)
+}
+
+// ParseLinesWithPrecision is similar to ParsePointsWithPrecision, but parses each line
+// independently. Lines which fail to parse do not produce any point. It returns the points
+// along with the number of the line each one was parsed from, and an error for every line
+// which failed to parse.
+func ParseLinesWithPrecision(buf []byte, mm []byte, defaultTime time.Time, precision string) (points []Point, lines []int, errs []*LineError) {
+	points = make([]Point, 0, bytes.Count(buf, []byte{'\n'})+1)
+	lines = make([]int, 0, cap(points))
+	scanLines(buf, func(line int, block []byte) {
+		n := len(points)
+
+		var err error
+		if points, err = parsePointsAppend(points, block, mm, defaultTime, precision, true); err != nil {
+			points = points[:n]
+			errs = append(errs, &LineError{Line: line, Text: string(block), Err: err})
+			return
+		}
+
+		for i := n; i < len(points); i++ {
+			lines = append(lines, line)
+		}
+	})
+	return points, lines, errs
+}
+
+// scanLines calls fn with the 1-based number and content of each line of buf which is
+// neither blank nor a comment. The content of a line excludes leading whitespace and the
+// trailing newline.
+func scanLines(buf []byte, fn func(line int, block []byte)) {
 	var (
-		pos    int
-		block  []byte
-		failed []string
+		pos   int
+		block []byte
 	)
-	for pos < len(buf) {
+	for line := 1; pos < len(buf); {
 		pos, block = scanLine(buf, pos)
 		pos++
 
+		// Quoted string fields may span multiple lines.
+		n := line
+		line += bytes.Count(block, []byte{'\n'}) + 1
+
 		if len(block) == 0 {
 			continue
 		}
@@ -409,23 +472,15 @@ func parsePointsWithPrecision(buf []byte, mm []byte, defaultTime time.Time, prec
 			block = block[:len(block)-1]
 		}
 
-		points, err = parsePointsAppend(points, block[start:], mm, defaultTime, precision, rewrite)
-		if err != nil {
-			failed = append(failed, fmt.Sprintf("unable to parse '%s': %v", string(block[start:]), err))
-		}
-	}
-	if len(failed) > 0 {
-		return points, fmt.Errorf("%s", strings.Join(failed, "\n"))
+		fn(n, block[start:])
 	}
-
-	return points, nil
 }
 
 func parsePointsAppend(points []Point, buf []byte, mm []byte, defaultTime time.Time, precision string, rewrite bool) ([]Point, error) {
 	// scan the first block which is measurement[,tag1=value1,tag2=value=2...]
 	pos, key, err := scanKey(buf, 0)
 	if err != nil {
-		return nil, err
+		return points, err
 	}
 
 	// measurement name is required
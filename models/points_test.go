@@ -2138,6 +2138,43 @@ cpu,host=serverA,region=us-east value=1.0 946730096789012345`,
 	}
 }
 
+func TestParseLinesWithPrecision(t *testing.T) {
+	batch := `# comment
+cpu,host=serverA value=1.0,value2=2.0 946730096789012345
+cpu,host=serverA value=
+cpu,host=serverA value="multi
+line" 946730096789012345
+
+cpu,host=serverB value=1.0 946730096789012345
+cpu,host=serverB value=1.0 invalid`
+
+	pts, lines, errs := models.ParseLinesWithPrecision([]byte(batch), []byte("mm"), time.Now().UTC(), "")
+	if got, exp := len(pts), 4; got != exp {
+		t.Fatalf("ParseLinesWithPrecision() len mismatch: got %v, exp %v", got, exp)
+	}
+	if got, exp := lines, []int{2, 2, 4, 7}; !reflect.DeepEqual(got, exp) {
+		t.Errorf("ParseLinesWithPrecision() lines mismatch: got %v, exp %v", got, exp)
+	}
+	if got, exp := pts[3].String(), "mm,\x00=cpu,host=serverB,\xff=value value=1.0 946730096789012345"; got != exp {
+		t.Errorf("ParseLinesWithPrecision() to string mismatch:\n got %v\n exp %v", got, exp)
+	}
+
+	if got, exp := len(errs), 2; got != exp {
+		t.Fatalf("ParseLinesWithPrecision() errors mismatch: got %v, exp %v", got, exp)
+	}
+	for i, exp := range []struct {
+		line int
+		text string
+	}{
+		{3, "cpu,host=serverA value="},
+		{8, "cpu,host=serverB value=1.0 invalid"},
+	} {
+		if errs[i].Line != exp.line || errs[i].Text != exp.text || errs[i].Err == nil {
+			t.Errorf("ParseLinesWithPrecision() error mismatch: got %+v, exp line %d %q", errs[i], exp.line, exp.text)
+		}
+	}
+}
+
 func TestNewPointEscaped(t *testing.T) {
 	// commas
 	pt := models.MustNewPoint("cpu,main", models.NewTags(map[string]string{"tag,bar": "value"}), models.Fields{"name,bar": 1.0}, time.Unix(0, 0))
@@ -7,6 +7,7 @@ type Organization struct {
 	ID          ID     `json:"id,omitempty"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	MaxSeries   int64  `json:"maxSeries,omitempty"` // Default series limit of the buckets of the organization
 }
 
 // errors of org
@@ -54,7 +55,8 @@ type OrganizationService interface {
 // OrganizationUpdate represents updates to a organization.
 // Only fields which are set are updated.
 type OrganizationUpdate struct {
-	Name *string
+	Name      *string
+	MaxSeries *int64 `json:"maxSeries,omitempty"`
 }
 
 // OrganizationFilter represents a set of filter that restrict the returned results.
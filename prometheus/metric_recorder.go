@@ -14,6 +14,7 @@ type EventRecorder struct {
 	count         *prometheus.CounterVec
 	requestBytes  *prometheus.CounterVec
 	responseBytes *prometheus.CounterVec
+	droppedPoints *prometheus.CounterVec
 }
 
 // NewEventRecorder returns an instance of a metric event recorder. Subsystem is expected to be
@@ -25,6 +26,7 @@ type EventRecorder struct {
 // http_<subsystem>_request_count{org_id=<org_id>, status=<status>, endpoint=<endpoint>} ...
 // http_<subsystem>_request_bytes{org_id=<org_id>, status=<status>, endpoint=<endpoint>} ...
 // http_<subsystem>_response_bytes{org_id=<org_id>, status=<status>, endpoint=<endpoint>} ...
+// http_<subsystem>_dropped_points{org_id=<org_id>, status=<status>, endpoint=<endpoint>} ...
 func NewEventRecorder(subsystem string) *EventRecorder {
 	const namespace = "http"
 
@@ -51,15 +53,23 @@ func NewEventRecorder(subsystem string) *EventRecorder {
 		Help:      "Count of bytes returned",
 	}, labels)
 
+	droppedPoints := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "dropped_points",
+		Help:      "Count of points dropped from requests",
+	}, labels)
+
 	return &EventRecorder{
 		count:         count,
 		requestBytes:  requestBytes,
 		responseBytes: responseBytes,
+		droppedPoints: droppedPoints,
 	}
 }
 
-// Record metric records the request count, response bytes, and request bytes with labels
-// for the org, endpoint, and status.
+// Record metric records the request count, response bytes, request bytes, and dropped points
+// with labels for the org, endpoint, and status.
 func (r *EventRecorder) Record(ctx context.Context, e metric.Event) {
 	labels := prometheus.Labels{
 		"org_id":   e.OrgID.String(),
@@ -69,6 +79,9 @@ func (r *EventRecorder) Record(ctx context.Context, e metric.Event) {
 	r.count.With(labels).Inc()
 	r.requestBytes.With(labels).Add(float64(e.RequestBytes))
 	r.responseBytes.With(labels).Add(float64(e.ResponseBytes))
+	if e.DroppedPoints > 0 {
+		r.droppedPoints.With(labels).Add(float64(e.DroppedPoints))
+	}
 }
 
 // PrometheusCollectors exposes the prometheus collectors associated with a metric recorder.
@@ -77,5 +90,6 @@ func (r *EventRecorder) PrometheusCollectors() []prometheus.Collector {
 		r.count,
 		r.requestBytes,
 		r.responseBytes,
+		r.droppedPoints,
 	}
 }
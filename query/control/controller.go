@@ -18,17 +18,41 @@ const orgLabel = "org"
 
 // Controller implements AsyncQueryService by consuming a control.Controller.
 type Controller struct {
-	c *control.Controller
+	c         *control.Controller
+	limits    Limits
+	admission *admission
+	queries   *registry
+}
+
+// Option configures a Controller.
+type Option func(*Controller)
+
+// WithLimits admits the queries of each organization within limits.
+func WithLimits(limits Limits) Option {
+	return func(c *Controller) {
+		c.limits = limits
+	}
 }
 
 // NewController creates a new Controller specific to platform.
-func New(config control.Config) (*Controller, error) {
+func New(config control.Config, opts ...Option) (*Controller, error) {
+	ctrl := &Controller{}
+	for _, opt := range opts {
+		opt(ctrl)
+	}
+	if err := ctrl.limits.validate(config.MemoryBytesQuotaPerQuery); err != nil {
+		return nil, err
+	}
+	ctrl.admission = newAdmission(ctrl.limits, config.MemoryBytesQuotaPerQuery)
+	ctrl.queries = newRegistry()
+
 	config.MetricLabelKeys = append(config.MetricLabelKeys, orgLabel)
 	c, err := control.New(config)
 	if err != nil {
 		return nil, err
 	}
-	return &Controller{c: c}, nil
+	ctrl.c = c
+	return ctrl, nil
 }
 
 // Query satisfies the AsyncQueryService while ensuring the request is propagated on the context.
@@ -40,8 +64,26 @@ func (c *Controller) Query(ctx context.Context, req *query.Request) (flux.Query,
 	ctx = query.ContextWithRequest(ctx, req)
 	// Set the org label value for controller metrics
 	ctx = context.WithValue(ctx, orgLabel, req.OrganizationID.String())
+
+	// The query is registered while it is queued, so that it can be canceled
+	// before it is admitted.
+	ctx, cancel := context.WithCancel(ctx)
+	id := c.queries.add(req, cancel)
+	done := func() {
+		c.queries.remove(id)
+		cancel()
+	}
+
+	release, err := c.admission.acquire(ctx, req.OrganizationID)
+	if err != nil {
+		done()
+		return nil, err
+	}
+
 	q, err := c.c.Query(ctx, req.Compiler)
 	if err != nil {
+		release()
+		done()
 		// If the controller reports an error, it's usually because of a syntax error
 		// or other problem that the client must fix.
 		return q, &platform.Error{
@@ -49,8 +91,40 @@ func (c *Controller) Query(ctx context.Context, req *query.Request) (flux.Query,
 			Msg:  err.Error(),
 		}
 	}
+	c.queries.start(id, q, c.admission.reserved())
+
+	return &admittedQuery{Query: q, release: func() {
+		release()
+		done()
+	}}, nil
+}
+
+// admittedQuery releases its admission and leaves the active queries once it is done.
+type admittedQuery struct {
+	flux.Query
+	release func()
+}
+
+func (q *admittedQuery) Done() {
+	q.Query.Done()
+	q.release()
+}
+
+var _ platform.ActiveQueryService = (*Controller)(nil)
+
+// FindActiveQueryByID returns the query queued or running with the id.
+func (c *Controller) FindActiveQueryByID(ctx context.Context, id platform.ID) (*platform.ActiveQuery, error) {
+	return c.queries.find(id)
+}
+
+// FindActiveQueries returns the queries queued or running that match filter, oldest first.
+func (c *Controller) FindActiveQueries(ctx context.Context, filter platform.ActiveQueryFilter) ([]*platform.ActiveQuery, error) {
+	return c.queries.list(filter), nil
+}
 
-	return q, nil
+// CancelActiveQuery cancels the query with the id, whether it is queued or running.
+func (c *Controller) CancelActiveQuery(ctx context.Context, id platform.ID) error {
+	return c.queries.cancel(id)
 }
 
 // PrometheusCollectors satisifies the prom.PrometheusCollector interface.
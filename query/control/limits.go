@@ -0,0 +1,185 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+// Limits configures the admission of queries into the controller, so that
+// the queries of one organization cannot starve those of the others.
+// A zero quota disables the corresponding limit, but a zero OrgQueueSize
+// rejects the queries which cannot be admitted immediately.
+type Limits struct {
+	// MemoryBytesQuota is the memory available to the queries of all organizations.
+	MemoryBytesQuota int64
+
+	// OrgMemoryBytesQuota is the memory available to the queries of an organization.
+	OrgMemoryBytesQuota int64
+
+	// OrgConcurrencyQuota is the number of queries of an organization admitted at once.
+	OrgConcurrencyQuota int
+
+	// OrgQueueSize is the number of queries of an organization waiting to be admitted.
+	// Queries beyond it are rejected, so with a size of 0 queries are never queued.
+	OrgQueueSize int
+}
+
+// validate checks the limits can be enforced with queries each allowed
+// queryMemory bytes.
+func (l Limits) validate(queryMemory int64) error {
+	if l.MemoryBytesQuota < 0 || l.OrgMemoryBytesQuota < 0 || l.OrgConcurrencyQuota < 0 || l.OrgQueueSize < 0 {
+		return fmt.Errorf("query limits must not be negative")
+	}
+	if l.MemoryBytesQuota == 0 && l.OrgMemoryBytesQuota == 0 {
+		return nil
+	}
+	if queryMemory <= 0 || queryMemory == math.MaxInt64 {
+		return fmt.Errorf("memory quotas require a memory quota per query")
+	}
+	if l.MemoryBytesQuota > 0 && queryMemory > l.MemoryBytesQuota {
+		return fmt.Errorf("memory quota per query %d exceeds the memory quota %d", queryMemory, l.MemoryBytesQuota)
+	}
+	if l.OrgMemoryBytesQuota > 0 && queryMemory > l.OrgMemoryBytesQuota {
+		return fmt.Errorf("memory quota per query %d exceeds the organization memory quota %d", queryMemory, l.OrgMemoryBytesQuota)
+	}
+	return nil
+}
+
+// orgUsage is the usage of the limits by the queries of an organization.
+type orgUsage struct {
+	running int
+	queued  int
+	memory  int64
+}
+
+// admission admits queries within the limits. Each admitted query reserves
+// the memory it may allocate until it is released.
+type admission struct {
+	limits      Limits
+	queryMemory int64
+
+	mu      sync.Mutex
+	memory  int64
+	orgs    map[platform.ID]*orgUsage
+	changed chan struct{} // closed when a query is released
+}
+
+func newAdmission(limits Limits, queryMemory int64) *admission {
+	return &admission{
+		limits:      limits,
+		queryMemory: queryMemory,
+		orgs:        make(map[platform.ID]*orgUsage),
+		changed:     make(chan struct{}),
+	}
+}
+
+// acquire admits a query of the organization, waiting in the queue of the
+// organization if the limits are reached. The returned function releases
+// the query and must be called once it is done.
+func (a *admission) acquire(ctx context.Context, orgID platform.ID) (func(), error) {
+	a.mu.Lock()
+	usage := a.orgs[orgID]
+	if usage == nil {
+		usage = &orgUsage{}
+		a.orgs[orgID] = usage
+	}
+
+	queued := false
+	for !a.admitsLocked(usage) {
+		if !queued {
+			if usage.queued >= a.limits.OrgQueueSize {
+				a.removeIfIdleLocked(orgID, usage)
+				a.mu.Unlock()
+				return nil, &platform.Error{
+					Code: platform.ETooManyRequests,
+					Msg:  fmt.Sprintf("query limits of organization %s are exhausted; try again later", orgID),
+				}
+			}
+			usage.queued++
+			queued = true
+		}
+
+		changed := a.changed
+		a.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			a.mu.Lock()
+			usage.queued--
+			a.removeIfIdleLocked(orgID, usage)
+			a.mu.Unlock()
+			return nil, &platform.Error{
+				Code: platform.ETooManyRequests,
+				Msg:  fmt.Sprintf("query of organization %s was not admitted before it was canceled", orgID),
+				Err:  ctx.Err(),
+			}
+		case <-changed:
+		}
+		a.mu.Lock()
+	}
+
+	if queued {
+		usage.queued--
+	}
+	usage.running++
+	if a.reservesMemory() {
+		usage.memory += a.queryMemory
+		a.memory += a.queryMemory
+	}
+	a.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { a.release(orgID, usage) })
+	}, nil
+}
+
+func (a *admission) release(orgID platform.ID, usage *orgUsage) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	usage.running--
+	if a.reservesMemory() {
+		usage.memory -= a.queryMemory
+		a.memory -= a.queryMemory
+	}
+	a.removeIfIdleLocked(orgID, usage)
+
+	close(a.changed)
+	a.changed = make(chan struct{})
+}
+
+// admitsLocked reports whether one more query of the organization is within the limits.
+func (a *admission) admitsLocked(usage *orgUsage) bool {
+	if a.limits.OrgConcurrencyQuota > 0 && usage.running >= a.limits.OrgConcurrencyQuota {
+		return false
+	}
+	if a.limits.OrgMemoryBytesQuota > 0 && usage.memory+a.queryMemory > a.limits.OrgMemoryBytesQuota {
+		return false
+	}
+	if a.limits.MemoryBytesQuota > 0 && a.memory+a.queryMemory > a.limits.MemoryBytesQuota {
+		return false
+	}
+	return true
+}
+
+// reserved returns the memory reserved by each admitted query.
+func (a *admission) reserved() int64 {
+	if !a.reservesMemory() {
+		return 0
+	}
+	return a.queryMemory
+}
+
+func (a *admission) reservesMemory() bool {
+	return a.limits.MemoryBytesQuota > 0 || a.limits.OrgMemoryBytesQuota > 0
+}
+
+func (a *admission) removeIfIdleLocked(orgID platform.ID, usage *orgUsage) {
+	if usage.running == 0 && usage.queued == 0 {
+		delete(a.orgs, orgID)
+	}
+}
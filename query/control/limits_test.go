@@ -0,0 +1,168 @@
+package control
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+func TestLimits_validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		limits      Limits
+		queryMemory int64
+		wantErr     bool
+	}{
+		{name: "no limits", queryMemory: math.MaxInt64},
+		{name: "concurrency only", limits: Limits{OrgConcurrencyQuota: 2, OrgQueueSize: 4}, queryMemory: math.MaxInt64},
+		{name: "memory", limits: Limits{MemoryBytesQuota: 100, OrgMemoryBytesQuota: 50}, queryMemory: 10},
+		{name: "unlimited query memory", limits: Limits{OrgMemoryBytesQuota: 50}, queryMemory: math.MaxInt64, wantErr: true},
+		{name: "query memory above quota", limits: Limits{MemoryBytesQuota: 5}, queryMemory: 10, wantErr: true},
+		{name: "negative", limits: Limits{OrgQueueSize: -1}, queryMemory: 10, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.limits.validate(tt.queryMemory); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAdmission_OrgConcurrency(t *testing.T) {
+	a := newAdmission(Limits{OrgConcurrencyQuota: 1, OrgQueueSize: 1}, math.MaxInt64)
+	ctx := context.Background()
+	org1, org2 := platform.ID(1), platform.ID(2)
+
+	release, err := a.acquire(ctx, org1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Other organizations are not affected.
+	release2, err := a.acquire(ctx, org2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release2()
+
+	// The next query of the organization waits in the queue...
+	admitted := make(chan error, 1)
+	go func() {
+		r, err := a.acquire(ctx, org1)
+		if err == nil {
+			defer r()
+		}
+		admitted <- err
+	}()
+	waitQueued(t, a, org1, 1)
+
+	// ...and the queries beyond the queue are rejected.
+	if _, err := a.acquire(ctx, org1); platform.ErrorCode(err) != platform.ETooManyRequests {
+		t.Fatalf("expected %q error, got %v", platform.ETooManyRequests, err)
+	}
+
+	release()
+	if err := <-admitted; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAdmission_NoQueue(t *testing.T) {
+	a := newAdmission(Limits{OrgConcurrencyQuota: 1}, math.MaxInt64)
+	ctx := context.Background()
+	org := platform.ID(1)
+
+	release, err := a.acquire(ctx, org)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a queue, a query which cannot be admitted is rejected immediately.
+	if _, err := a.acquire(ctx, org); platform.ErrorCode(err) != platform.ETooManyRequests {
+		t.Fatalf("expected %q error, got %v", platform.ETooManyRequests, err)
+	}
+
+	release()
+	release, err = a.acquire(ctx, org)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
+
+func TestAdmission_Memory(t *testing.T) {
+	a := newAdmission(Limits{MemoryBytesQuota: 30, OrgMemoryBytesQuota: 20}, 10)
+	ctx := context.Background()
+	org1, org2 := platform.ID(1), platform.ID(2)
+
+	var releases []func()
+	for _, org := range []platform.ID{org1, org1, org2} {
+		r, err := a.acquire(ctx, org)
+		if err != nil {
+			t.Fatal(err)
+		}
+		releases = append(releases, r)
+	}
+
+	// org1 exhausted its own quota and org2 the remaining global memory.
+	for _, org := range []platform.ID{org1, org2} {
+		if _, err := a.acquire(ctx, org); platform.ErrorCode(err) != platform.ETooManyRequests {
+			t.Fatalf("expected %q error for org %s, got %v", platform.ETooManyRequests, org, err)
+		}
+	}
+
+	// Releasing twice must not free the memory twice.
+	releases[0]()
+	releases[0]()
+	r, err := a.acquire(ctx, org2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r()
+	if got, exp := a.memory, int64(20); got != exp {
+		t.Fatalf("unexpected reserved memory; got %d, exp %d", got, exp)
+	}
+}
+
+func TestAdmission_Canceled(t *testing.T) {
+	a := newAdmission(Limits{OrgConcurrencyQuota: 1, OrgQueueSize: 1}, math.MaxInt64)
+	org := platform.ID(1)
+
+	release, err := a.acquire(context.Background(), org)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := a.acquire(ctx, org); platform.ErrorCode(err) != platform.ETooManyRequests {
+		t.Fatalf("expected %q error, got %v", platform.ETooManyRequests, err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if got := a.orgs[org].queued; got != 0 {
+		t.Fatalf("expected the canceled query to leave the queue, %d queued", got)
+	}
+}
+
+// waitQueued waits for n queries of the organization to be queued.
+func waitQueued(t *testing.T, a *admission, orgID platform.ID, n int) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		a.mu.Lock()
+		usage := a.orgs[orgID]
+		queued := usage != nil && usage.queued == n
+		a.mu.Unlock()
+		if queued {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected %d queued queries", n)
+}
@@ -0,0 +1,137 @@
+package control
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/snowflake"
+)
+
+// activeQuery is a query known to the registry.
+type activeQuery struct {
+	info   platform.ActiveQuery
+	cancel context.CancelFunc
+	q      flux.Query // set once the query is running
+}
+
+// registry tracks the queries from the moment they are queued until they are done.
+type registry struct {
+	idGen platform.IDGenerator
+	now   func() time.Time
+
+	mu      sync.Mutex
+	queries map[platform.ID]*activeQuery
+}
+
+func newRegistry() *registry {
+	return &registry{
+		idGen:   snowflake.NewIDGenerator(),
+		now:     time.Now,
+		queries: make(map[platform.ID]*activeQuery),
+	}
+}
+
+// add registers a queued query of the request. cancel stops the query
+// while it is queued.
+func (r *registry) add(req *query.Request, cancel context.CancelFunc) platform.ID {
+	aq := &activeQuery{
+		info: platform.ActiveQuery{
+			ID:             r.idGen.ID(),
+			OrganizationID: req.OrganizationID,
+			Query:          query.CompilerText(req.Compiler),
+			StartTime:      r.now().UTC(),
+			State:          platform.ActiveQueryQueued,
+		},
+		cancel: cancel,
+	}
+	if req.Authorization != nil {
+		aq.info.UserID = req.Authorization.UserID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.queries[aq.info.ID] = aq
+	return aq.info.ID
+}
+
+// start marks the query as running q with memoryBytes reserved.
+func (r *registry) start(id platform.ID, q flux.Query, memoryBytes int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if aq, ok := r.queries[id]; ok {
+		aq.q = q
+		aq.info.State = platform.ActiveQueryRunning
+		aq.info.MemoryBytes = memoryBytes
+	}
+}
+
+// remove forgets the query once it is done.
+func (r *registry) remove(id platform.ID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.queries, id)
+}
+
+func (r *registry) find(id platform.ID) (*platform.ActiveQuery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	aq, ok := r.queries[id]
+	if !ok {
+		return nil, &platform.Error{
+			Op:  platform.OpFindActiveQueryByID,
+			Err: platform.ErrActiveQueryNotFound,
+		}
+	}
+	info := aq.info
+	return &info, nil
+}
+
+// list returns the queries matching filter, oldest first.
+func (r *registry) list(filter platform.ActiveQueryFilter) []*platform.ActiveQuery {
+	r.mu.Lock()
+	qs := make([]*platform.ActiveQuery, 0, len(r.queries))
+	for _, aq := range r.queries {
+		if filter.OrganizationID != nil && aq.info.OrganizationID != *filter.OrganizationID {
+			continue
+		}
+		info := aq.info
+		qs = append(qs, &info)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(qs, func(i, j int) bool {
+		if !qs[i].StartTime.Equal(qs[j].StartTime) {
+			return qs[i].StartTime.Before(qs[j].StartTime)
+		}
+		return qs[i].ID < qs[j].ID
+	})
+	return qs
+}
+
+// cancel stops the query, whether it is queued or running.
+func (r *registry) cancel(id platform.ID) error {
+	r.mu.Lock()
+	aq, ok := r.queries[id]
+	var q flux.Query
+	if ok {
+		q = aq.q
+	}
+	r.mu.Unlock()
+	if !ok {
+		return &platform.Error{
+			Op:  platform.OpCancelActiveQuery,
+			Err: platform.ErrActiveQueryNotFound,
+		}
+	}
+
+	if q != nil {
+		q.Cancel()
+	}
+	aq.cancel()
+	return nil
+}
@@ -0,0 +1,87 @@
+package control
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux/lang"
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/mock"
+)
+
+func TestRegistry(t *testing.T) {
+	r := newRegistry()
+	now := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	org1, org2 := platform.ID(1), platform.ID(2)
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	id1 := r.add(&query.Request{
+		Authorization:  &platform.Authorization{UserID: 3},
+		OrganizationID: org1,
+		Compiler:       lang.FluxCompiler{Query: `from(bucket: "b") |> range(start: -1h)`},
+	}, cancel1)
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	id2 := r.add(&query.Request{OrganizationID: org2, Compiler: lang.FluxCompiler{Query: "q2"}}, cancel2)
+
+	q := mock.NewQuery()
+	r.start(id1, q, 1024)
+
+	got, err := r.find(id1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := platform.ActiveQuery{
+		ID:             id1,
+		OrganizationID: org1,
+		UserID:         3,
+		Query:          `from(bucket: "b") |> range(start: -1h)`,
+		StartTime:      time.Date(2019, 5, 1, 0, 0, 1, 0, time.UTC),
+		MemoryBytes:    1024,
+		State:          platform.ActiveQueryRunning,
+	}
+	if *got != exp {
+		t.Fatalf("unexpected query:\ngot  %+v\nexp %+v", *got, exp)
+	}
+
+	if qs := r.list(platform.ActiveQueryFilter{}); len(qs) != 2 || qs[0].ID != id1 || qs[1].ID != id2 {
+		t.Fatalf("expected both queries, oldest first, got %+v", qs)
+	}
+	qs := r.list(platform.ActiveQueryFilter{OrganizationID: &org2})
+	if len(qs) != 1 || qs[0].ID != id2 || qs[0].State != platform.ActiveQueryQueued {
+		t.Fatalf("expected the queued query of org2, got %+v", qs)
+	}
+
+	// Canceling a running query cancels the flux query.
+	if err := r.cancel(id1); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-q.Results(); ok {
+		t.Fatal("expected the results of the canceled query to be closed")
+	}
+	if ctx1.Err() == nil {
+		t.Fatal("expected the context of the canceled query to be canceled")
+	}
+
+	// Canceling a queued query cancels its context.
+	if err := r.cancel(id2); err != nil {
+		t.Fatal(err)
+	}
+	if ctx2.Err() == nil {
+		t.Fatal("expected the context of the canceled query to be canceled")
+	}
+
+	r.remove(id1)
+	r.remove(id2)
+	if _, err := r.find(id1); platform.ErrorCode(err) != platform.ENotFound {
+		t.Fatalf("expected %q error, got %v", platform.ENotFound, err)
+	}
+	if err := r.cancel(id2); platform.ErrorCode(err) != platform.ENotFound {
+		t.Fatalf("expected %q error, got %v", platform.ENotFound, err)
+	}
+}
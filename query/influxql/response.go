@@ -1,5 +1,14 @@
 package influxql
 
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/influxdata/influxdb/models"
+)
+
 // all of this code is copied more or less verbatim from the influxdb repo.
 // we copy instead of sharing because we want to prevent inadvertent breaking
 // changes introduced by the transpiler vs the actual InfluxQL engine.
@@ -42,3 +51,88 @@ type Row struct {
 	Values  [][]interface{}   `json:"values,omitempty"`
 	Partial bool              `json:"partial,omitempty"`
 }
+
+// writeCSV writes the response in the CSV format of the influxdb 1.X http response.
+// Each statement starts with a header of the name and tags of the series followed by
+// their columns, and statements are separated by an empty line.
+func (r *Response) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if r.Err != "" {
+		cw.Write([]string{"error"})
+		cw.Write([]string{r.Err})
+		cw.Flush()
+		return cw.Error()
+	}
+
+	var (
+		columns []string
+		started bool
+	)
+	header := func(row *Row) {
+		if started {
+			cw.Flush()
+			w.Write([]byte("\n"))
+		}
+		started = true
+
+		columns = make([]string, 2+len(row.Columns))
+		columns[0], columns[1] = "name", "tags"
+		copy(columns[2:], row.Columns)
+		cw.Write(columns)
+	}
+
+	for _, result := range r.Results {
+		for i, row := range result.Series {
+			if i == 0 || !stringsEqual(result.Series[i-1].Columns, row.Columns) {
+				header(row)
+			}
+
+			columns[0], columns[1] = row.Name, ""
+			if len(row.Tags) > 0 {
+				if key := models.NewTags(row.Tags).HashKey(); len(key) > 0 {
+					columns[1] = string(key[1:])
+				}
+			}
+
+			for _, values := range row.Values {
+				for i, value := range values {
+					columns[i+2] = formatCSVValue(value)
+				}
+				cw.Write(columns)
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatCSVValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -12,8 +12,13 @@ import (
 	"github.com/influxdata/flux/iocounter"
 )
 
-// MultiResultEncoder encodes results as InfluxQL JSON format.
-type MultiResultEncoder struct{}
+// MultiResultEncoder encodes results as InfluxQL JSON or CSV format.
+type MultiResultEncoder struct {
+	// Encoding is the format of the results; defaults to JSON.
+	Encoding EncodingFormat
+	// TimeFormat is the format of the timestamps; defaults to RFC3339Nano.
+	TimeFormat TimeFormat
+}
 
 // Encode writes a collection of results to the influxdb 1.X http response format.
 // Expectations/Assumptions:
@@ -154,7 +159,7 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 						vs := cr.Times(idx)
 						for i := 0; i < vs.Len(); i++ {
 							if vs.IsValid(i) {
-								values[i][j] = execute.Time(vs.Value(i)).Time().Format(time.RFC3339Nano)
+								values[i][j] = e.formatTime(execute.Time(vs.Value(i)).Time())
 							}
 						}
 					default:
@@ -182,9 +187,49 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 		resp.error(err)
 	}
 
-	err := json.NewEncoder(wc).Encode(resp)
+	var err error
+	switch e.Encoding {
+	case JSON:
+		err = json.NewEncoder(wc).Encode(resp)
+	case JSONPretty:
+		enc := json.NewEncoder(wc)
+		enc.SetIndent("", "    ")
+		err = enc.Encode(resp)
+	case CSV:
+		err = resp.writeCSV(wc)
+	default:
+		err = fmt.Errorf("unsupported encoding: %d", e.Encoding)
+	}
 	return wc.Count(), err
 }
+
+// formatTime formats a timestamp of the results. Timestamps are written as
+// nanoseconds in the unix epoch when encoding CSV, unless another epoch is requested.
+func (e *MultiResultEncoder) formatTime(t time.Time) interface{} {
+	format := e.TimeFormat
+	if format == RFC3339Nano && e.Encoding == CSV {
+		format = Nanosecond
+	}
+
+	switch format {
+	case Hour:
+		return t.UnixNano() / int64(time.Hour)
+	case Minute:
+		return t.UnixNano() / int64(time.Minute)
+	case Second:
+		return t.UnixNano() / int64(time.Second)
+	case Millisecond:
+		return t.UnixNano() / int64(time.Millisecond)
+	case Microsecond:
+		return t.UnixNano() / int64(time.Microsecond)
+	case Nanosecond:
+		return t.UnixNano()
+	default:
+		return t.Format(time.RFC3339Nano)
+	}
+}
+
+// NewMultiResultEncoder returns an encoder of results as InfluxQL JSON.
 func NewMultiResultEncoder() *MultiResultEncoder {
 	return new(MultiResultEncoder)
 }
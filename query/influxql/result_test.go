@@ -16,6 +16,7 @@ import (
 func TestMultiResultEncoder_Encode(t *testing.T) {
 	for _, tt := range []struct {
 		name string
+		enc  *influxql.MultiResultEncoder
 		in   flux.ResultIterator
 		out  string
 	}{
@@ -83,6 +84,90 @@ func TestMultiResultEncoder_Encode(t *testing.T) {
 			in:   &resultErrorIterator{Error: "expected"},
 			out:  `{"error":"expected"}`,
 		},
+		{
+			name: "Epoch",
+			enc:  &influxql.MultiResultEncoder{TimeFormat: influxql.Second},
+			in: flux.NewSliceResultIterator(
+				[]flux.Result{&executetest.Result{
+					Nm: "0",
+					Tbls: []*executetest.Table{{
+						KeyCols: []string{"_measurement"},
+						ColMeta: []flux.ColMeta{
+							{Label: "_time", Type: flux.TTime},
+							{Label: "_measurement", Type: flux.TString},
+							{Label: "value", Type: flux.TFloat},
+						},
+						Data: [][]interface{}{
+							{ts("2018-05-24T09:00:00Z"), "m0", float64(2)},
+						},
+					}},
+				}},
+			),
+			out: `{"results":[{"statement_id":0,"series":[{"name":"m0","columns":["time","value"],"values":[[1527152400,2]]}]}]}`,
+		},
+		{
+			name: "CSV",
+			enc:  &influxql.MultiResultEncoder{Encoding: influxql.CSV},
+			in: flux.NewSliceResultIterator(
+				[]flux.Result{
+					&executetest.Result{
+						Nm: "0",
+						Tbls: []*executetest.Table{
+							{
+								KeyCols: []string{"_measurement", "host"},
+								ColMeta: []flux.ColMeta{
+									{Label: "_time", Type: flux.TTime},
+									{Label: "_measurement", Type: flux.TString},
+									{Label: "host", Type: flux.TString},
+									{Label: "value", Type: flux.TFloat},
+								},
+								Data: [][]interface{}{
+									{ts("2018-05-24T09:00:00Z"), "m0", "server01", float64(2)},
+									{ts("2018-05-24T09:00:10Z"), "m0", "server01", float64(2.5)},
+								},
+							},
+							{
+								KeyCols: []string{"_measurement", "host"},
+								ColMeta: []flux.ColMeta{
+									{Label: "_time", Type: flux.TTime},
+									{Label: "_measurement", Type: flux.TString},
+									{Label: "host", Type: flux.TString},
+									{Label: "value", Type: flux.TFloat},
+								},
+								Data: [][]interface{}{
+									{ts("2018-05-24T09:00:00Z"), "m0", "server02", float64(3)},
+								},
+							},
+						},
+					},
+					&executetest.Result{
+						Nm: "1",
+						Tbls: []*executetest.Table{{
+							KeyCols: []string{},
+							ColMeta: []flux.ColMeta{
+								{Label: "name", Type: flux.TString},
+							},
+							Data: [][]interface{}{
+								{"telegraf"},
+							},
+						}},
+					},
+				},
+			),
+			out: "name,tags,time,value\n" +
+				"m0,host=server01,1527152400000000000,2\n" +
+				"m0,host=server01,1527152410000000000,2.5\n" +
+				"m0,host=server02,1527152400000000000,3\n" +
+				"\n" +
+				"name,tags,name\n" +
+				",,telegraf",
+		},
+		{
+			name: "CSV Error",
+			enc:  &influxql.MultiResultEncoder{Encoding: influxql.CSV},
+			in:   &resultErrorIterator{Error: "expected"},
+			out:  "error\nexpected",
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
@@ -90,7 +175,10 @@ func TestMultiResultEncoder_Encode(t *testing.T) {
 			tt.out += "\n"
 
 			var buf bytes.Buffer
-			enc := influxql.NewMultiResultEncoder()
+			enc := tt.enc
+			if enc == nil {
+				enc = influxql.NewMultiResultEncoder()
+			}
 			n, err := enc.Encode(&buf, tt.in)
 			if err != nil {
 				t.Fatalf("unexpected error: %s", err)
@@ -16,6 +16,8 @@ type Logger interface {
 type Log struct {
 	// Time is the time the query was completed
 	Time time.Time
+	// Duration is the time taken by the query
+	Duration time.Duration
 	// OrganizationID is the ID of the organization that requested the query
 	OrganizationID platform.ID
 	// Error is any error encountered by the query
@@ -28,6 +28,7 @@ func (s *LoggingProxyQueryService) Query(ctx context.Context, w io.Writer, req *
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
 
+	start := s.now()
 	var n int64
 	defer func() {
 		if r := recover(); r != nil {
@@ -37,17 +38,13 @@ func (s *LoggingProxyQueryService) Query(ctx context.Context, w io.Writer, req *
 				entry.Write(zap.Error(err))
 			}
 		}
-		var now time.Time
-		if s.NowFunction != nil {
-			now = s.NowFunction()
-		} else {
-			now = time.Now()
-		}
+		now := s.now()
 		log := Log{
 			OrganizationID: req.Request.OrganizationID,
 			ProxyRequest:   req,
 			ResponseSize:   n,
 			Time:           now,
+			Duration:       now.Sub(start),
 			Statistics:     stats,
 			Error:          err,
 		}
@@ -63,6 +60,13 @@ func (s *LoggingProxyQueryService) Query(ctx context.Context, w io.Writer, req *
 	return stats, nil
 }
 
+func (s *LoggingProxyQueryService) now() time.Time {
+	if s.NowFunction != nil {
+		return s.NowFunction()
+	}
+	return time.Now()
+}
+
 func (s *LoggingProxyQueryService) Check(ctx context.Context) check.Response {
 	return s.ProxyQueryService.Check(ctx)
 }
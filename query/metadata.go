@@ -0,0 +1,28 @@
+package query
+
+import "github.com/influxdata/flux"
+
+// Keys of the metadata of the statistics of the queries reading from or writing to storage.
+const (
+	// ScannedValuesMetadataKey is the key of the number of values read by each storage source.
+	ScannedValuesMetadataKey = "influxdb/scanned-values"
+	// ScannedBytesMetadataKey is the key of the number of bytes read by each storage source.
+	ScannedBytesMetadataKey = "influxdb/scanned-bytes"
+	// RowsWrittenMetadataKey is the key of the number of rows written by the to() calls.
+	RowsWrittenMetadataKey = "influxdb/rows-written"
+)
+
+// SumMetadata sums the integer values of the metadata key, which may be reported by
+// several sources of the query.
+func SumMetadata(m flux.Metadata, key string) int64 {
+	var n int64
+	for _, v := range m[key] {
+		switch v := v.(type) {
+		case int64:
+			n += v
+		case int:
+			n += int64(v)
+		}
+	}
+	return n
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/lang"
 	platform "github.com/influxdata/influxdb"
 )
 
@@ -70,6 +73,29 @@ func (r Request) MarshalJSON() ([]byte, error) {
 	return json.Marshal(raw)
 }
 
+// CompilerText returns the text of the query compiled by c, for display.
+// Compilers other than the Flux ones are described by their JSON encoding.
+func CompilerText(c flux.Compiler) string {
+	switch c := c.(type) {
+	case nil:
+		return ""
+	case lang.FluxCompiler:
+		return c.Query
+	case *lang.FluxCompiler:
+		return c.Query
+	case lang.ASTCompiler:
+		return ast.Format(c.AST)
+	case *lang.ASTCompiler:
+		return ast.Format(c.AST)
+	}
+
+	octets, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Sprintf("%s query", c.CompilerType())
+	}
+	return string(octets)
+}
+
 type contextKey struct{}
 
 var activeContextKey = contextKey{}
@@ -79,6 +105,48 @@ func ContextWithRequest(ctx context.Context, req *Request) context.Context {
 	return context.WithValue(ctx, activeContextKey, req)
 }
 
+type dryRunContextKey struct{}
+
+// ContextWithDryRun returns a new context whose queries do not write the data of their to() calls.
+// It is only honored by the to() function of the influxdata/influxdb package.
+func ContextWithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunContextKey{}, true)
+}
+
+// IsDryRun reports whether the queries of the context must not write data.
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunContextKey{}).(bool)
+	return dryRun
+}
+
+// WriteCounter counts the rows written by the to() calls of a query.
+type WriteCounter struct {
+	rows int64
+}
+
+// AddRows records that n rows were written.
+func (c *WriteCounter) AddRows(n int64) {
+	atomic.AddInt64(&c.rows, n)
+}
+
+// Rows returns the number of rows written.
+func (c *WriteCounter) Rows() int64 {
+	return atomic.LoadInt64(&c.rows)
+}
+
+type writeCounterContextKey struct{}
+
+// ContextWithWriteCounter returns a new context whose queries count the rows they write with c.
+func ContextWithWriteCounter(ctx context.Context, c *WriteCounter) context.Context {
+	return context.WithValue(ctx, writeCounterContextKey{}, c)
+}
+
+// WriteCounterFromContext returns the write counter of the context, or nil if it has none.
+func WriteCounterFromContext(ctx context.Context) *WriteCounter {
+	c, _ := ctx.Value(writeCounterContextKey{}).(*WriteCounter)
+	return c
+}
+
 //RequestFromContext retrieves a *Request from a context.
 // If not request exists on the context nil is returned.
 func RequestFromContext(ctx context.Context) *Request {
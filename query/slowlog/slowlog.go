@@ -0,0 +1,205 @@
+// Package slowlog persists the queries slower than a threshold as points into
+// the queries system bucket of their organization, so that they can be charted
+// and alerted on with Flux, such as
+//
+//	from(bucketID: "000000000000000b") |> range(start: -1d) |> filter(fn: (r) => r._measurement == "queries")
+package slowlog
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+const (
+	measurement = "queries"
+
+	compilerTypeTag = "compilerType"
+	statusTag       = "status"
+
+	queryField           = "query"
+	userIDField          = "userID"
+	errorField           = "error"
+	durationField        = "duration"
+	responseSizeField    = "responseSize"
+	totalDurationField   = "totalDuration"
+	compileDurationField = "compileDuration"
+	queueDurationField   = "queueDuration"
+	planDurationField    = "planDuration"
+	requeueDurationField = "requeueDuration"
+	executeDurationField = "executeDuration"
+	concurrencyField     = "concurrency"
+	maxAllocatedField    = "maxAllocated"
+	scannedValuesField   = "scannedValues"
+	scannedBytesField    = "scannedBytes"
+
+	statusSuccess = "success"
+	statusFailed  = "failed"
+)
+
+// DefaultQueueSize is the number of slow queries waiting to be written above which
+// the slow queries are dropped.
+const DefaultQueueSize = 1000
+
+// ErrQueueFull is returned when a slow query is dropped because too many slow queries
+// are waiting to be written.
+var ErrQueueFull = errors.New("slow query log queue is full")
+
+// SystemBucketID is the fixed ID of the bucket of each organization the slow queries are written to.
+const SystemBucketID = platform.ID(platform.BucketTypeQueries)
+
+// PointsWriter is a copy of the storage.PointsWriter interface, to avoid depending on storage.
+type PointsWriter interface {
+	WritePoints(ctx context.Context, points []models.Point) error
+}
+
+var _ query.Logger = (*Logger)(nil)
+
+// Logger is a query.Logger writing the queries that took at least a threshold.
+//
+// The queries are written in the background, so that the slow queries are not
+// slowed down further by the writes. The queries logged while DefaultQueueSize
+// queries are waiting to be written are dropped.
+type Logger struct {
+	pointsWriter PointsWriter
+	threshold    time.Duration
+	logger       *zap.Logger
+
+	mu     sync.RWMutex // guards closed, so that no point is queued once queue is closed
+	closed bool
+	queue  chan []models.Point
+	wg     sync.WaitGroup
+}
+
+// NewLogger returns a Logger writing the queries that took at least threshold.
+// It must be closed to write the queued queries and stop writing.
+func NewLogger(pw PointsWriter, threshold time.Duration, logger *zap.Logger) *Logger {
+	l := &Logger{
+		pointsWriter: pw,
+		threshold:    threshold,
+		logger:       logger,
+		queue:        make(chan []models.Point, DefaultQueueSize),
+	}
+
+	l.wg.Add(1)
+	go l.writePoints()
+	return l
+}
+
+// Close writes the queued queries, and stops writing the queries logged later.
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.queue)
+	}
+	l.mu.Unlock()
+
+	l.wg.Wait()
+	return nil
+}
+
+func (l *Logger) writePoints() {
+	defer l.wg.Done()
+	for points := range l.queue {
+		if err := l.pointsWriter.WritePoints(context.Background(), points); err != nil {
+			l.logger.Error("Failed to write slow query", zap.Error(err))
+		}
+	}
+}
+
+// Log queues the query to be written if it was slow.
+func (l *Logger) Log(log query.Log) error {
+	d := log.Duration
+	if d == 0 {
+		d = log.Statistics.TotalDuration
+	}
+	if d < l.threshold {
+		return nil
+	}
+
+	pt, err := newPoint(log, d)
+	if err != nil {
+		l.logger.Error("Failed to create slow query point", zap.Error(err))
+		return err
+	}
+
+	points, err := tsdb.ExplodePoints(log.OrganizationID, SystemBucketID, models.Points{pt})
+	if err != nil {
+		l.logger.Error("Failed to create slow query point", zap.Error(err))
+		return err
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		return nil
+	}
+
+	select {
+	case l.queue <- points:
+		return nil
+	default:
+		l.logger.Warn("Dropping slow query", zap.Error(ErrQueueFull))
+		return ErrQueueFull
+	}
+}
+
+func newPoint(log query.Log, d time.Duration) (models.Point, error) {
+	var (
+		compilerType flux.CompilerType
+		text         string
+		userID       platform.ID
+	)
+	if req := log.ProxyRequest; req != nil {
+		if req.Request.Compiler != nil {
+			compilerType = req.Request.Compiler.CompilerType()
+			text = query.CompilerText(req.Request.Compiler)
+		}
+		if req.Request.Authorization != nil {
+			userID = req.Request.Authorization.UserID
+		}
+	}
+
+	status := statusSuccess
+	if log.Error != nil {
+		status = statusFailed
+	}
+	tags := map[string]string{statusTag: status}
+	if compilerType != "" {
+		tags[compilerTypeTag] = string(compilerType)
+	}
+
+	stats := log.Statistics
+	fields := map[string]interface{}{
+		queryField:           text,
+		durationField:        int64(d),
+		responseSizeField:    log.ResponseSize,
+		totalDurationField:   int64(stats.TotalDuration),
+		compileDurationField: int64(stats.CompileDuration),
+		queueDurationField:   int64(stats.QueueDuration),
+		planDurationField:    int64(stats.PlanDuration),
+		requeueDurationField: int64(stats.RequeueDuration),
+		executeDurationField: int64(stats.ExecuteDuration),
+		concurrencyField:     int64(stats.Concurrency),
+		maxAllocatedField:    stats.MaxAllocated,
+		scannedValuesField:   query.SumMetadata(stats.Metadata, query.ScannedValuesMetadataKey),
+		scannedBytesField:    query.SumMetadata(stats.Metadata, query.ScannedBytesMetadataKey),
+	}
+	if userID.Valid() {
+		fields[userIDField] = userID.String()
+	}
+	if log.Error != nil {
+		fields[errorField] = log.Error.Error()
+	}
+
+	return models.NewPoint(measurement, models.NewTags(tags), fields, log.Time)
+}
@@ -0,0 +1,175 @@
+package slowlog_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/lang"
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/slowlog"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+type pointsWriter struct {
+	points []models.Point
+}
+
+func (w *pointsWriter) WritePoints(ctx context.Context, points []models.Point) error {
+	w.points = append(w.points, points...)
+	return nil
+}
+
+func TestLogger_Log(t *testing.T) {
+	orgID := platform.ID(1)
+	now := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	newLog := func(d time.Duration, err error) query.Log {
+		return query.Log{
+			Time:           now,
+			Duration:       d,
+			OrganizationID: orgID,
+			Error:          err,
+			ProxyRequest: &query.ProxyRequest{
+				Request: query.Request{
+					Authorization:  &platform.Authorization{UserID: 2},
+					OrganizationID: orgID,
+					Compiler:       lang.FluxCompiler{Query: `from(bucket: "b") |> range(start: -1h)`},
+				},
+			},
+			ResponseSize: 100,
+			Statistics: flux.Statistics{
+				TotalDuration:   d,
+				ExecuteDuration: d / 2,
+				Concurrency:     2,
+				MaxAllocated:    1024,
+				Metadata: flux.Metadata{
+					"influxdb/scanned-values": []interface{}{int64(10), int64(5)},
+					"influxdb/scanned-bytes":  []interface{}{int64(80), int64(40)},
+				},
+			},
+		}
+	}
+
+	w := &pointsWriter{}
+	l := slowlog.NewLogger(w, time.Second, zap.NewNop())
+
+	if err := l.Log(newLog(time.Millisecond, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if len(w.points) != 0 {
+		t.Fatalf("expected fast queries not to be written, got %d points", len(w.points))
+	}
+
+	if err := l.Log(newLog(2*time.Second, errors.New("boom"))); err != nil {
+		t.Fatal(err)
+	}
+	// Close waits for the queued queries to be written.
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	name := tsdb.EncodeNameString(orgID, slowlog.SystemBucketID)
+	got := make(map[string]interface{})
+	for _, pt := range w.points {
+		if string(pt.Name()) != name {
+			t.Fatalf("expected the point to be written to the system bucket, got name %q", pt.Name())
+		}
+		if !pt.Time().Equal(now) {
+			t.Fatalf("unexpected time %v", pt.Time())
+		}
+		tags := pt.Tags()
+		if m := tags.GetString(models.MeasurementTagKey); m != "queries" {
+			t.Fatalf("unexpected measurement %q", m)
+		}
+		if s := tags.GetString("status"); s != "failed" {
+			t.Fatalf("unexpected status %q", s)
+		}
+		if c := tags.GetString("compilerType"); c != string(lang.FluxCompilerType) {
+			t.Fatalf("unexpected compiler type %q", c)
+		}
+		fields, err := pt.Fields()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range fields {
+			got[k] = v
+		}
+	}
+
+	exp := map[string]interface{}{
+		"query":           `from(bucket: "b") |> range(start: -1h)`,
+		"userID":          platform.ID(2).String(),
+		"error":           "boom",
+		"duration":        int64(2 * time.Second),
+		"responseSize":    int64(100),
+		"totalDuration":   int64(2 * time.Second),
+		"compileDuration": int64(0),
+		"queueDuration":   int64(0),
+		"planDuration":    int64(0),
+		"requeueDuration": int64(0),
+		"executeDuration": int64(time.Second),
+		"concurrency":     int64(2),
+		"maxAllocated":    int64(1024),
+		"scannedValues":   int64(15),
+		"scannedBytes":    int64(120),
+	}
+	if diff := cmp.Diff(got, exp); diff != "" {
+		t.Fatalf("unexpected fields -got/+exp\n%s", diff)
+	}
+}
+
+// TestLogger_Engine writes a slow query to an engine enforcing the series limits of
+// the buckets of a kv service, which has no record of the system bucket.
+func TestLogger_Engine(t *testing.T) {
+	ctx := context.Background()
+	svc := kv.NewService(inmem.NewKVStore())
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+	org := &platform.Organization{Name: "org", MaxSeries: 100}
+	if err := svc.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := ioutil.TempDir("", "slowlog_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	engine := storage.NewEngine(path, storage.NewConfig(), storage.WithSeriesLimits(svc, svc))
+	if err := engine.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+
+	l := slowlog.NewLogger(engine, time.Second, zap.NewNop())
+	if err := l.Log(query.Log{
+		Time:           time.Now(),
+		Duration:       2 * time.Second,
+		OrganizationID: org.ID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := engine.BucketSeriesCardinality(ctx, org.ID, slowlog.SystemBucketID)
+	if err != nil {
+		t.Fatal(err)
+	} else if n == 0 {
+		t.Fatal("expected the slow query to be written to the system bucket")
+	}
+}
@@ -13,9 +13,12 @@ import (
 )
 
 const (
-	ReadRangePhysKind     = "ReadRangePhysKind"
-	ReadTagKeysPhysKind   = "ReadTagKeysPhysKind"
-	ReadTagValuesPhysKind = "ReadTagValuesPhysKind"
+	ReadRangePhysKind             = "ReadRangePhysKind"
+	ReadTagKeysPhysKind           = "ReadTagKeysPhysKind"
+	ReadTagValuesPhysKind         = "ReadTagValuesPhysKind"
+	ReadWindowAggregatePhysKind   = "ReadWindowAggregatePhysKind"
+	ReadGroupPhysKind             = "ReadGroupPhysKind"
+	ReadMeasurementFieldsPhysKind = "ReadMeasurementFieldsPhysKind"
 )
 
 type ReadRangePhysSpec struct {
@@ -32,6 +35,13 @@ type ReadRangePhysSpec struct {
 	Filter *semantic.FunctionExpression
 
 	Bounds flux.Bounds
+
+	// PointsLimit is the maximum number of points to read
+	// from each series. A value of 0 reads all of the points.
+	PointsLimit int64
+	// Descending reads the points of each series in
+	// descending time order.
+	Descending bool
 }
 
 func (s *ReadRangePhysSpec) Kind() plan.ProcedureKind {
@@ -50,9 +60,18 @@ func (s *ReadRangePhysSpec) Copy() plan.ProcedureSpec {
 
 	ns.Bounds = s.Bounds
 
+	ns.PointsLimit = s.PointsLimit
+	ns.Descending = s.Descending
+
 	return ns
 }
 
+// LimitOrOrderSet returns true if the read has been limited or
+// ordered, in which case no other operations may be merged into it.
+func (s *ReadRangePhysSpec) LimitOrOrderSet() bool {
+	return s.PointsLimit > 0 || s.Descending
+}
+
 func (s *ReadRangePhysSpec) PostPhysicalValidate(id plan.NodeID) error {
 	if s.Bounds.Start.IsZero() && s.Bounds.Stop.IsZero() {
 		var bucket string
@@ -123,3 +142,72 @@ func (s *ReadTagValuesPhysSpec) Copy() plan.ProcedureSpec {
 	ns.TagKey = s.TagKey
 	return ns
 }
+
+// ReadMeasurementFieldsPhysSpec reads the key and type of the fields
+// of the measurements with series in the range.
+type ReadMeasurementFieldsPhysSpec struct {
+	ReadRangePhysSpec
+}
+
+func (s *ReadMeasurementFieldsPhysSpec) Kind() plan.ProcedureKind {
+	return ReadMeasurementFieldsPhysKind
+}
+
+func (s *ReadMeasurementFieldsPhysSpec) Copy() plan.ProcedureSpec {
+	ns := new(ReadMeasurementFieldsPhysSpec)
+	ns.ReadRangePhysSpec = *s.ReadRangePhysSpec.Copy().(*ReadRangePhysSpec)
+	return ns
+}
+
+// ReadWindowAggregatePhysSpec reads a range of data and computes
+// the given aggregates for each window of WindowEvery nanoseconds
+// within the storage layer. A WindowEvery of 0 computes the
+// aggregates over the entire range. If CreateEmpty is set, the
+// windows without any points of each series read are output too.
+type ReadWindowAggregatePhysSpec struct {
+	ReadRangePhysSpec
+
+	WindowEvery int64
+	Aggregates  []plan.ProcedureKind
+	CreateEmpty bool
+}
+
+func (s *ReadWindowAggregatePhysSpec) Kind() plan.ProcedureKind {
+	return ReadWindowAggregatePhysKind
+}
+
+func (s *ReadWindowAggregatePhysSpec) Copy() plan.ProcedureSpec {
+	ns := new(ReadWindowAggregatePhysSpec)
+	ns.ReadRangePhysSpec = *s.ReadRangePhysSpec.Copy().(*ReadRangePhysSpec)
+	ns.WindowEvery = s.WindowEvery
+	ns.Aggregates = make([]plan.ProcedureKind, len(s.Aggregates))
+	copy(ns.Aggregates, s.Aggregates)
+	ns.CreateEmpty = s.CreateEmpty
+	return ns
+}
+
+// ReadGroupPhysSpec reads a range of data with the series grouped
+// by GroupKeys within the storage layer. If AggregateMethod is set,
+// each group is reduced to a single row by that aggregate.
+type ReadGroupPhysSpec struct {
+	ReadRangePhysSpec
+
+	GroupMode flux.GroupMode
+	GroupKeys []string
+
+	AggregateMethod string
+}
+
+func (s *ReadGroupPhysSpec) Kind() plan.ProcedureKind {
+	return ReadGroupPhysKind
+}
+
+func (s *ReadGroupPhysSpec) Copy() plan.ProcedureSpec {
+	ns := new(ReadGroupPhysSpec)
+	ns.ReadRangePhysSpec = *s.ReadRangePhysSpec.Copy().(*ReadRangePhysSpec)
+	ns.GroupMode = s.GroupMode
+	ns.GroupKeys = make([]string, len(s.GroupKeys))
+	copy(ns.GroupKeys, s.GroupKeys)
+	ns.AggregateMethod = s.AggregateMethod
+	return ns
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb/schema"
 )
 
 func init() {
@@ -15,6 +16,26 @@ func init() {
 		PushDownFilterRule{},
 		//PushDownReadTagKeysRule{},
 		PushDownReadTagValuesRule{},
+		PushDownWindowAggregateRule{Kind: universe.CountKind},
+		PushDownWindowAggregateRule{Kind: universe.SumKind},
+		PushDownWindowAggregateRule{Kind: universe.MeanKind},
+		PushDownWindowAggregateRule{Kind: universe.MinKind},
+		PushDownWindowAggregateRule{Kind: universe.MaxKind},
+		PushDownWindowAggregateRule{Kind: universe.FirstKind},
+		PushDownWindowAggregateRule{Kind: universe.LastKind},
+		PushDownSelectorRule{Kind: universe.FirstKind},
+		PushDownSelectorRule{Kind: universe.LastKind},
+		PushDownSelectorRule{Kind: universe.MinKind},
+		PushDownSelectorRule{Kind: universe.MaxKind},
+		PushDownGroupRule{},
+		PushDownGroupAggregateRule{Kind: universe.CountKind},
+		PushDownGroupAggregateRule{Kind: universe.SumKind},
+		PushDownLimitRule{},
+		PushDownTailRule{},
+		PushDownDescendingSortRule{},
+		PushDownSchemaRule{Kind: schema.MeasurementsKind},
+		PushDownSchemaRule{Kind: schema.TagKeysKind},
+		PushDownSchemaRule{Kind: schema.FieldKeysKind},
 	)
 }
 
@@ -61,6 +82,11 @@ func (PushDownFilterRule) Rewrite(pn plan.Node) (plan.Node, bool, error) {
 	fromNode := pn.Predecessors()[0]
 	fromSpec := fromNode.ProcedureSpec().(*ReadRangePhysSpec)
 
+	// A limit must be applied after the filter, not before.
+	if fromSpec.PointsLimit > 0 {
+		return pn, false, nil
+	}
+
 	bodyExpr, ok := filterSpec.Fn.Block.Body.(semantic.Expression)
 	if !ok {
 		return pn, false, nil
@@ -213,6 +239,9 @@ func (rule PushDownReadTagValuesRule) Rewrite(pn plan.Node) (plan.Node, bool, er
 	// from spec if it existed so we will take that one when
 	// constructing our own replacement. We do not care about it
 	// at the moment though which is why it is not in the pattern.
+	if fromSpec.LimitOrOrderSet() {
+		return pn, false, nil
+	}
 
 	// All of the values need to be grouped into the same table.
 	if groupSpec.GroupMode != flux.GroupModeBy {
@@ -252,6 +281,343 @@ func (rule PushDownReadTagValuesRule) Rewrite(pn plan.Node) (plan.Node, bool, er
 	}), true, nil
 }
 
+// PushDownWindowAggregateRule matches 'ReadRange |> window |> agg' where agg
+// is the aggregate of kind Kind and rewrites it into 'ReadWindowAggregate'.
+// The 'from()' must have already been merged with 'range' and, optionally,
+// may have been merged with 'filter'.
+// Only windows aligned to the epoch with the default columns are pushed
+// down, as that is all the storage layer is able to compute.
+type PushDownWindowAggregateRule struct {
+	Kind plan.ProcedureKind
+}
+
+func (rule PushDownWindowAggregateRule) Name() string {
+	return "PushDownWindowAggregateRule/" + string(rule.Kind)
+}
+
+func (rule PushDownWindowAggregateRule) Pattern() plan.Pattern {
+	return plan.Pat(rule.Kind,
+		plan.Pat(universe.WindowKind,
+			plan.Pat(ReadRangePhysKind)))
+}
+
+func (rule PushDownWindowAggregateRule) Rewrite(pn plan.Node) (plan.Node, bool, error) {
+	// Retrieve the nodes and specs for all of the predecessors.
+	aggSpec := pn.ProcedureSpec()
+	windowNode := pn.Predecessors()[0]
+	windowSpec := windowNode.ProcedureSpec().(*universe.WindowProcedureSpec)
+	fromNode := windowNode.Predecessors()[0]
+	fromSpec := fromNode.ProcedureSpec().(*ReadRangePhysSpec)
+
+	// The window and the read are replaced by the new node so
+	// nothing else may depend on them.
+	if len(windowNode.Successors()) != 1 || len(fromNode.Successors()) != 1 {
+		return pn, false, nil
+	} else if fromSpec.LimitOrOrderSet() {
+		return pn, false, nil
+	}
+
+	if !isPushableWindowAggregate(aggSpec) {
+		return pn, false, nil
+	}
+
+	// Storage computes tumbling windows aligned to the epoch.
+	window := windowSpec.Window
+	if window.Every <= 0 || window.Every != window.Period {
+		return pn, false, nil
+	} else if window.Round != 0 || !window.Start.IsZero() {
+		return pn, false, nil
+	}
+
+	if windowSpec.TimeColumn != execute.DefaultTimeColLabel ||
+		windowSpec.StartColumn != execute.DefaultStartColLabel ||
+		windowSpec.StopColumn != execute.DefaultStopColLabel {
+		return pn, false, nil
+	}
+
+	// We have passed all of the necessary prerequisites
+	// so construct the procedure spec. Storage also creates
+	// the empty windows, as aggregateWindow() does by default.
+	return plan.CreatePhysicalNode("ReadWindowAggregate", &ReadWindowAggregatePhysSpec{
+		ReadRangePhysSpec: *fromSpec.Copy().(*ReadRangePhysSpec),
+		WindowEvery:       int64(window.Every),
+		Aggregates:        []plan.ProcedureKind{aggSpec.Kind()},
+		CreateEmpty:       windowSpec.CreateEmpty,
+	}), true, nil
+}
+
+// isPushableWindowAggregate returns true if the aggregate only
+// operates on the value column and can be computed by storage.
+func isPushableWindowAggregate(spec plan.ProcedureSpec) bool {
+	switch spec := spec.(type) {
+	case *universe.CountProcedureSpec:
+		return isDefaultValueColumns(spec.Columns)
+	case *universe.SumProcedureSpec:
+		return isDefaultValueColumns(spec.Columns)
+	case *universe.MeanProcedureSpec:
+		return isDefaultValueColumns(spec.Columns)
+	case *universe.MinProcedureSpec:
+		return spec.Column == execute.DefaultValueColLabel
+	case *universe.MaxProcedureSpec:
+		return spec.Column == execute.DefaultValueColLabel
+	case *universe.FirstProcedureSpec:
+		return spec.Column == execute.DefaultValueColLabel
+	case *universe.LastProcedureSpec:
+		return spec.Column == execute.DefaultValueColLabel
+	default:
+		return false
+	}
+}
+
+// PushDownSelectorRule matches 'ReadRange |> sel' where sel is the selector
+// of kind Kind and rewrites it into a 'ReadWindowAggregate' over the entire
+// range, so storage only returns the selected point of each series.
+// The 'from()' must have already been merged with 'range' and, optionally,
+// may have been merged with 'filter'.
+type PushDownSelectorRule struct {
+	Kind plan.ProcedureKind
+}
+
+func (rule PushDownSelectorRule) Name() string {
+	return "PushDownSelectorRule/" + string(rule.Kind)
+}
+
+func (rule PushDownSelectorRule) Pattern() plan.Pattern {
+	return plan.Pat(rule.Kind, plan.Pat(ReadRangePhysKind))
+}
+
+func (rule PushDownSelectorRule) Rewrite(pn plan.Node) (plan.Node, bool, error) {
+	selectorSpec := pn.ProcedureSpec()
+	fromNode := pn.Predecessors()[0]
+	fromSpec := fromNode.ProcedureSpec().(*ReadRangePhysSpec)
+
+	// The read is replaced by the new node so nothing
+	// else may depend on it.
+	if len(fromNode.Successors()) != 1 {
+		return pn, false, nil
+	} else if fromSpec.LimitOrOrderSet() {
+		return pn, false, nil
+	}
+
+	if !isPushableWindowAggregate(selectorSpec) {
+		return pn, false, nil
+	}
+
+	return plan.CreatePhysicalNode("ReadWindowAggregate", &ReadWindowAggregatePhysSpec{
+		ReadRangePhysSpec: *fromSpec.Copy().(*ReadRangePhysSpec),
+		Aggregates:        []plan.ProcedureKind{selectorSpec.Kind()},
+	}), true, nil
+}
+
+func isDefaultValueColumns(columns []string) bool {
+	return len(columns) == 1 && columns[0] == execute.DefaultValueColLabel
+}
+
+// PushDownGroupRule matches 'ReadRange |> group(columns: [...])' and
+// rewrites it into 'ReadGroup' so the series are grouped by storage.
+// The 'from()' must have already been merged with 'range' and, optionally,
+// may have been merged with 'filter'.
+type PushDownGroupRule struct{}
+
+func (rule PushDownGroupRule) Name() string {
+	return "PushDownGroupRule"
+}
+
+func (rule PushDownGroupRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.GroupKind, plan.Pat(ReadRangePhysKind))
+}
+
+func (rule PushDownGroupRule) Rewrite(pn plan.Node) (plan.Node, bool, error) {
+	groupSpec := pn.ProcedureSpec().(*universe.GroupProcedureSpec)
+	fromNode := pn.Predecessors()[0]
+	fromSpec := fromNode.ProcedureSpec().(*ReadRangePhysSpec)
+
+	// The read is replaced by the new node so nothing
+	// else may depend on it.
+	if len(fromNode.Successors()) != 1 {
+		return pn, false, nil
+	} else if fromSpec.LimitOrOrderSet() {
+		return pn, false, nil
+	}
+
+	// Storage only supports grouping by a set of tag keys. The measurement and
+	// field are stored under other tag keys than their column, so the series are
+	// not grouped by them in storage.
+	if groupSpec.GroupMode != flux.GroupModeBy {
+		return pn, false, nil
+	}
+	for _, k := range groupSpec.GroupKeys {
+		switch k {
+		case execute.DefaultTimeColLabel, execute.DefaultValueColLabel, "_measurement", "_field":
+			return pn, false, nil
+		}
+	}
+
+	return plan.CreatePhysicalNode("ReadGroup", &ReadGroupPhysSpec{
+		ReadRangePhysSpec: *fromSpec.Copy().(*ReadRangePhysSpec),
+		GroupMode:         groupSpec.GroupMode,
+		GroupKeys:         groupSpec.GroupKeys,
+	}), true, nil
+}
+
+// PushDownGroupAggregateRule matches 'ReadGroup |> agg' where agg is
+// the aggregate of kind Kind and merges the aggregate into the read,
+// so storage produces a single row for each group.
+type PushDownGroupAggregateRule struct {
+	Kind plan.ProcedureKind
+}
+
+func (rule PushDownGroupAggregateRule) Name() string {
+	return "PushDownGroupAggregateRule/" + string(rule.Kind)
+}
+
+func (rule PushDownGroupAggregateRule) Pattern() plan.Pattern {
+	return plan.Pat(rule.Kind, plan.Pat(ReadGroupPhysKind))
+}
+
+func (rule PushDownGroupAggregateRule) Rewrite(pn plan.Node) (plan.Node, bool, error) {
+	aggSpec := pn.ProcedureSpec()
+	groupNode := pn.Predecessors()[0]
+	groupSpec := groupNode.ProcedureSpec().(*ReadGroupPhysSpec)
+
+	if len(groupNode.Successors()) != 1 || groupSpec.AggregateMethod != "" {
+		return pn, false, nil
+	}
+
+	switch spec := aggSpec.(type) {
+	case *universe.CountProcedureSpec:
+		if !isDefaultValueColumns(spec.Columns) {
+			return pn, false, nil
+		}
+	case *universe.SumProcedureSpec:
+		if !isDefaultValueColumns(spec.Columns) {
+			return pn, false, nil
+		}
+	default:
+		return pn, false, nil
+	}
+
+	newGroupSpec := groupSpec.Copy().(*ReadGroupPhysSpec)
+	newGroupSpec.AggregateMethod = string(aggSpec.Kind())
+	merged, err := plan.MergeToPhysicalNode(pn, groupNode, newGroupSpec)
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
+// PushDownLimitRule matches 'ReadRange |> limit(n)' and sets the
+// points limit of the read, so storage stops reading each series
+// after n points.
+type PushDownLimitRule struct{}
+
+func (rule PushDownLimitRule) Name() string {
+	return "PushDownLimitRule"
+}
+
+func (rule PushDownLimitRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.LimitKind, plan.Pat(ReadRangePhysKind))
+}
+
+func (rule PushDownLimitRule) Rewrite(pn plan.Node) (plan.Node, bool, error) {
+	limitSpec := pn.ProcedureSpec().(*universe.LimitProcedureSpec)
+	fromNode := pn.Predecessors()[0]
+	fromSpec := fromNode.ProcedureSpec().(*ReadRangePhysSpec)
+
+	if len(fromNode.Successors()) != 1 {
+		return pn, false, nil
+	} else if limitSpec.N <= 0 || limitSpec.Offset != 0 {
+		return pn, false, nil
+	}
+
+	newFromSpec := fromSpec.Copy().(*ReadRangePhysSpec)
+	if newFromSpec.PointsLimit == 0 || limitSpec.N < newFromSpec.PointsLimit {
+		newFromSpec.PointsLimit = limitSpec.N
+	}
+
+	merged, err := plan.MergeToPhysicalNode(pn, fromNode, newFromSpec)
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
+// PushDownTailRule matches 'ReadRange |> tail(n)' and rewrites it into
+// a descending read of n points from each series. The tail is replaced
+// by a sort, as tail must produce the points in ascending order.
+type PushDownTailRule struct{}
+
+func (rule PushDownTailRule) Name() string {
+	return "PushDownTailRule"
+}
+
+func (rule PushDownTailRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.TailKind, plan.Pat(ReadRangePhysKind))
+}
+
+func (rule PushDownTailRule) Rewrite(pn plan.Node) (plan.Node, bool, error) {
+	tailSpec := pn.ProcedureSpec().(*universe.TailProcedureSpec)
+	fromNode := pn.Predecessors()[0]
+	fromSpec := fromNode.ProcedureSpec().(*ReadRangePhysSpec)
+
+	if len(fromNode.Successors()) != 1 || fromSpec.LimitOrOrderSet() {
+		return pn, false, nil
+	} else if tailSpec.N <= 0 || tailSpec.Offset != 0 {
+		return pn, false, nil
+	}
+
+	newFromSpec := fromSpec.Copy().(*ReadRangePhysSpec)
+	newFromSpec.Descending = true
+	newFromSpec.PointsLimit = tailSpec.N
+	if err := fromNode.ReplaceSpec(newFromSpec); err != nil {
+		return nil, false, err
+	}
+
+	sortSpec := &universe.SortProcedureSpec{
+		Columns: []string{execute.DefaultTimeColLabel},
+	}
+	if err := pn.ReplaceSpec(sortSpec); err != nil {
+		return nil, false, err
+	}
+	return pn, true, nil
+}
+
+// PushDownDescendingSortRule matches 'ReadRange |> sort(columns: ["_time"], desc: true)'
+// and rewrites it into a descending read.
+type PushDownDescendingSortRule struct{}
+
+func (rule PushDownDescendingSortRule) Name() string {
+	return "PushDownDescendingSortRule"
+}
+
+func (rule PushDownDescendingSortRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.SortKind, plan.Pat(ReadRangePhysKind))
+}
+
+func (rule PushDownDescendingSortRule) Rewrite(pn plan.Node) (plan.Node, bool, error) {
+	sortSpec := pn.ProcedureSpec().(*universe.SortProcedureSpec)
+	fromNode := pn.Predecessors()[0]
+	fromSpec := fromNode.ProcedureSpec().(*ReadRangePhysSpec)
+
+	// Sorting after a limit must not change which points are read.
+	if len(fromNode.Successors()) != 1 || fromSpec.LimitOrOrderSet() {
+		return pn, false, nil
+	}
+
+	if !sortSpec.Desc || len(sortSpec.Columns) != 1 || sortSpec.Columns[0] != execute.DefaultTimeColLabel {
+		return pn, false, nil
+	}
+
+	newFromSpec := fromSpec.Copy().(*ReadRangePhysSpec)
+	newFromSpec.Descending = true
+
+	merged, err := plan.MergeToPhysicalNode(pn, fromNode, newFromSpec)
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
 var invalidTagKeysForTagValues = []string{
 	execute.DefaultTimeColLabel,
 	execute.DefaultValueColLabel,
@@ -400,3 +766,52 @@ func isPushableFieldOperator(kind ast.OperatorKind) bool {
 
 	return false
 }
+
+// PushDownSchemaRule matches 'ReadRange |> schema.fn()' where fn is the
+// schema function of kind Kind and rewrites it into the storage read
+// describing the schema of the range.
+// The 'from()' must have already been merged with 'range' and, optionally,
+// may have been merged with 'filter'.
+type PushDownSchemaRule struct {
+	Kind plan.ProcedureKind
+}
+
+func (rule PushDownSchemaRule) Name() string {
+	return "PushDownSchemaRule/" + string(rule.Kind)
+}
+
+func (rule PushDownSchemaRule) Pattern() plan.Pattern {
+	return plan.Pat(rule.Kind, plan.Pat(ReadRangePhysKind))
+}
+
+func (rule PushDownSchemaRule) Rewrite(pn plan.Node) (plan.Node, bool, error) {
+	fromNode := pn.Predecessors()[0]
+	fromSpec := fromNode.ProcedureSpec().(*ReadRangePhysSpec)
+
+	// The read is replaced by the new node so
+	// nothing else may depend on it.
+	if len(fromNode.Successors()) != 1 {
+		return pn, false, nil
+	} else if fromSpec.LimitOrOrderSet() {
+		return pn, false, nil
+	}
+
+	readSpec := *fromSpec.Copy().(*ReadRangePhysSpec)
+	switch rule.Kind {
+	case schema.MeasurementsKind:
+		return plan.CreatePhysicalNode("ReadTagValues", &ReadTagValuesPhysSpec{
+			ReadRangePhysSpec: readSpec,
+			TagKey:            "_measurement",
+		}), true, nil
+	case schema.TagKeysKind:
+		return plan.CreatePhysicalNode("ReadTagKeys", &ReadTagKeysPhysSpec{
+			ReadRangePhysSpec: readSpec,
+		}), true, nil
+	case schema.FieldKeysKind:
+		return plan.CreatePhysicalNode("ReadMeasurementFields", &ReadMeasurementFieldsPhysSpec{
+			ReadRangePhysSpec: readSpec,
+		}), true, nil
+	default:
+		return pn, false, nil
+	}
+}
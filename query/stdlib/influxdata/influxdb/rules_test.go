@@ -2,6 +2,7 @@ package influxdb_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/ast"
@@ -11,6 +12,7 @@ import (
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/stdlib/universe"
 	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb"
+	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb/schema"
 )
 
 func TestPushDownRangeRule(t *testing.T) {
@@ -547,3 +549,864 @@ func TestReadTagValuesRule(t *testing.T) {
 		})
 	}
 }
+
+func TestPushDownWindowAggregateRule(t *testing.T) {
+	fromSpec := influxdb.FromProcedureSpec{
+		Bucket: "my-bucket",
+	}
+	rangeSpec := universe.RangeProcedureSpec{
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+	readRangeSpec := influxdb.ReadRangePhysSpec{
+		Bucket: "my-bucket",
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+	windowSpec := func(every, period time.Duration) *universe.WindowProcedureSpec {
+		return &universe.WindowProcedureSpec{
+			Window: plan.WindowSpec{
+				Every:  flux.Duration(every),
+				Period: flux.Duration(period),
+			},
+			TimeColumn:  execute.DefaultTimeColLabel,
+			StartColumn: execute.DefaultStartColLabel,
+			StopColumn:  execute.DefaultStopColLabel,
+		}
+	}
+	valueColumns := execute.AggregateConfig{
+		Columns: []string{execute.DefaultValueColLabel},
+	}
+	readWindowAggregateSpec := func(kind plan.ProcedureKind) plan.PhysicalProcedureSpec {
+		return &influxdb.ReadWindowAggregatePhysSpec{
+			ReadRangePhysSpec: readRangeSpec,
+			WindowEvery:       int64(time.Minute),
+			Aggregates:        []plan.ProcedureKind{kind},
+		}
+	}
+	// aggregateWindow() creates empty windows by default.
+	createEmptyWindowSpec := windowSpec(time.Minute, time.Minute)
+	createEmptyWindowSpec.CreateEmpty = true
+	duplicateSpec := &universe.DuplicateProcedureSpec{
+		Column: execute.DefaultStopColLabel,
+		As:     execute.DefaultTimeColLabel,
+	}
+
+	rules := []plan.Rule{
+		influxdb.PushDownRangeRule{},
+		influxdb.PushDownWindowAggregateRule{Kind: universe.CountKind},
+		influxdb.PushDownWindowAggregateRule{Kind: universe.MeanKind},
+		influxdb.PushDownWindowAggregateRule{Kind: universe.MaxKind},
+	}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name: "count",
+			// from -> range -> window -> count  =>  ReadWindowAggregate
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("window", windowSpec(time.Minute, time.Minute)),
+					plan.CreatePhysicalNode("count", &universe.CountProcedureSpec{AggregateConfig: valueColumns}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{2, 3},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadWindowAggregate", readWindowAggregateSpec(universe.CountKind)),
+				},
+			},
+		},
+		{
+			Name: "mean with successor",
+			// from -> range -> window -> mean -> yield  =>  ReadWindowAggregate -> yield
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("window", windowSpec(time.Minute, time.Minute)),
+					plan.CreatePhysicalNode("mean", &universe.MeanProcedureSpec{AggregateConfig: valueColumns}),
+					plan.CreatePhysicalNode("yield", &universe.YieldProcedureSpec{Name: "_result"}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{2, 3},
+					{3, 4},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadWindowAggregate", readWindowAggregateSpec(universe.MeanKind)),
+					plan.CreatePhysicalNode("yield", &universe.YieldProcedureSpec{Name: "_result"}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+		},
+		{
+			Name: "aggregateWindow",
+			// from -> range -> window(createEmpty: true) -> mean -> duplicate  =>  ReadWindowAggregate -> duplicate
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("window", createEmptyWindowSpec),
+					plan.CreatePhysicalNode("mean", &universe.MeanProcedureSpec{AggregateConfig: valueColumns}),
+					plan.CreatePhysicalNode("duplicate", duplicateSpec),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{2, 3},
+					{3, 4},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadWindowAggregate", &influxdb.ReadWindowAggregatePhysSpec{
+						ReadRangePhysSpec: readRangeSpec,
+						WindowEvery:       int64(time.Minute),
+						Aggregates:        []plan.ProcedureKind{universe.MeanKind},
+						CreateEmpty:       true,
+					}),
+					plan.CreatePhysicalNode("duplicate", duplicateSpec),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+		},
+		{
+			Name: "max",
+			// from -> range -> window -> max  =>  ReadWindowAggregate
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("window", windowSpec(time.Minute, time.Minute)),
+					plan.CreatePhysicalNode("max", &universe.MaxProcedureSpec{
+						SelectorConfig: execute.SelectorConfig{Column: execute.DefaultValueColLabel},
+					}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{2, 3},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadWindowAggregate", readWindowAggregateSpec(universe.MaxKind)),
+				},
+			},
+		},
+		{
+			Name: "sliding window",
+			// from -> range -> window -> count  =>  ReadRange -> window -> count
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("window", windowSpec(time.Minute, 2*time.Minute)),
+					plan.CreatePhysicalNode("count", &universe.CountProcedureSpec{AggregateConfig: valueColumns}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{2, 3},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadRange", &readRangeSpec),
+					plan.CreatePhysicalNode("window", windowSpec(time.Minute, 2*time.Minute)),
+					plan.CreatePhysicalNode("count", &universe.CountProcedureSpec{AggregateConfig: valueColumns}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+		},
+		{
+			Name: "non-value column",
+			// from -> range -> window -> count  =>  ReadRange -> window -> count
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("window", windowSpec(time.Minute, time.Minute)),
+					plan.CreatePhysicalNode("count", &universe.CountProcedureSpec{
+						AggregateConfig: execute.AggregateConfig{Columns: []string{"host"}},
+					}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{2, 3},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadRange", &readRangeSpec),
+					plan.CreatePhysicalNode("window", windowSpec(time.Minute, time.Minute)),
+					plan.CreatePhysicalNode("count", &universe.CountProcedureSpec{
+						AggregateConfig: execute.AggregateConfig{Columns: []string{"host"}},
+					}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
+
+func TestPushDownSelectorRule(t *testing.T) {
+	fromSpec := influxdb.FromProcedureSpec{
+		Bucket: "my-bucket",
+	}
+	rangeSpec := universe.RangeProcedureSpec{
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+	readRangeSpec := influxdb.ReadRangePhysSpec{
+		Bucket: "my-bucket",
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+	valueColumn := execute.SelectorConfig{
+		Column: execute.DefaultValueColLabel,
+	}
+
+	rules := []plan.Rule{
+		influxdb.PushDownRangeRule{},
+		influxdb.PushDownSelectorRule{Kind: universe.FirstKind},
+		influxdb.PushDownSelectorRule{Kind: universe.LastKind},
+	}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name: "last",
+			// from -> range -> last  =>  ReadWindowAggregate
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("last", &universe.LastProcedureSpec{SelectorConfig: valueColumn}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadWindowAggregate", &influxdb.ReadWindowAggregatePhysSpec{
+						ReadRangePhysSpec: readRangeSpec,
+						Aggregates:        []plan.ProcedureKind{universe.LastKind},
+					}),
+				},
+			},
+		},
+		{
+			Name: "first",
+			// from -> range -> first  =>  ReadWindowAggregate
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("first", &universe.FirstProcedureSpec{SelectorConfig: valueColumn}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadWindowAggregate", &influxdb.ReadWindowAggregatePhysSpec{
+						ReadRangePhysSpec: readRangeSpec,
+						Aggregates:        []plan.ProcedureKind{universe.FirstKind},
+					}),
+				},
+			},
+		},
+		{
+			Name: "with multiple successors",
+			// last      count
+			//     \     /          last     count
+			//      range       =>     \    /
+			//        |              ReadRange
+			//       from
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("last", &universe.LastProcedureSpec{SelectorConfig: valueColumn}),
+					plan.CreatePhysicalNode("count", &universe.CountProcedureSpec{}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{1, 3},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadRange", &readRangeSpec),
+					plan.CreatePhysicalNode("last", &universe.LastProcedureSpec{SelectorConfig: valueColumn}),
+					plan.CreatePhysicalNode("count", &universe.CountProcedureSpec{}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{0, 2},
+				},
+			},
+		},
+		{
+			Name: "non-value column",
+			// from -> range -> last  =>  ReadRange -> last
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("last", &universe.LastProcedureSpec{
+						SelectorConfig: execute.SelectorConfig{Column: "host"},
+					}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadRange", &readRangeSpec),
+					plan.CreatePhysicalNode("last", &universe.LastProcedureSpec{
+						SelectorConfig: execute.SelectorConfig{Column: "host"},
+					}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
+
+func TestPushDownGroupRule(t *testing.T) {
+	fromSpec := influxdb.FromProcedureSpec{
+		Bucket: "my-bucket",
+	}
+	rangeSpec := universe.RangeProcedureSpec{
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+	readRangeSpec := influxdb.ReadRangePhysSpec{
+		Bucket: "my-bucket",
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+	groupSpec := func(keys ...string) *universe.GroupProcedureSpec {
+		return &universe.GroupProcedureSpec{
+			GroupMode: flux.GroupModeBy,
+			GroupKeys: keys,
+		}
+	}
+	readGroupSpec := func(agg string, keys ...string) *influxdb.ReadGroupPhysSpec {
+		return &influxdb.ReadGroupPhysSpec{
+			ReadRangePhysSpec: readRangeSpec,
+			GroupMode:         flux.GroupModeBy,
+			GroupKeys:         keys,
+			AggregateMethod:   agg,
+		}
+	}
+	valueColumns := execute.AggregateConfig{
+		Columns: []string{execute.DefaultValueColLabel},
+	}
+
+	rules := []plan.Rule{
+		influxdb.PushDownRangeRule{},
+		influxdb.PushDownGroupRule{},
+		influxdb.PushDownGroupAggregateRule{Kind: universe.CountKind},
+		influxdb.PushDownGroupAggregateRule{Kind: universe.SumKind},
+	}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name: "simple",
+			// from -> range -> group  =>  ReadGroup
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("group", groupSpec("host")),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadGroup", readGroupSpec("", "host")),
+				},
+			},
+		},
+		{
+			Name: "with count",
+			// from -> range -> group -> count  =>  ReadGroup
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("group", groupSpec("host")),
+					plan.CreatePhysicalNode("count", &universe.CountProcedureSpec{AggregateConfig: valueColumns}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{2, 3},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("merged_ReadGroup_count", readGroupSpec("count", "host")),
+				},
+			},
+		},
+		{
+			Name: "with mean",
+			// from -> range -> group -> mean  =>  ReadGroup -> mean
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("group", groupSpec("host")),
+					plan.CreatePhysicalNode("mean", &universe.MeanProcedureSpec{AggregateConfig: valueColumns}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{2, 3},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadGroup", readGroupSpec("", "host")),
+					plan.CreatePhysicalNode("mean", &universe.MeanProcedureSpec{AggregateConfig: valueColumns}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+		},
+		{
+			Name: "group by value",
+			// from -> range -> group  =>  ReadRange -> group
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("group", groupSpec("_value")),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadRange", &readRangeSpec),
+					plan.CreatePhysicalNode("group", groupSpec("_value")),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+		},
+		{
+			Name: "group by measurement",
+			// from -> range -> group  =>  ReadRange -> group
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("group", groupSpec("_measurement", "host")),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadRange", &readRangeSpec),
+					plan.CreatePhysicalNode("group", groupSpec("_measurement", "host")),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+		},
+		{
+			Name: "group except",
+			// from -> range -> group  =>  ReadRange -> group
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("group", &universe.GroupProcedureSpec{
+						GroupMode: flux.GroupModeExcept,
+						GroupKeys: []string{"host"},
+					}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadRange", &readRangeSpec),
+					plan.CreatePhysicalNode("group", &universe.GroupProcedureSpec{
+						GroupMode: flux.GroupModeExcept,
+						GroupKeys: []string{"host"},
+					}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
+
+func TestPushDownLimitAndSortRules(t *testing.T) {
+	fromSpec := influxdb.FromProcedureSpec{
+		Bucket: "my-bucket",
+	}
+	rangeSpec := universe.RangeProcedureSpec{
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+	readRangeSpec := func(limit int64, desc bool) *influxdb.ReadRangePhysSpec {
+		return &influxdb.ReadRangePhysSpec{
+			Bucket: "my-bucket",
+			Bounds: flux.Bounds{
+				Start: fluxTime(5),
+				Stop:  fluxTime(10),
+			},
+			PointsLimit: limit,
+			Descending:  desc,
+		}
+	}
+	sortDesc := &universe.SortProcedureSpec{
+		Columns: []string{execute.DefaultTimeColLabel},
+		Desc:    true,
+	}
+
+	rules := []plan.Rule{
+		influxdb.PushDownRangeRule{},
+		influxdb.PushDownLimitRule{},
+		influxdb.PushDownTailRule{},
+		influxdb.PushDownDescendingSortRule{},
+	}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name: "limit",
+			// from -> range -> limit  =>  ReadRange
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("limit", &universe.LimitProcedureSpec{N: 100}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("merged_ReadRange_limit", readRangeSpec(100, false)),
+				},
+			},
+		},
+		{
+			Name: "limit with offset",
+			// from -> range -> limit  =>  ReadRange -> limit
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("limit", &universe.LimitProcedureSpec{N: 100, Offset: 10}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadRange", readRangeSpec(0, false)),
+					plan.CreatePhysicalNode("limit", &universe.LimitProcedureSpec{N: 100, Offset: 10}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+		},
+		{
+			Name: "sort desc then limit",
+			// from -> range -> sort -> limit  =>  ReadRange
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("sort", sortDesc),
+					plan.CreatePhysicalNode("limit", &universe.LimitProcedureSpec{N: 100}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{2, 3},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("merged_merged_ReadRange_sort_limit", readRangeSpec(100, true)),
+				},
+			},
+		},
+		{
+			Name: "limit then sort desc",
+			// from -> range -> limit -> sort  =>  ReadRange -> sort
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("limit", &universe.LimitProcedureSpec{N: 100}),
+					plan.CreatePhysicalNode("sort", sortDesc),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{2, 3},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("merged_ReadRange_limit", readRangeSpec(100, false)),
+					plan.CreatePhysicalNode("sort", sortDesc),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+		},
+		{
+			Name: "tail",
+			// from -> range -> tail  =>  ReadRange -> sort
+			Rules: rules,
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("tail", &universe.TailProcedureSpec{N: 100}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadRange", readRangeSpec(100, true)),
+					plan.CreatePhysicalNode("tail", &universe.SortProcedureSpec{
+						Columns: []string{execute.DefaultTimeColLabel},
+					}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
+
+func TestPushDownSchemaRule(t *testing.T) {
+	fromSpec := influxdb.FromProcedureSpec{
+		Bucket: "my-bucket",
+	}
+	rangeSpec := universe.RangeProcedureSpec{
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+	readRangeSpec := influxdb.ReadRangePhysSpec{
+		Bucket: "my-bucket",
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+
+	rules := []plan.Rule{
+		influxdb.PushDownRangeRule{},
+		influxdb.PushDownSchemaRule{Kind: schema.MeasurementsKind},
+		influxdb.PushDownSchemaRule{Kind: schema.TagKeysKind},
+		influxdb.PushDownSchemaRule{Kind: schema.FieldKeysKind},
+	}
+
+	before := func(kind plan.ProcedureKind) *plantest.PlanSpec {
+		return &plantest.PlanSpec{
+			Nodes: []plan.Node{
+				plan.CreateLogicalNode("from", &fromSpec),
+				plan.CreateLogicalNode("range", &rangeSpec),
+				plan.CreateLogicalNode("schema", &schema.ProcedureSpec{Op: kind}),
+			},
+			Edges: [][2]int{
+				{0, 1},
+				{1, 2},
+			},
+		}
+	}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name: "measurements",
+			// from -> range -> schema.measurements  =>  ReadTagValues
+			Rules:  rules,
+			Before: before(schema.MeasurementsKind),
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadTagValues", &influxdb.ReadTagValuesPhysSpec{
+						ReadRangePhysSpec: readRangeSpec,
+						TagKey:            "_measurement",
+					}),
+				},
+			},
+		},
+		{
+			Name: "tag keys",
+			// from -> range -> schema.tagKeys  =>  ReadTagKeys
+			Rules:  rules,
+			Before: before(schema.TagKeysKind),
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadTagKeys", &influxdb.ReadTagKeysPhysSpec{
+						ReadRangePhysSpec: readRangeSpec,
+					}),
+				},
+			},
+		},
+		{
+			Name: "field keys",
+			// from -> range -> schema.fieldKeys  =>  ReadMeasurementFields
+			Rules:  rules,
+			Before: before(schema.FieldKeysKind),
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadMeasurementFields", &influxdb.ReadMeasurementFieldsPhysSpec{
+						ReadRangePhysSpec: readRangeSpec,
+					}),
+				},
+			},
+		},
+		{
+			Name: "limited read",
+			// from -> range -> limit -> schema.fieldKeys  =>  ReadRange -> schema.fieldKeys
+			Rules: append(rules, influxdb.PushDownLimitRule{}),
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreateLogicalNode("from", &fromSpec),
+					plan.CreateLogicalNode("range", &rangeSpec),
+					plan.CreatePhysicalNode("limit", &universe.LimitProcedureSpec{N: 1}),
+					plan.CreateLogicalNode("schema", &schema.ProcedureSpec{Op: schema.FieldKeysKind}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{2, 3},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("merged_ReadRange_limit", &influxdb.ReadRangePhysSpec{
+						Bucket:      "my-bucket",
+						Bounds:      readRangeSpec.Bounds,
+						PointsLimit: 1,
+					}),
+					plan.CreatePhysicalNode("schema", &schema.ProcedureSpec{Op: schema.FieldKeysKind}),
+				},
+				Edges: [][2]int{{0, 1}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Package schema provides Flux functions describing the schema of a bucket.
+//
+// The functions follow a range of a from, optionally filtered by tags, such as
+//
+//	from(bucket: "telegraf") |> range(start: -1h) |> filter(fn: (r) => r._measurement == "cpu") |> schema.fieldKeys()
+//
+// and are always pushed down into the storage engine.
+package schema
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+)
+
+const PackagePath = "influxdata/influxdb/schema"
+
+const (
+	// MeasurementsKind lists the measurements with series in the range.
+	MeasurementsKind = "schemaMeasurements"
+	// TagKeysKind lists the tag keys of the series in the range.
+	TagKeysKind = "schemaTagKeys"
+	// FieldKeysKind lists the key and type of the fields of the
+	// measurements with series in the range.
+	FieldKeysKind = "schemaFieldKeys"
+)
+
+const source = `package schema
+
+builtin measurements
+builtin tagKeys
+builtin fieldKeys
+`
+
+func init() {
+	pkg := parser.ParseSource(source)
+	pkg.Path = PackagePath
+	flux.RegisterPackage(pkg)
+
+	signature := flux.FunctionSignature(map[string]semantic.PolyType{}, nil)
+	for name, kind := range map[string]flux.OperationKind{
+		"measurements": MeasurementsKind,
+		"tagKeys":      TagKeysKind,
+		"fieldKeys":    FieldKeysKind,
+	} {
+		kind := kind
+		flux.RegisterPackageValue(PackagePath, name, flux.FunctionValue(string(kind), createOpSpec(kind), signature))
+		flux.RegisterOpSpec(kind, func() flux.OperationSpec { return &OpSpec{Op: kind} })
+		plan.RegisterProcedureSpec(plan.ProcedureKind(kind), newProcedure, kind)
+	}
+}
+
+// OpSpec is the operation of the schema function Op.
+type OpSpec struct {
+	Op flux.OperationKind `json:"op"`
+}
+
+func createOpSpec(kind flux.OperationKind) flux.CreateOperationSpec {
+	return func(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+		if err := a.AddParentFromArgs(args); err != nil {
+			return nil, err
+		}
+		return &OpSpec{Op: kind}, nil
+	}
+}
+
+func (s *OpSpec) Kind() flux.OperationKind {
+	return s.Op
+}
+
+// ProcedureSpec is the logical procedure of a schema function. It has no
+// implementation of its own and must be rewritten into a storage read by
+// the planner.
+type ProcedureSpec struct {
+	plan.DefaultCost
+
+	Op plan.ProcedureKind
+}
+
+func newProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*OpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ProcedureSpec{Op: plan.ProcedureKind(spec.Op)}, nil
+}
+
+func (s *ProcedureSpec) Kind() plan.ProcedureKind {
+	return s.Op
+}
+
+func (s *ProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+// PostPhysicalValidate reports a schema function that could not be pushed down.
+func (s *ProcedureSpec) PostPhysicalValidate(id plan.NodeID) error {
+	return fmt.Errorf("%s: schema functions must follow a range of a from, optionally filtered by tags", id)
+}
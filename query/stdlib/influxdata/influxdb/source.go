@@ -18,6 +18,9 @@ func init() {
 	execute.RegisterSource(ReadRangePhysKind, createReadFilterSource)
 	execute.RegisterSource(ReadTagKeysPhysKind, createReadTagKeysSource)
 	execute.RegisterSource(ReadTagValuesPhysKind, createReadTagValuesSource)
+	execute.RegisterSource(ReadWindowAggregatePhysKind, createReadWindowAggregateSource)
+	execute.RegisterSource(ReadGroupPhysKind, createReadGroupSource)
+	execute.RegisterSource(ReadMeasurementFieldsPhysKind, createReadMeasurementFieldsSource)
 }
 
 type runner interface {
@@ -47,8 +50,8 @@ func (s *Source) AddTransformation(t execute.Transformation) {
 
 func (s *Source) Metadata() flux.Metadata {
 	return flux.Metadata{
-		"influxdb/scanned-bytes":  []interface{}{s.stats.ScannedBytes},
-		"influxdb/scanned-values": []interface{}{s.stats.ScannedValues},
+		query.ScannedBytesMetadataKey:  []interface{}{s.stats.ScannedBytes},
+		query.ScannedValuesMetadataKey: []interface{}{s.stats.ScannedValues},
 	}
 }
 
@@ -143,6 +146,24 @@ func createReadFilterSource(s plan.ProcedureSpec, id execute.DatasetID, a execut
 	if spec.FilterSet {
 		filter = spec.Filter
 	}
+
+	// A limited or ordered read requires a full read request.
+	if spec.LimitOrOrderSet() {
+		return ReadSource(
+			id,
+			deps.Reader,
+			ReadSpec{
+				OrganizationID: orgID,
+				BucketID:       bucketID,
+				Predicate:      filter,
+				PointsLimit:    spec.PointsLimit,
+				Descending:     spec.Descending,
+			},
+			*bounds,
+			a.Allocator(),
+		), nil
+	}
+
 	return ReadFilterSource(
 		id,
 		deps.Reader,
@@ -220,6 +241,70 @@ func (s *readTagKeysSource) run(ctx context.Context) error {
 	return s.processTables(ctx, ti, execute.Now())
 }
 
+func createReadMeasurementFieldsSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	span, ctx := tracing.StartSpanFromContext(context.TODO())
+	defer span.Finish()
+
+	spec := prSpec.(*ReadMeasurementFieldsPhysSpec)
+	deps := a.Dependencies()[FromKind].(Dependencies)
+	req := query.RequestFromContext(a.Context())
+	if req == nil {
+		return nil, errors.New("missing request on context")
+	}
+	orgID := req.OrganizationID
+
+	bucketID, err := spec.LookupBucketID(ctx, orgID, deps.BucketLookup)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter *semantic.FunctionExpression
+	if spec.FilterSet {
+		filter = spec.Filter
+	}
+
+	bounds := a.StreamContext().Bounds()
+	return ReadMeasurementFieldsSource(
+		dsid,
+		deps.Reader,
+		ReadMeasurementFieldsSpec{
+			ReadFilterSpec: ReadFilterSpec{
+				OrganizationID: orgID,
+				BucketID:       bucketID,
+				Bounds:         *bounds,
+				Predicate:      filter,
+			},
+		},
+		a.Allocator(),
+	), nil
+}
+
+type readMeasurementFieldsSource struct {
+	Source
+
+	reader   Reader
+	readSpec ReadMeasurementFieldsSpec
+}
+
+func ReadMeasurementFieldsSource(id execute.DatasetID, r Reader, readSpec ReadMeasurementFieldsSpec, alloc *memory.Allocator) execute.Source {
+	src := &readMeasurementFieldsSource{
+		reader:   r,
+		readSpec: readSpec,
+	}
+	src.id = id
+	src.alloc = alloc
+	src.runner = src
+	return src
+}
+
+func (s *readMeasurementFieldsSource) run(ctx context.Context) error {
+	ti, err := s.reader.ReadMeasurementFields(ctx, s.readSpec, s.alloc)
+	if err != nil {
+		return err
+	}
+	return s.processTables(ctx, ti, execute.Now())
+}
+
 func createReadTagValuesSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
 	span, ctx := tracing.StartSpanFromContext(context.TODO())
 	defer span.Finish()
@@ -284,3 +369,148 @@ func (s *readTagValuesSource) run(ctx context.Context) error {
 	}
 	return s.processTables(ctx, ti, execute.Now())
 }
+
+func createReadWindowAggregateSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	span, ctx := tracing.StartSpanFromContext(context.TODO())
+	defer span.Finish()
+
+	spec := prSpec.(*ReadWindowAggregatePhysSpec)
+
+	bounds := a.StreamContext().Bounds()
+	if bounds == nil {
+		return nil, errors.New("nil bounds passed to from")
+	}
+
+	deps := a.Dependencies()[FromKind].(Dependencies)
+	req := query.RequestFromContext(a.Context())
+	if req == nil {
+		return nil, errors.New("missing request on context")
+	}
+	orgID := req.OrganizationID
+
+	bucketID, err := spec.LookupBucketID(ctx, orgID, deps.BucketLookup)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter *semantic.FunctionExpression
+	if spec.FilterSet {
+		filter = spec.Filter
+	}
+
+	return ReadWindowAggregateSource(
+		dsid,
+		deps.Reader,
+		ReadWindowAggregateSpec{
+			ReadFilterSpec: ReadFilterSpec{
+				OrganizationID: orgID,
+				BucketID:       bucketID,
+				Bounds:         *bounds,
+				Predicate:      filter,
+			},
+			WindowEvery: spec.WindowEvery,
+			Aggregates:  spec.Aggregates,
+			CreateEmpty: spec.CreateEmpty,
+		},
+		a.Allocator(),
+	), nil
+}
+
+type readWindowAggregateSource struct {
+	Source
+
+	reader   Reader
+	readSpec ReadWindowAggregateSpec
+}
+
+func ReadWindowAggregateSource(id execute.DatasetID, r Reader, readSpec ReadWindowAggregateSpec, alloc *memory.Allocator) execute.Source {
+	src := &readWindowAggregateSource{
+		reader:   r,
+		readSpec: readSpec,
+	}
+	src.id = id
+	src.alloc = alloc
+	src.runner = src
+	return src
+}
+
+func (s *readWindowAggregateSource) run(ctx context.Context) error {
+	stop := s.readSpec.Bounds.Stop
+	ti, err := s.reader.ReadWindowAggregate(ctx, s.readSpec, s.alloc)
+	if err != nil {
+		return err
+	}
+	return s.processTables(ctx, ti, stop)
+}
+
+func createReadGroupSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	span, ctx := tracing.StartSpanFromContext(context.TODO())
+	defer span.Finish()
+
+	spec := prSpec.(*ReadGroupPhysSpec)
+
+	bounds := a.StreamContext().Bounds()
+	if bounds == nil {
+		return nil, errors.New("nil bounds passed to from")
+	}
+
+	deps := a.Dependencies()[FromKind].(Dependencies)
+	req := query.RequestFromContext(a.Context())
+	if req == nil {
+		return nil, errors.New("missing request on context")
+	}
+	orgID := req.OrganizationID
+
+	bucketID, err := spec.LookupBucketID(ctx, orgID, deps.BucketLookup)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter *semantic.FunctionExpression
+	if spec.FilterSet {
+		filter = spec.Filter
+	}
+
+	return ReadSource(
+		dsid,
+		deps.Reader,
+		ReadSpec{
+			OrganizationID:  orgID,
+			BucketID:        bucketID,
+			Predicate:       filter,
+			GroupMode:       ToGroupMode(spec.GroupMode),
+			GroupKeys:       spec.GroupKeys,
+			AggregateMethod: spec.AggregateMethod,
+		},
+		*bounds,
+		a.Allocator(),
+	), nil
+}
+
+type readSource struct {
+	Source
+
+	reader   Reader
+	readSpec ReadSpec
+	bounds   execute.Bounds
+}
+
+func ReadSource(id execute.DatasetID, r Reader, readSpec ReadSpec, bounds execute.Bounds, alloc *memory.Allocator) execute.Source {
+	src := &readSource{
+		reader:   r,
+		readSpec: readSpec,
+		bounds:   bounds,
+	}
+	src.id = id
+	src.alloc = alloc
+	src.runner = src
+	return src
+}
+
+func (s *readSource) run(ctx context.Context) error {
+	ti, err := s.reader.Read(ctx, s.readSpec, s.bounds.Start, s.bounds.Stop, s.alloc)
+	if err != nil {
+		return err
+	}
+	return s.processTables(ctx, ti, s.bounds.Stop)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/tsdb/cursors"
@@ -215,11 +216,24 @@ type ReadTagValuesSpec struct {
 	TagKey string
 }
 
+type ReadMeasurementFieldsSpec struct {
+	ReadFilterSpec
+}
+
+type ReadWindowAggregateSpec struct {
+	ReadFilterSpec
+	WindowEvery int64
+	Aggregates  []plan.ProcedureKind
+	CreateEmpty bool
+}
+
 type Reader interface {
 	Read(ctx context.Context, rs ReadSpec, start, stop execute.Time, alloc *memory.Allocator) (TableIterator, error)
 	ReadFilter(ctx context.Context, spec ReadFilterSpec, alloc *memory.Allocator) (TableIterator, error)
 	ReadTagKeys(ctx context.Context, spec ReadTagKeysSpec, alloc *memory.Allocator) (TableIterator, error)
 	ReadTagValues(ctx context.Context, spec ReadTagValuesSpec, alloc *memory.Allocator) (TableIterator, error)
+	ReadWindowAggregate(ctx context.Context, spec ReadWindowAggregateSpec, alloc *memory.Allocator) (TableIterator, error)
+	ReadMeasurementFields(ctx context.Context, spec ReadMeasurementFieldsSpec, alloc *memory.Allocator) (TableIterator, error)
 	Close()
 }
 
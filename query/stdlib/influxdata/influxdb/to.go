@@ -19,6 +19,7 @@ import (
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/kit/tracing"
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/storage"
 	"github.com/influxdata/influxdb/tsdb"
 )
@@ -246,6 +247,8 @@ func createToTransformation(id execute.DatasetID, mode execute.AccumulationMode,
 	if err != nil {
 		return nil, nil, err
 	}
+	t.dryRun = query.IsDryRun(a.Context())
+	t.counter = query.WriteCounterFromContext(a.Context())
 	return t, d, nil
 }
 
@@ -256,6 +259,12 @@ type ToTransformation struct {
 	cache execute.TableBuilderCache
 	spec  *ToProcedureSpec
 	deps  ToDependencies
+
+	// dryRun is set to only output the tables, without writing their points.
+	dryRun bool
+
+	// counter, if set, counts the rows written.
+	counter *query.WriteCounter
 }
 
 // RetractTable retracts the table for the transformation for the `to` flux function.
@@ -608,7 +617,16 @@ func writeTable(t *ToTransformation, tbl flux.Table) error {
 				return err
 			}
 		}
-		return d.PointsWriter.WritePoints(context.TODO(), points)
+		if t.dryRun {
+			return nil
+		}
+		if err := d.PointsWriter.WritePoints(context.TODO(), points); err != nil {
+			return err
+		}
+		if t.counter != nil {
+			t.counter.AddRows(int64(er.Len()))
+		}
+		return nil
 	})
 }
 
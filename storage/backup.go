@@ -0,0 +1,317 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/tsm1"
+)
+
+// rebuildIndexBatchSize specifies the number of series passed to the index at
+// a time when rebuilding it.
+const rebuildIndexBatchSize = 1000
+
+// Backup is a point-in-time snapshot of the TSM and tombstone files of an engine.
+type Backup struct {
+	// Dir is the directory holding the files of the backup. The caller is
+	// responsible for removing it once it is done with the backup.
+	Dir string
+
+	// Files are the names of the files within Dir.
+	Files []string
+
+	// TSMFiles are all of the TSM files making up the backup, including those
+	// left out of an incremental backup as they were part of a previous one.
+	TSMFiles []platform.BackupTSMFile
+
+	// Generation is the highest TSM file generation at the time of the backup.
+	Generation int
+}
+
+// CreateBackup writes the contents of the cache to a new TSM file and then hard links
+// the current set of TSM and tombstone files into a new directory, producing a
+// point-in-time snapshot of the data in the engine.
+//
+// If the filter has an organization, only data belonging to that organization is
+// included in the snapshot, and if it also has a bucket, only data belonging to that
+// bucket. TSM files are rewritten to contain only the matching series when a filter
+// is used.
+//
+// If the filter has a previous manifest, TSM files whose generation and sequence are
+// listed in that manifest are left out of the backup. Every tombstone file is
+// included, as tombstones may have been added to any file since the previous backup.
+func (e *Engine) CreateBackup(ctx context.Context, filter platform.BackupFilter) (*Backup, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if filter.Since != nil {
+		filter.OrgID, filter.BucketID = filter.Since.OrgID, filter.Since.BucketID
+	}
+
+	if filter.BucketID != nil && filter.OrgID == nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "storage/CreateBackup",
+			Msg:  "an organization is required to backup a bucket",
+		}
+	}
+
+	e.mu.RLock()
+	closed := e.closing == nil
+	e.mu.RUnlock()
+	if closed {
+		return nil, ErrEngineClosed
+	}
+
+	// Flush the cache so that every point written before the backup started is
+	// contained within a TSM file.
+	if err := e.engine.WriteSnapshot(ctx); err != nil {
+		return nil, err
+	}
+
+	dir, err := e.engine.FileStore.CreateSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	backup, err := e.newBackup(dir, filter)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	return backup, nil
+}
+
+// backupPrefix returns the prefix shared by the TSM keys of the organization
+// or bucket, or nil if orgID is nil.
+func backupPrefix(orgID, bucketID *platform.ID) []byte {
+	if orgID == nil {
+		return nil
+	}
+
+	var id platform.ID
+	if bucketID != nil {
+		id = *bucketID
+	}
+	encoded := tsdb.EncodeName(*orgID, id)
+
+	// The escaped organization ID is a prefix of the escaped name, as escaping is
+	// applied byte by byte.
+	if bucketID == nil {
+		return models.EscapeMeasurement(encoded[:8])
+	}
+	return append(models.EscapeMeasurement(encoded[:]), ',')
+}
+
+// newBackup builds the backup of the files in the snapshot dir. Files that are not
+// part of the backup are removed from dir.
+func (e *Engine) newBackup(dir string, filter platform.BackupFilter) (*Backup, error) {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type generationSequence struct{ generation, sequence int }
+	since := make(map[generationSequence]struct{})
+	if filter.Since != nil {
+		for _, f := range filter.Since.TSMFiles {
+			since[generationSequence{f.Generation, f.Sequence}] = struct{}{}
+		}
+	}
+
+	prefix := backupPrefix(filter.OrgID, filter.BucketID)
+	backup := &Backup{Dir: dir}
+
+	// TSM files are processed first, recording the files that are kept so that the
+	// tombstone files of the others can be dropped.
+	keep := make(map[string]struct{})
+	for _, fi := range fis {
+		name := fi.Name()
+		if filepath.Ext(name) != "."+tsm1.TSMFileExtension {
+			continue
+		}
+		path := filepath.Join(dir, name)
+
+		generation, sequence, err := e.engine.FileStore.ParseFileName(path)
+		if err != nil {
+			return nil, err
+		}
+		if generation > backup.Generation {
+			backup.Generation = generation
+		}
+		tsmFile := platform.BackupTSMFile{Name: name, Generation: generation, Sequence: sequence}
+
+		if _, ok := since[generationSequence{generation, sequence}]; ok {
+			// The file is already part of a previous backup.
+			if err := os.Remove(path); err != nil {
+				return nil, err
+			}
+			backup.TSMFiles = append(backup.TSMFiles, tsmFile)
+			keep[baseName(name)] = struct{}{}
+			continue
+		}
+
+		if len(prefix) > 0 {
+			ok, err := filterTSMFile(path, prefix)
+			if err != nil {
+				return nil, err
+			} else if !ok {
+				continue
+			}
+		}
+
+		backup.Files = append(backup.Files, name)
+		backup.TSMFiles = append(backup.TSMFiles, tsmFile)
+		keep[baseName(name)] = struct{}{}
+	}
+
+	// Keep the tombstone files of the TSM files that are part of the backup.
+	for _, fi := range fis {
+		name := fi.Name()
+		if filepath.Ext(name) == "."+tsm1.TSMFileExtension {
+			continue
+		}
+
+		if _, ok := keep[baseName(name)]; !ok {
+			if err := os.Remove(filepath.Join(dir, name)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		backup.Files = append(backup.Files, name)
+	}
+
+	return backup, nil
+}
+
+// baseName returns name without its extension.
+func baseName(name string) string {
+	return name[:len(name)-len(filepath.Ext(name))]
+}
+
+// filterTSMFile replaces the TSM file at path with a copy containing only the blocks
+// of the keys beginning with prefix. It returns false and removes the file if no
+// key matches.
+func filterTSMFile(path string, prefix []byte) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return false, err
+	}
+	defer r.Close()
+
+	tmpPath := path + "." + tsm1.TmpTSMFileExtension
+	fd, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0666)
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmpPath)
+	defer fd.Close()
+
+	w, err := tsm1.NewTSMWriter(fd)
+	if err != nil {
+		return false, err
+	}
+
+	itr := r.Iterator(prefix)
+	for itr.Next() && bytes.HasPrefix(itr.Key(), prefix) {
+		for _, entry := range itr.Entries() {
+			_, b, err := r.ReadBytes(&entry, nil)
+			if err != nil {
+				return false, err
+			}
+
+			if err := w.WriteBlock(itr.Key(), entry.MinTime, entry.MaxTime, b); err != nil {
+				return false, err
+			}
+		}
+	}
+	if err := itr.Err(); err != nil {
+		return false, err
+	}
+
+	if err := w.WriteIndex(); err == tsm1.ErrNoValues {
+		return false, os.Remove(path)
+	} else if err != nil {
+		return false, err
+	}
+
+	if err := w.Flush(); err != nil {
+		return false, err
+	} else if err := fd.Sync(); err != nil {
+		return false, err
+	} else if err := fd.Close(); err != nil {
+		return false, err
+	}
+
+	// The snapshot contains hard links to the live TSM files, so the rewritten
+	// file must replace the link rather than be written through it.
+	if err := os.Rename(tmpPath, path); err != nil {
+		return false, fmt.Errorf("error replacing filtered tsm file: %v", err)
+	}
+	return true, nil
+}
+
+// RebuildIndex adds the series of every key found in the TSM files to the series
+// file and index. It is used to recreate the index of a data directory restored
+// from a backup, which only contains TSM and tombstone files.
+func (e *Engine) RebuildIndex(ctx context.Context) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.closing == nil {
+		return ErrEngineClosed
+	}
+
+	collection := &tsdb.SeriesCollection{
+		Keys:  make([][]byte, 0, rebuildIndexBatchSize),
+		Names: make([][]byte, 0, rebuildIndexBatchSize),
+		Tags:  make([]models.Tags, 0, rebuildIndexBatchSize),
+		Types: make([]models.FieldType, 0, rebuildIndexBatchSize),
+	}
+
+	if err := e.engine.FileStore.WalkKeys(nil, func(key []byte, typ byte) error {
+		// The key references memory owned by the TSM file, so take a copy as the
+		// index retains the names and tags it is given.
+		seriesKey, _ := tsm1.SeriesAndFieldFromCompositeKey(key)
+		seriesKey = append([]byte(nil), seriesKey...)
+		name, tags := models.ParseKeyBytes(seriesKey)
+
+		collection.Keys = append(collection.Keys, seriesKey)
+		collection.Names = append(collection.Names, name)
+		collection.Tags = append(collection.Tags, tags)
+		collection.Types = append(collection.Types, tsm1.BlockTypeToFieldType(typ))
+
+		if collection.Length() == rebuildIndexBatchSize {
+			if err := e.index.CreateSeriesListIfNotExists(collection); err != nil {
+				return err
+			}
+			collection.Truncate(0)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if collection.Length() > 0 {
+		return e.index.CreateSeriesListIfNotExists(collection)
+	}
+	return nil
+}
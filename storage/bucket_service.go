@@ -74,7 +74,11 @@ func (s *BucketService) CreateBucket(ctx context.Context, b *platform.Bucket) er
 	if s.inner == nil || s.engine == nil {
 		return errors.New("nil inner BucketService or Engine")
 	}
-	return s.inner.CreateBucket(ctx, b)
+	if err := s.inner.CreateBucket(ctx, b); err != nil {
+		return err
+	}
+	s.invalidateSeriesLimits()
+	return nil
 }
 
 // UpdateBucket updates a single bucket with changeset.
@@ -86,7 +90,19 @@ func (s *BucketService) UpdateBucket(ctx context.Context, id platform.ID, upd pl
 	if s.inner == nil || s.engine == nil {
 		return nil, errors.New("nil inner BucketService or Engine")
 	}
-	return s.inner.UpdateBucket(ctx, id, upd)
+	b, err := s.inner.UpdateBucket(ctx, id, upd)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateSeriesLimits()
+	return b, nil
+}
+
+// invalidateSeriesLimits discards the series limits cached by the engine, if any.
+func (s *BucketService) invalidateSeriesLimits() {
+	if inv, ok := s.engine.(SeriesLimitInvalidator); ok {
+		inv.InvalidateSeriesLimits()
+	}
 }
 
 // DeleteBucket removes a bucket by ID.
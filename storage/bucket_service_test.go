@@ -51,13 +51,30 @@ func TestBucketService(t *testing.T) {
 	} else if deleter.bucketID != bucket.ID {
 		t.Errorf("got bucket ID: %s, expected %s", deleter.bucketID, bucket.ID)
 	}
+
+	// Test updating a bucket invalidates the series limits of the engine.
+	if err := inmemService.CreateBucket(context.TODO(), bucket); err != nil {
+		panic(err)
+	}
+	name := "bucket2"
+	if _, err := service.UpdateBucket(context.TODO(), bucket.ID, platform.BucketUpdate{Name: &name}); err != nil {
+		t.Fatal(err)
+	}
+	if deleter.invalidated != 1 {
+		t.Errorf("got %d invalidations of the series limits, expected 1", deleter.invalidated)
+	}
 }
 
 type MockDeleter struct {
 	orgID, bucketID platform.ID
+	invalidated     int
 }
 
 func (m *MockDeleter) DeleteBucket(orgID, bucketID platform.ID) error {
 	m.orgID, m.bucketID = orgID, bucketID
 	return nil
 }
+
+func (m *MockDeleter) InvalidateSeriesLimits() {
+	m.invalidated++
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"math"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// A ChangeObserver is notified of the time ranges of the buckets modified by
+// writes and deletes, such as to invalidate the data cached from them.
+type ChangeObserver interface {
+	// BucketRangeChanged is called after the data of the bucket within the
+	// time range [min, max] has been written or deleted. It may be called
+	// concurrently and must not block.
+	BucketRangeChanged(orgID, bucketID platform.ID, min, max int64)
+}
+
+// WithChangeObserver makes the engine notify obs of the writes and deletes.
+func WithChangeObserver(obs ChangeObserver) Option {
+	return func(e *Engine) {
+		e.changeObserver = obs
+	}
+}
+
+// notifyCollection notifies the change observer of the time range written to
+// each bucket by the points of the collection.
+func (e *Engine) notifyCollection(collection *tsdb.SeriesCollection) {
+	if e.changeObserver == nil {
+		return
+	}
+
+	type timeRange struct{ min, max int64 }
+	ranges := make(map[[16]byte]*timeRange)
+	for iter := collection.Iterator(); iter.Next(); {
+		var encoded [16]byte
+		if name := iter.Name(); len(name) == len(encoded) {
+			copy(encoded[:], name)
+		} else {
+			continue
+		}
+
+		r, ok := ranges[encoded]
+		if !ok {
+			r = &timeRange{min: math.MaxInt64, max: math.MinInt64}
+			ranges[encoded] = r
+		}
+		t := iter.Point().UnixNano()
+		if t < r.min {
+			r.min = t
+		}
+		if t > r.max {
+			r.max = t
+		}
+	}
+
+	for encoded, r := range ranges {
+		orgID, bucketID := tsdb.DecodeName(encoded)
+		e.changeObserver.BucketRangeChanged(orgID, bucketID, r.min, r.max)
+	}
+}
+
+// notifyDelete notifies the change observer of the deletion of the data of the
+// bucket within the time range [min, max].
+func (e *Engine) notifyDelete(orgID, bucketID platform.ID, min, max int64) {
+	if e.changeObserver != nil {
+		e.changeObserver.BucketRangeChanged(orgID, bucketID, min, max)
+	}
+}
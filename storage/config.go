@@ -12,11 +12,12 @@ import (
 
 // Default configuration values.
 const (
-	DefaultRetentionInterval       = time.Hour
-	DefaultSeriesFileDirectoryName = "_series"
-	DefaultIndexDirectoryName      = "index"
-	DefaultWALDirectoryName        = "wal"
-	DefaultEngineDirectoryName     = "data"
+	DefaultRetentionInterval        = time.Hour
+	DefaultWriteBackPressureTimeout = 10 * time.Second
+	DefaultSeriesFileDirectoryName  = "_series"
+	DefaultIndexDirectoryName       = "index"
+	DefaultWALDirectoryName         = "wal"
+	DefaultEngineDirectoryName      = "data"
 )
 
 // Config holds the configuration for an Engine.
@@ -24,6 +25,10 @@ type Config struct {
 	// Frequency of retention in seconds.
 	RetentionInterval toml.Duration `toml:"retention-interval"`
 
+	// Maximum time a write waits for the cache to be snapshotted once it is near
+	// its maximum memory size. Zero means writes fail immediately.
+	WriteBackPressureTimeout toml.Duration `toml:"write-back-pressure-timeout"`
+
 	// Series file config.
 	SeriesFilePath string `toml:"series-file-path"` // Overrides the default path.
 
@@ -46,11 +51,12 @@ type Config struct {
 // NewConfig initialises a new config for an Engine.
 func NewConfig() Config {
 	return Config{
-		RetentionInterval: toml.Duration(DefaultRetentionInterval),
-		TSDB:              tsdb.NewConfig(),
-		WAL:               tsm1.NewWALConfig(),
-		Engine:            tsm1.NewConfig(),
-		Index:             tsi1.NewConfig(),
+		RetentionInterval:        toml.Duration(DefaultRetentionInterval),
+		WriteBackPressureTimeout: toml.Duration(DefaultWriteBackPressureTimeout),
+		TSDB:                     tsdb.NewConfig(),
+		WAL:                      tsm1.NewWALConfig(),
+		Engine:                   tsm1.NewConfig(),
+		Index:                    tsi1.NewConfig(),
 	}
 }
 
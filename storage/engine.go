@@ -30,6 +30,17 @@ var timeBytes = []byte("time")
 // it's closed.
 var ErrEngineClosed = errors.New("engine is closed")
 
+// ErrCacheFull is returned when a write times out waiting for the cache to be
+// snapshotted, as the cache is near its maximum memory size.
+var ErrCacheFull = &platform.Error{
+	Code: platform.EUnavailable,
+	Msg:  "cache is near its maximum memory size; retry the write later",
+}
+
+// cacheBackPressureRatio is the ratio of the maximum memory size of the cache
+// above which writes wait for the cache to be snapshotted.
+const cacheBackPressureRatio = 0.9
+
 type Engine struct {
 	config   Config
 	path     string
@@ -39,10 +50,18 @@ type Engine struct {
 	mu                sync.RWMutex
 	closing           chan struct{} //closing returns the zero value when the engine is shutting down.
 	index             *tsi1.Index
+	fieldTypes        *fieldTypeRegistry
 	sfile             *tsdb.SeriesFile
 	engine            *tsm1.Engine
 	wal               *wal.WAL
 	retentionEnforcer *retentionEnforcer
+	seriesLimiter     *seriesLimiter
+	changeObserver    ChangeObserver
+
+	// The start time of the snapshot in progress, and of the last snapshot
+	// committed, both guarded by mu.
+	snapshotStarted time.Time
+	lastSnapshot    time.Time
 
 	defaultMetricLabels prometheus.Labels
 
@@ -201,6 +220,8 @@ func (e *Engine) Open(ctx context.Context) (err error) {
 		return err
 	}
 
+	e.fieldTypes = newFieldTypeRegistry(e.index, e.sfile)
+
 	if err := e.replayWAL(); err != nil {
 		return err
 	}
@@ -252,6 +273,13 @@ func (e *Engine) replayWAL() error {
 
 		case *wal.DeleteBucketRangeWALEntry:
 			return e.deleteBucketRangeLocked(en.OrgID, en.BucketID, en.Min, en.Max)
+
+		case *wal.DeleteBucketRangePredicateWALEntry:
+			pred, err := ParsePredicate(string(en.Predicate))
+			if err != nil {
+				return err
+			}
+			return e.deleteBucketRangePredicateLocked(en.OrgID, en.BucketID, en.Min, en.Max, pred)
 		}
 
 		return nil
@@ -360,30 +388,27 @@ func (e *Engine) WritePoints(ctx context.Context, points []models.Point) error {
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
 
-	collection, j := tsdb.NewSeriesCollection(points), 0
-
-	// dropPoint should be called whenever there is reason to drop a point from
-	// the batch.
-	dropPoint := func(key []byte, reason string) {
-		if collection.Reason == "" {
-			collection.Reason = reason
-		}
-		collection.Dropped++
-		collection.DroppedKeys = append(collection.DroppedKeys, key)
+	// Slow down writes to the rate at which the cache is snapshotted, rather than
+	// failing them once the cache is full. This must not hold the lock, which
+	// snapshots acquire.
+	if err := e.waitForCache(ctx); err != nil {
+		return err
 	}
 
+	collection, j := tsdb.NewSeriesCollection(points), 0
+
 	for iter := collection.Iterator(); iter.Next(); {
 		tags := iter.Tags()
 
 		// Not enough tags present.
 		if tags.Len() < 2 {
-			dropPoint(iter.Key(), fmt.Sprintf("missing required tags: parsed tags: %q", tags))
+			collection.Drop(iter.Index(), fmt.Sprintf("missing required tags: parsed tags: %q", tags))
 			continue
 		}
 
 		// First tag key is not measurement tag.
 		if !bytes.Equal(tags[0].Key, models.MeasurementTagKeyBytes) {
-			dropPoint(iter.Key(), fmt.Sprintf("missing required measurement tag as first tag, got: %q", tags[0].Key))
+			collection.Drop(iter.Index(), fmt.Sprintf("missing required measurement tag as first tag, got: %q", tags[0].Key))
 			continue
 		}
 
@@ -391,26 +416,26 @@ func (e *Engine) WritePoints(ctx context.Context, points []models.Point) error {
 
 		// Last tag key is not field tag.
 		if !bytes.Equal(fkey, models.FieldKeyTagKeyBytes) {
-			dropPoint(iter.Key(), fmt.Sprintf("missing required field key tag as last tag, got: %q", tags[0].Key))
+			collection.Drop(iter.Index(), fmt.Sprintf("missing required field key tag as last tag, got: %q", tags[0].Key))
 			continue
 		}
 
 		// The value representing the underlying field key is invalid if it's "time".
 		if bytes.Equal(fval, timeBytes) {
-			dropPoint(iter.Key(), fmt.Sprintf("invalid field key: input field %q is invalid", timeBytes))
+			collection.Drop(iter.Index(), fmt.Sprintf("invalid field key: input field %q is invalid", timeBytes))
 			continue
 		}
 
 		// Filter out any tags with key equal to "time": they are invalid.
 		if tags.Get(timeBytes) != nil {
-			dropPoint(iter.Key(), fmt.Sprintf("invalid tag key: input tag %q on measurement %q is invalid", timeBytes, iter.Name()))
+			collection.Drop(iter.Index(), fmt.Sprintf("invalid tag key: input tag %q on measurement %q is invalid", timeBytes, iter.Name()))
 			continue
 		}
 
 		// Drop any point with invalid unicode characters in any of the tag keys or values.
 		// This will also cover validating the value used to represent the field key.
 		if !models.ValidTagTokens(tags) {
-			dropPoint(iter.Key(), fmt.Sprintf("key contains invalid unicode: %q", iter.Key()))
+			collection.Drop(iter.Index(), fmt.Sprintf("key contains invalid unicode: %q", iter.Key()))
 			continue
 		}
 
@@ -426,6 +451,25 @@ func (e *Engine) WritePoints(ctx context.Context, points []models.Point) error {
 		return ErrEngineClosed
 	}
 
+	// Drop the points with a field type conflicting with the field of another
+	// series of their measurement. The types of new fields are claimed until the
+	// write completes, and only registered for the points written.
+	claims, err := e.fieldTypes.CheckCollection(collection)
+	if err != nil {
+		return err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			e.fieldTypes.Rollback(claims)
+		}
+	}()
+
+	// Drop the points which would create series beyond the limits of their bucket.
+	if err := e.limitSeries(ctx, collection); err != nil {
+		return err
+	}
+
 	// Convert the collection to values for adding to the WAL/Cache.
 	values, err := tsm1.CollectionToValues(collection)
 	if err != nil {
@@ -437,7 +481,46 @@ func (e *Engine) WritePoints(ctx context.Context, points []models.Point) error {
 		return err
 	}
 
-	return e.writePointsLocked(ctx, collection, values)
+	// The write may be partial, so the observer is notified even on error.
+	defer e.notifyCollection(collection)
+
+	err = e.writePointsLocked(ctx, collection, values)
+	if _, ok := err.(tsdb.PartialWriteError); err == nil || ok {
+		// Only the types of the fields of the points written are registered.
+		e.fieldTypes.Commit(claims, collection)
+		committed = true
+	}
+	return err
+}
+
+// waitForCache blocks while the cache is near its maximum memory size, until it
+// has been snapshotted or the back-pressure timeout expires.
+func (e *Engine) waitForCache(ctx context.Context) error {
+	limit := e.engine.Cache.MaxSize()
+	if limit == 0 {
+		return nil
+	}
+
+	threshold := uint64(float64(limit) * cacheBackPressureRatio)
+	if e.engine.Cache.Size() < threshold {
+		return nil
+	}
+
+	timeout := time.NewTimer(time.Duration(e.config.WriteBackPressureTimeout))
+	defer timeout.Stop()
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	for e.engine.Cache.Size() >= threshold {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return ErrCacheFull
+		case <-ticker.C:
+		}
+	}
+	return nil
 }
 
 // writePointsLocked does the work of writing points and must be called under some sort of lock.
@@ -490,6 +573,7 @@ func (e *Engine) AcquireSegments(ctx context.Context, fn func(segs []string) err
 		return err
 	}
 
+	e.snapshotStarted = time.Now()
 	return fn(segments)
 }
 
@@ -502,10 +586,20 @@ func (e *Engine) CommitSegments(ctx context.Context, segs []string, fn func() er
 	if err := fn(); err != nil {
 		return err
 	}
+	e.lastSnapshot = e.snapshotStarted
 
 	return e.wal.Remove(ctx, segs)
 }
 
+// LastSnapshotTime returns the time the last committed snapshot of the cache
+// was started, or the zero time if none has been committed. The data written
+// before then has been written to TSM files.
+func (e *Engine) LastSnapshotTime() time.Time {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.lastSnapshot
+}
+
 // DeleteBucket deletes an entire bucket from the storage engine.
 func (e *Engine) DeleteBucket(orgID, bucketID platform.ID) error {
 	return e.DeleteBucketRange(orgID, bucketID, math.MinInt64, math.MaxInt64)
@@ -524,6 +618,9 @@ func (e *Engine) DeleteBucketRange(orgID, bucketID platform.ID, min, max int64)
 		return err
 	}
 
+	// The delete may be partial, so the observer is notified even on error.
+	defer e.notifyDelete(orgID, bucketID, min, max)
+
 	return e.deleteBucketRangeLocked(orgID, bucketID, min, max)
 }
 
@@ -535,9 +632,56 @@ func (e *Engine) deleteBucketRangeLocked(orgID, bucketID platform.ID, min, max i
 	encoded := tsdb.EncodeName(orgID, bucketID)
 	name := models.EscapeMeasurement(encoded[:])
 
+	// The delete may remove series, and with them the last series of a field.
+	defer e.fieldTypes.Invalidate(encoded[:])
+
 	return e.engine.DeleteBucketRange(name, min, max)
 }
 
+// DeleteBucketRangePredicate deletes data from a bucket in the storage engine
+// for the series matching the predicate within the time range [min, max].
+// An empty predicate deletes all the data in the time range, like
+// DeleteBucketRange. See ParsePredicate for the supported predicate syntax.
+func (e *Engine) DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID platform.ID, min, max int64, predicate string) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	pred, err := ParsePredicate(predicate)
+	if err != nil {
+		return err
+	} else if pred == nil {
+		return e.DeleteBucketRange(orgID, bucketID, min, max)
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return ErrEngineClosed
+	}
+
+	// Add the delete to the WAL to be replayed if there is a crash or shutdown.
+	if _, err := e.wal.DeleteBucketRangePredicate(orgID, bucketID, min, max, []byte(predicate)); err != nil {
+		return err
+	}
+
+	// The delete may be partial, so the observer is notified even on error.
+	defer e.notifyDelete(orgID, bucketID, min, max)
+
+	return e.deleteBucketRangePredicateLocked(orgID, bucketID, min, max, pred)
+}
+
+// deleteBucketRangePredicateLocked does the work of deleting a bucket range for the
+// series matching pred and must be called under some sort of lock.
+func (e *Engine) deleteBucketRangePredicateLocked(orgID, bucketID platform.ID, min, max int64, pred influxql.Expr) error {
+	encoded := tsdb.EncodeName(orgID, bucketID)
+	name := models.EscapeMeasurement(encoded[:])
+
+	// The delete may remove series, and with them the last series of a field.
+	defer e.fieldTypes.Invalidate(encoded[:])
+
+	return e.engine.DeleteBucketRangePredicate(name, min, max, pred)
+}
+
 // SeriesCardinality returns the number of series in the engine.
 func (e *Engine) SeriesCardinality() int64 {
 	e.mu.RLock()
@@ -569,6 +713,21 @@ func (e *Engine) MeasurementCardinalityStats() tsi1.MeasurementCardinalityStats
 	return e.index.MeasurementCardinalityStats()
 }
 
+// BucketSeriesCardinality returns the number of series in the bucket.
+func (e *Engine) BucketSeriesCardinality(ctx context.Context, orgID, bucketID platform.ID) (int64, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return 0, ErrEngineClosed
+	}
+
+	name := tsdb.EncodeName(orgID, bucketID)
+	return int64(e.index.MeasurementCardinalityStats()[string(name[:])]), nil
+}
+
 // MeasurementStats returns the current measurement stats for the engine.
 func (e *Engine) MeasurementStats() (tsm1.MeasurementStats, error) {
 	return e.engine.MeasurementStats()
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
 	"github.com/influxdata/influxdb/tsdb/cursors"
 	"github.com/influxdata/influxql"
 )
@@ -32,3 +34,83 @@ func (e *Engine) TagValues(ctx context.Context, orgID, bucketID influxdb.ID, tag
 
 	return e.engine.TagValues(ctx, orgID, bucketID, tagKey, start, end, predicate)
 }
+
+// FieldTypes returns the type of each field of the measurement in the bucket, sorted
+// by field key. If measurement is empty, the fields of every measurement of the
+// bucket are returned, sorted by measurement and field key.
+func (e *Engine) FieldTypes(ctx context.Context, orgID, bucketID influxdb.ID, measurement string) ([]FieldType, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return nil, ErrEngineClosed
+	}
+
+	name := tsdb.EncodeName(orgID, bucketID)
+	return e.fieldTypes.FieldTypes(name[:], measurement)
+}
+
+// MeasurementFields returns the type of each field of the bucket with series matching
+// the predicate within the time range (start, end], sorted by measurement and field key.
+// Fields without a registered type, such as those of a write that is still in
+// progress, are omitted.
+func (e *Engine) MeasurementFields(ctx context.Context, orgID, bucketID influxdb.ID, start, end int64, predicate influxql.Expr) ([]FieldType, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return nil, ErrEngineClosed
+	}
+
+	measurements, err := e.engine.TagValues(ctx, orgID, bucketID, models.MeasurementTagKey, start, end, predicate)
+	if err != nil || measurements == nil {
+		return nil, err
+	}
+
+	var names []string
+	for measurements.Next() {
+		names = append(names, measurements.Value())
+	}
+
+	name := tsdb.EncodeName(orgID, bucketID)
+
+	var a []FieldType
+	for _, m := range names {
+		fields, err := e.engine.TagValues(ctx, orgID, bucketID, models.FieldKeyTagKey, start, end, measurementPredicate(m, predicate))
+		if err != nil {
+			return nil, err
+		} else if fields == nil {
+			continue
+		}
+
+		types, err := e.fieldTypes.FieldTypes(name[:], m)
+		if err != nil {
+			return nil, err
+		}
+		typeOf := make(map[string]models.FieldType, len(types))
+		for _, t := range types {
+			typeOf[t.Field] = t.Type
+		}
+
+		for fields.Next() {
+			field := fields.Value()
+			typ, ok := typeOf[field]
+			if !ok {
+				continue
+			}
+			a = append(a, FieldType{Measurement: m, Field: field, Type: typ})
+		}
+	}
+	return a, nil
+}
+
+// measurementPredicate restricts predicate to the series of the measurement.
+func measurementPredicate(measurement string, predicate influxql.Expr) influxql.Expr {
+	expr := influxql.Expr(&influxql.BinaryExpr{
+		Op:  influxql.EQ,
+		LHS: &influxql.VarRef{Val: models.MeasurementTagKey},
+		RHS: &influxql.StringLiteral{Val: measurement},
+	})
+	if predicate == nil {
+		return expr
+	}
+	return &influxql.BinaryExpr{Op: influxql.AND, LHS: expr, RHS: predicate}
+}
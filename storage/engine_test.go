@@ -6,13 +6,18 @@ import (
 	"io/ioutil"
 	"math"
 	"os"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/toml"
 	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxql"
 )
 
 func TestEngine_WriteAndIndex(t *testing.T) {
@@ -353,6 +358,402 @@ func TestEngine_WriteConflictingBatch(t *testing.T) {
 	}
 }
 
+func TestEngine_WritePoints_FieldTypeConflict(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	name := tsdb.EncodeNameString(engine.org, engine.bucket)
+	newPoint := func(host, field string, v interface{}) models.Point {
+		return models.MustNewPoint(
+			name,
+			models.NewTags(map[string]string{models.FieldKeyTagKey: field, models.MeasurementTagKey: "cpu", "host": host}),
+			map[string]interface{}{field: v},
+			time.Unix(1, 2),
+		)
+	}
+
+	if err := engine.Engine.WritePoints(context.TODO(), []models.Point{
+		newPoint("a", "value", 1.0),
+		newPoint("a", "count", int64(1)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Points of other series of the measurement conflicting with the type of the
+	// field are dropped.
+	err := engine.Engine.WritePoints(context.TODO(), []models.Point{
+		newPoint("b", "value", int64(2)),
+		newPoint("b", "count", int64(2)),
+		newPoint("b", "status", "ok"),
+		newPoint("c", "status", true),
+	})
+	pwe, ok := err.(tsdb.PartialWriteError)
+	if !ok {
+		t.Fatal("expected partial write error. got:", err)
+	} else if got, exp := len(pwe.DroppedPoints), 2; got != exp {
+		t.Fatalf("got %d dropped points, exp %d", got, exp)
+	} else if got, exp := pwe.Reason, `field type conflict: input field "value" on measurement "cpu" is type Integer, already exists as type Float`; got != exp {
+		t.Fatalf("got reason %q, exp %q", got, exp)
+	}
+
+	types, err := engine.FieldTypes(context.TODO(), engine.org, engine.bucket, "cpu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := types, []storage.FieldType{
+		{Measurement: "cpu", Field: "count", Type: models.Integer},
+		{Measurement: "cpu", Field: "status", Type: models.String},
+		{Measurement: "cpu", Field: "value", Type: models.Float},
+	}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got field types %v, exp %v", got, exp)
+	}
+
+	// Once the series of the field are deleted, the field may be written with
+	// another type.
+	if err := engine.DeleteBucket(engine.org, engine.bucket); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.Engine.WritePoints(context.TODO(), []models.Point{newPoint("b", "value", int64(2))}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEngine_WritePoints_ConcurrentFieldTypeConflict(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	name := tsdb.EncodeNameString(engine.org, engine.bucket)
+	newPoint := func(host, field string, v interface{}) models.Point {
+		return models.MustNewPoint(
+			name,
+			models.NewTags(map[string]string{models.FieldKeyTagKey: field, models.MeasurementTagKey: "cpu", "host": host}),
+			map[string]interface{}{field: v},
+			time.Unix(1, 2),
+		)
+	}
+
+	// Two writers concurrently write each new field from different series, one as
+	// a float and the other as an integer. Exactly one of them must win each field.
+	const n = 100
+	var (
+		wg      sync.WaitGroup
+		start   = make(chan struct{})
+		written [2][n]bool
+		errs    = make(chan error, 2*n)
+	)
+	for w, v := range []interface{}{1.0, int64(1)} {
+		wg.Add(1)
+		go func(w int, v interface{}) {
+			defer wg.Done()
+			<-start
+			for i := 0; i < n; i++ {
+				err := engine.Engine.WritePoints(context.TODO(), []models.Point{newPoint(fmt.Sprint("host", w), fmt.Sprint("f", i), v)})
+				if _, ok := err.(tsdb.PartialWriteError); err != nil && !ok {
+					errs <- err
+				}
+				written[w][i] = err == nil
+			}
+		}(w, v)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	types, err := engine.FieldTypes(context.TODO(), engine.org, engine.bucket, "cpu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	typeOf := make(map[string]models.FieldType, len(types))
+	for _, typ := range types {
+		typeOf[typ.Field] = typ.Type
+	}
+
+	for i := 0; i < n; i++ {
+		field := fmt.Sprint("f", i)
+		switch {
+		case written[0][i] && written[1][i]:
+			t.Fatalf("both types of field %q were written", field)
+		case written[0][i] && typeOf[field] != models.Float:
+			t.Fatalf("field %q was written as a float, but is registered as %s", field, typeOf[field])
+		case written[1][i] && typeOf[field] != models.Integer:
+			t.Fatalf("field %q was written as an integer, but is registered as %s", field, typeOf[field])
+		case !written[0][i] && !written[1][i]:
+			t.Fatalf("neither type of field %q was written", field)
+		}
+	}
+}
+
+func TestEngine_MeasurementFields(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	name := tsdb.EncodeNameString(engine.org, engine.bucket)
+	newPoint := func(measurement, host, field string, v interface{}) models.Point {
+		return models.MustNewPoint(
+			name,
+			models.NewTags(map[string]string{models.FieldKeyTagKey: field, models.MeasurementTagKey: measurement, "host": host}),
+			map[string]interface{}{field: v},
+			time.Unix(1, 2),
+		)
+	}
+
+	if err := engine.Engine.WritePoints(context.TODO(), []models.Point{
+		newPoint("cpu", "a", "value", 1.0),
+		newPoint("cpu", "b", "count", int64(1)),
+		newPoint("mem", "a", "free", uint64(1)),
+		newPoint("mem", "a", "ok", true),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	fields, err := engine.MeasurementFields(context.TODO(), engine.org, engine.bucket, models.MinNanoTime, models.MaxNanoTime, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := fields, []storage.FieldType{
+		{Measurement: "cpu", Field: "count", Type: models.Integer},
+		{Measurement: "cpu", Field: "value", Type: models.Float},
+		{Measurement: "mem", Field: "free", Type: models.Unsigned},
+		{Measurement: "mem", Field: "ok", Type: models.Boolean},
+	}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got fields %v, exp %v", got, exp)
+	}
+
+	// The predicate restricts the fields to those of the matching series.
+	predicate := &influxql.BinaryExpr{
+		Op:  influxql.EQ,
+		LHS: &influxql.VarRef{Val: "host"},
+		RHS: &influxql.StringLiteral{Val: "b"},
+	}
+	fields, err = engine.MeasurementFields(context.TODO(), engine.org, engine.bucket, models.MinNanoTime, models.MaxNanoTime, predicate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := fields, []storage.FieldType{
+		{Measurement: "cpu", Field: "count", Type: models.Integer},
+	}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got fields %v, exp %v", got, exp)
+	}
+}
+
+func TestEngine_WritePoints_CacheFull(t *testing.T) {
+	c := storage.NewConfig()
+	c.Engine.Cache.MaxMemorySize = 1
+	c.WriteBackPressureTimeout = toml.Duration(10 * time.Millisecond)
+
+	engine := NewEngine(c)
+	defer engine.Close()
+	engine.MustOpen()
+
+	// The cache never has room for the write, so it times out.
+	err := engine.Engine.WritePoints(context.TODO(), []models.Point{models.MustNewPoint(
+		tsdb.EncodeNameString(engine.org, engine.bucket),
+		models.NewTags(map[string]string{models.FieldKeyTagKey: "value", models.MeasurementTagKey: "cpu", "host": "server"}),
+		map[string]interface{}{"value": 1.0},
+		time.Unix(1, 2),
+	)})
+	if err != storage.ErrCacheFull {
+		t.Fatalf("got error %v, exp %v", err, storage.ErrCacheFull)
+	}
+}
+
+func TestEngine_WritePoints_SeriesLimit(t *testing.T) {
+	path, err := ioutil.TempDir("", "storage_engine_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	const (
+		orgID           = influxdb.ID(0x3131313131313131)
+		bucketID        = influxdb.ID(0x3232323232323232)
+		defaultBucketID = influxdb.ID(0x3333333333333333)
+		otherOrgID      = influxdb.ID(0x3434343434343434)
+		otherBucketID   = influxdb.ID(0x3535353535353535)
+	)
+
+	maxSeries := int64(2)
+	buckets := mock.NewBucketService()
+	buckets.FindBucketsFn = func(ctx context.Context, filter influxdb.BucketFilter, opts ...influxdb.FindOptions) ([]*influxdb.Bucket, int, error) {
+		switch *filter.ID {
+		case bucketID:
+			return []*influxdb.Bucket{{ID: bucketID, OrgID: orgID, MaxSeries: maxSeries}}, 1, nil
+		case otherBucketID:
+			// Like the kv bucket service, for a bucket without a record such as a system bucket.
+			return nil, 0, &influxdb.Error{Code: influxdb.ENotFound, Msg: "bucket not found"}
+		}
+		return []*influxdb.Bucket{{ID: *filter.ID}}, 1, nil
+	}
+
+	orgs := mock.NewOrganizationService()
+	orgs.FindOrganizationByIDF = func(ctx context.Context, id influxdb.ID) (*influxdb.Organization, error) {
+		if id == orgID {
+			return &influxdb.Organization{ID: id, MaxSeries: 1}, nil
+		}
+		return &influxdb.Organization{ID: id}, nil
+	}
+
+	engine := storage.NewEngine(path, storage.NewConfig(), storage.WithSeriesLimits(buckets, orgs))
+	if err := engine.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+
+	newPoint := func(orgID, bucketID influxdb.ID, host string) models.Point {
+		return models.MustNewPoint(
+			tsdb.EncodeNameString(orgID, bucketID),
+			models.NewTags(map[string]string{models.FieldKeyTagKey: "value", models.MeasurementTagKey: "cpu", "host": host}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(1, 2),
+		)
+	}
+
+	// The first write creates the maximum number of series of the bucket.
+	if err := engine.WritePoints(context.Background(), []models.Point{
+		newPoint(orgID, bucketID, "a"),
+		newPoint(orgID, bucketID, "a"),
+		newPoint(orgID, bucketID, "b"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Points of new series are dropped, while points of existing series and of
+	// other buckets are written.
+	err = engine.WritePoints(context.Background(), []models.Point{
+		newPoint(orgID, bucketID, "a"),
+		newPoint(orgID, bucketID, "c"),
+		newPoint(orgID, defaultBucketID, "a"),
+		newPoint(orgID, defaultBucketID, "b"),
+		newPoint(otherOrgID, otherBucketID, "a"),
+		newPoint(otherOrgID, otherBucketID, "b"),
+	})
+	pwe, ok := err.(tsdb.PartialWriteError)
+	if !ok {
+		t.Fatal("expected partial write error. got:", err)
+	} else if got, exp := pwe.Dropped, 2; got != exp {
+		t.Fatalf("got %d dropped points, exp %d", got, exp)
+	}
+
+	for _, tt := range []struct {
+		orgID, bucketID influxdb.ID
+		series          int64
+	}{
+		{orgID, bucketID, 2},
+		{orgID, defaultBucketID, 1},
+		{otherOrgID, otherBucketID, 2},
+	} {
+		n, err := engine.BucketSeriesCardinality(context.Background(), tt.orgID, tt.bucketID)
+		if err != nil {
+			t.Fatal(err)
+		} else if n != tt.series {
+			t.Errorf("got %d series in bucket %s/%s, exp %d", n, tt.orgID, tt.bucketID, tt.series)
+		}
+	}
+
+	// The field types of the points dropped are not registered.
+	err = engine.WritePoints(context.Background(), []models.Point{models.MustNewPoint(
+		tsdb.EncodeNameString(orgID, bucketID),
+		models.NewTags(map[string]string{models.FieldKeyTagKey: "load", models.MeasurementTagKey: "cpu", "host": "d"}),
+		map[string]interface{}{"load": int64(1)},
+		time.Unix(1, 2),
+	)})
+	if _, ok := err.(tsdb.PartialWriteError); !ok {
+		t.Fatal("expected partial write error. got:", err)
+	}
+	types, err := engine.FieldTypes(context.Background(), orgID, bucketID, "cpu")
+	if err != nil {
+		t.Fatal(err)
+	} else if exp := []storage.FieldType{{Measurement: "cpu", Field: "value", Type: models.Float}}; !reflect.DeepEqual(types, exp) {
+		t.Fatalf("got field types %v, exp %v", types, exp)
+	}
+
+	// The limits are cached until they are invalidated.
+	maxSeries = 3
+	err = engine.WritePoints(context.Background(), []models.Point{newPoint(orgID, bucketID, "c")})
+	if _, ok := err.(tsdb.PartialWriteError); !ok {
+		t.Fatal("expected partial write error. got:", err)
+	}
+
+	engine.InvalidateSeriesLimits()
+	if err := engine.WritePoints(context.Background(), []models.Point{newPoint(orgID, bucketID, "c")}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type changeObserver struct {
+	mu      sync.Mutex
+	changes []bucketRangeChange
+}
+
+type bucketRangeChange struct {
+	orgID, bucketID influxdb.ID
+	min, max        int64
+}
+
+func (o *changeObserver) BucketRangeChanged(orgID, bucketID influxdb.ID, min, max int64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.changes = append(o.changes, bucketRangeChange{orgID, bucketID, min, max})
+}
+
+func TestEngine_ChangeObserver(t *testing.T) {
+	path, err := ioutil.TempDir("", "storage_engine_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	const (
+		orgID    = influxdb.ID(0x3131313131313131)
+		bucketID = influxdb.ID(0x3232323232323232)
+	)
+
+	obs := &changeObserver{}
+	engine := storage.NewEngine(path, storage.NewConfig(), storage.WithChangeObserver(obs))
+	if err := engine.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+
+	newPoint := func(host string, ts time.Time) models.Point {
+		return models.MustNewPoint(
+			tsdb.EncodeNameString(orgID, bucketID),
+			models.NewTags(map[string]string{models.FieldKeyTagKey: "value", models.MeasurementTagKey: "cpu", "host": host}),
+			map[string]interface{}{"value": 1.0},
+			ts,
+		)
+	}
+
+	if err := engine.WritePoints(context.Background(), []models.Point{
+		newPoint("a", time.Unix(0, 20)),
+		newPoint("b", time.Unix(0, 10)),
+		newPoint("a", time.Unix(0, 30)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.DeleteBucketRange(orgID, bucketID, 5, 15); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.DeleteBucketRangePredicate(context.Background(), orgID, bucketID, 25, 35, "host = 'a'"); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []bucketRangeChange{
+		{orgID, bucketID, 10, 30},
+		{orgID, bucketID, 5, 15},
+		{orgID, bucketID, 25, 35},
+	}
+	if !reflect.DeepEqual(obs.changes, exp) {
+		t.Fatalf("got changes %v, exp %v", obs.changes, exp)
+	}
+}
+
 func BenchmarkDeleteBucket(b *testing.B) {
 	var engine *Engine
 	setup := func(card int) {
@@ -0,0 +1,347 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/tsi1"
+)
+
+// FieldType describes the type of a field of a measurement.
+type FieldType struct {
+	Measurement string
+	Field       string
+	Type        models.FieldType
+}
+
+// measurementFieldTypes maps the field keys of a measurement to their type.
+type measurementFieldTypes map[string]models.FieldType
+
+// bucketFieldTypes maps the measurements of a bucket to their field types.
+type bucketFieldTypes map[string]measurementFieldTypes
+
+// fieldTypeRegistry tracks the type of every field of each bucket, so that a point
+// with a field type that conflicts with another series of the same measurement is
+// rejected before its values reach the cache.
+//
+// The field types of a bucket are loaded from the index and series file the first
+// time the bucket is used, and are kept up to date by the writes to the bucket.
+// Each bucket has its own lock, so that writes to different buckets, and writes of
+// fields whose type is already known, do not wait for each other.
+type fieldTypeRegistry struct {
+	mu      sync.RWMutex
+	buckets map[string]*bucketFieldTypeState // keyed by the encoded org and bucket name
+
+	index *tsi1.Index
+	sfile *tsdb.SeriesFile
+}
+
+// bucketFieldTypeState holds the field types of a bucket, once they are loaded.
+type bucketFieldTypeState struct {
+	mu     sync.RWMutex
+	loaded bool
+	fields bucketFieldTypes
+
+	// claims are the types of new fields claimed by batches being written. The
+	// claimed type is already in fields, so that concurrent writes of the field
+	// with another type are dropped.
+	claims map[measurementField]*fieldTypeClaim
+}
+
+// measurementField identifies a field of a measurement of a bucket.
+type measurementField struct {
+	measurement, field string
+}
+
+// fieldTypeClaim is the claim of the type of a new field by the batches writing it.
+type fieldTypeClaim struct {
+	n       int  // number of batches holding the claim
+	written bool // set once a batch holding the claim has written the field
+}
+
+func newFieldTypeRegistry(index *tsi1.Index, sfile *tsdb.SeriesFile) *fieldTypeRegistry {
+	return &fieldTypeRegistry{
+		buckets: make(map[string]*bucketFieldTypeState),
+		index:   index,
+		sfile:   sfile,
+	}
+}
+
+// fieldTypeKey identifies a field of a measurement of a bucket within a batch.
+type fieldTypeKey struct {
+	name, measurement, field string
+}
+
+// fieldTypeClaims are the field types claimed by a batch.
+type fieldTypeClaims map[fieldTypeKey]*bucketFieldTypeState
+
+// CheckCollection drops the points of the collection with a field type that conflicts
+// with the type registered or claimed for the field of their measurement. Points of a
+// batch conflicting with each other are resolved in favor of the first point.
+//
+// The types of the new fields of the remaining points are claimed until the batch has
+// been written, so that a concurrent write of the same fields with other types is
+// dropped. The claims must be released with Commit once the batch has been written, or
+// with Rollback if it was not.
+func (r *fieldTypeRegistry) CheckCollection(collection *tsdb.SeriesCollection) (fieldTypeClaims, error) {
+	var claims fieldTypeClaims
+
+	j := 0
+	for iter := collection.Iterator(); iter.Next(); {
+		b, err := r.bucket(iter.Name())
+		if err != nil {
+			r.Rollback(claims)
+			return nil, err
+		}
+
+		tags := iter.Tags()
+		key := fieldTypeKey{
+			name:        string(iter.Name()),
+			measurement: string(tags.Get(models.MeasurementTagKeyBytes)),
+			field:       string(tags.Get(models.FieldKeyTagKeyBytes)),
+		}
+		typ := iter.Type()
+
+		existing, ok := b.check(key, typ, claims)
+		if ok && existing != typ {
+			collection.Drop(iter.Index(), fmt.Sprintf("field type conflict: input field %q on measurement %q is type %s, already exists as type %s", key.field, key.measurement, typ, existing))
+			continue
+		}
+		if !ok {
+			if claims == nil {
+				claims = make(fieldTypeClaims)
+			}
+			claims[key] = b
+		}
+
+		collection.Copy(j, iter.Index())
+		j++
+	}
+	collection.Truncate(j)
+
+	return claims, nil
+}
+
+// check returns the type registered or claimed for the field of key, and true if the
+// batch does not need to claim it, in which case the point conflicts if the type is not
+// typ. Otherwise, typ is claimed for the batch and check returns false.
+func (b *bucketFieldTypeState) check(key fieldTypeKey, typ models.FieldType, claims fieldTypeClaims) (models.FieldType, bool) {
+	mf := measurementField{measurement: key.measurement, field: key.field}
+
+	// Fast path: the type of the field is registered, or already claimed by the batch.
+	b.mu.RLock()
+	existing, ok := b.fields[mf.measurement][mf.field]
+	_, pending := b.claims[mf]
+	b.mu.RUnlock()
+	if ok && (!pending || existing != typ) {
+		return existing, true
+	} else if _, claimed := claims[key]; claimed {
+		return existing, true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if existing, ok := b.fields[mf.measurement][mf.field]; ok {
+		c := b.claims[mf]
+		if c == nil || existing != typ {
+			return existing, true
+		}
+		// The batch depends on the claim of another batch, which must not be
+		// rolled back before this batch is written.
+		c.n++
+		return existing, false
+	}
+
+	types := b.fields[mf.measurement]
+	if types == nil {
+		types = make(measurementFieldTypes)
+		b.fields[mf.measurement] = types
+	}
+	types[mf.field] = typ
+	if b.claims == nil {
+		b.claims = make(map[measurementField]*fieldTypeClaim)
+	}
+	b.claims[mf] = &fieldTypeClaim{n: 1}
+	return typ, false
+}
+
+// Commit releases the claims of a batch which has been written. The claimed types of
+// the fields of the points of the collection, which are the points written, become
+// registered. The other claims are rolled back.
+func (r *fieldTypeRegistry) Commit(claims fieldTypeClaims, collection *tsdb.SeriesCollection) {
+	if len(claims) == 0 {
+		return
+	}
+
+	written := make(map[fieldTypeKey]bool, len(claims))
+	for iter := collection.Iterator(); iter.Next(); {
+		tags := iter.Tags()
+		written[fieldTypeKey{
+			name:        string(iter.Name()),
+			measurement: string(tags.Get(models.MeasurementTagKeyBytes)),
+			field:       string(tags.Get(models.FieldKeyTagKeyBytes)),
+		}] = true
+	}
+
+	for key, b := range claims {
+		b.release(key, written[key])
+	}
+}
+
+// Rollback releases the claims of a batch which has not been written. The claimed types
+// are discarded, unless another batch holding the same claim has written the field.
+func (r *fieldTypeRegistry) Rollback(claims fieldTypeClaims) {
+	for key, b := range claims {
+		b.release(key, false)
+	}
+}
+
+func (b *bucketFieldTypeState) release(key fieldTypeKey, written bool) {
+	mf := measurementField{measurement: key.measurement, field: key.field}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.claims[mf]
+	if c == nil {
+		return
+	}
+	c.written = c.written || written
+	if c.n--; c.n > 0 {
+		return
+	}
+
+	delete(b.claims, mf)
+	if !c.written {
+		delete(b.fields[mf.measurement], mf.field)
+		if len(b.fields[mf.measurement]) == 0 {
+			delete(b.fields, mf.measurement)
+		}
+	}
+}
+
+// FieldTypes returns the field types of the measurement in the bucket sorted by field
+// key, or of every measurement of the bucket sorted by measurement if measurement is
+// empty.
+func (r *fieldTypeRegistry) FieldTypes(name []byte, measurement string) ([]FieldType, error) {
+	b, err := r.bucket(name)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	var a []FieldType
+	for m, types := range b.fields {
+		if measurement != "" && m != measurement {
+			continue
+		}
+		for field, typ := range types {
+			a = append(a, FieldType{Measurement: m, Field: field, Type: typ})
+		}
+	}
+	b.mu.RUnlock()
+
+	sort.Slice(a, func(i, j int) bool {
+		if a[i].Measurement != a[j].Measurement {
+			return a[i].Measurement < a[j].Measurement
+		}
+		return a[i].Field < a[j].Field
+	})
+	return a, nil
+}
+
+// Invalidate discards the field types of the bucket, which are loaded again from the
+// index the next time the bucket is used. It must be called after series of the
+// bucket have been deleted.
+func (r *fieldTypeRegistry) Invalidate(name []byte) {
+	r.mu.Lock()
+	delete(r.buckets, string(name))
+	r.mu.Unlock()
+}
+
+// bucket returns the field types of the bucket, loading them from the index if
+// necessary.
+func (r *fieldTypeRegistry) bucket(name []byte) (*bucketFieldTypeState, error) {
+	r.mu.RLock()
+	b, ok := r.buckets[string(name)]
+	r.mu.RUnlock()
+
+	if !ok {
+		r.mu.Lock()
+		if b, ok = r.buckets[string(name)]; !ok {
+			b = &bucketFieldTypeState{}
+			r.buckets[string(name)] = b
+		}
+		r.mu.Unlock()
+	}
+
+	b.mu.RLock()
+	loaded := b.loaded
+	b.mu.RUnlock()
+	if loaded {
+		return b, nil
+	}
+
+	// Only the writes to this bucket wait for its series to be scanned.
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.loaded {
+		fields, err := r.load(name)
+		if err != nil {
+			return nil, err
+		}
+		b.fields, b.loaded = fields, true
+	}
+	return b, nil
+}
+
+// load reads the field types of the bucket from the index.
+func (r *fieldTypeRegistry) load(name []byte) (bucketFieldTypes, error) {
+	fields := make(bucketFieldTypes)
+
+	itr, err := r.index.MeasurementSeriesIDIterator(name)
+	if err != nil {
+		return nil, err
+	} else if itr != nil {
+		defer itr.Close()
+
+		var tags models.Tags
+		for {
+			elem, err := itr.Next()
+			if err != nil {
+				return nil, err
+			} else if elem.SeriesID.IsZero() {
+				break
+			}
+
+			key := r.sfile.SeriesKey(elem.SeriesID)
+			if len(key) == 0 {
+				continue
+			}
+
+			id := r.sfile.SeriesIDTypedBySeriesKey(key)
+			if !id.HasType() {
+				continue
+			}
+
+			_, tags = tsdb.ParseSeriesKeyInto(key, tags[:0])
+			measurement := string(tags.Get(models.MeasurementTagKeyBytes))
+			field := string(tags.Get(models.FieldKeyTagKeyBytes))
+
+			types := fields[measurement]
+			if types == nil {
+				types = make(measurementFieldTypes)
+				fields[measurement] = types
+			}
+			if _, ok := types[field]; !ok {
+				types[field] = id.Type()
+			}
+		}
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+// OrganizationService wraps an existing platform.OrganizationService implementation.
+//
+// OrganizationService ensures that the engine looks up the series limits of the
+// buckets again after an organization has been updated.
+type OrganizationService struct {
+	platform.OrganizationService
+	engine SeriesLimitInvalidator
+}
+
+// NewOrganizationService returns a new OrganizationService for the provided
+// SeriesLimitInvalidator, which typically will be an Engine.
+func NewOrganizationService(s platform.OrganizationService, engine SeriesLimitInvalidator) *OrganizationService {
+	return &OrganizationService{
+		OrganizationService: s,
+		engine:              engine,
+	}
+}
+
+// UpdateOrganization updates a single organization with changeset.
+// Returns the new organization state after update.
+func (s *OrganizationService) UpdateOrganization(ctx context.Context, id platform.ID, upd platform.OrganizationUpdate) (*platform.Organization, error) {
+	o, err := s.OrganizationService.UpdateOrganization(ctx, id, upd)
+	if err != nil {
+		return nil, err
+	}
+	s.engine.InvalidateSeriesLimits()
+	return o, nil
+}
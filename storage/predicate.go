@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"fmt"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxql"
+)
+
+const (
+	predicateMeasurementKey = "_measurement"
+	predicateFieldKey       = "_field"
+)
+
+// ParsePredicate parses a delete predicate into an expression that can be
+// evaluated against the index.
+//
+// A predicate is an InfluxQL conditional expression made up of tag comparisons
+// (=, !=, =~, !~) combined with AND and OR, for example:
+//
+//	_measurement = 'cpu' AND (host = 'a' OR host =~ /^b/)
+//
+// The _measurement and _field keys refer to the measurement and field of a series.
+// An empty predicate returns a nil expression, which matches every series.
+func ParsePredicate(s string) (influxql.Expr, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	expr, err := influxql.ParseExpr(s)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "storage/ParsePredicate",
+			Msg:  fmt.Sprintf("invalid predicate: %v", err),
+			Err:  err,
+		}
+	}
+
+	if err := validatePredicate(expr); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "storage/ParsePredicate",
+			Msg:  fmt.Sprintf("invalid predicate: %v", err),
+			Err:  err,
+		}
+	}
+
+	return influxql.RewriteExpr(expr, func(e influxql.Expr) influxql.Expr {
+		ref, ok := e.(*influxql.VarRef)
+		if !ok {
+			return e
+		}
+
+		switch ref.Val {
+		case predicateMeasurementKey:
+			return &influxql.VarRef{Val: models.MeasurementTagKey, Type: influxql.Tag}
+		case predicateFieldKey:
+			return &influxql.VarRef{Val: models.FieldKeyTagKey, Type: influxql.Tag}
+		}
+		return &influxql.VarRef{Val: ref.Val, Type: influxql.Tag}
+	}), nil
+}
+
+// validatePredicate ensures expr only contains expressions supported by the index.
+func validatePredicate(expr influxql.Expr) error {
+	switch e := expr.(type) {
+	case *influxql.ParenExpr:
+		return validatePredicate(e.Expr)
+
+	case *influxql.BinaryExpr:
+		switch e.Op {
+		case influxql.AND, influxql.OR:
+			if err := validatePredicate(e.LHS); err != nil {
+				return err
+			}
+			return validatePredicate(e.RHS)
+
+		case influxql.EQ, influxql.NEQ:
+			if _, ok := e.LHS.(*influxql.VarRef); !ok {
+				return fmt.Errorf("expected tag key on left hand side of %s, got %s", e.Op, e.LHS)
+			}
+			if _, ok := e.RHS.(*influxql.StringLiteral); !ok {
+				return fmt.Errorf("expected string literal on right hand side of %s, got %s", e.Op, e.RHS)
+			}
+			return nil
+
+		case influxql.EQREGEX, influxql.NEQREGEX:
+			if _, ok := e.LHS.(*influxql.VarRef); !ok {
+				return fmt.Errorf("expected tag key on left hand side of %s, got %s", e.Op, e.LHS)
+			}
+			if _, ok := e.RHS.(*influxql.RegexLiteral); !ok {
+				return fmt.Errorf("expected regular expression on right hand side of %s, got %s", e.Op, e.RHS)
+			}
+			return nil
+		}
+		return fmt.Errorf("unsupported operator %s", e.Op)
+	}
+
+	return fmt.Errorf("unsupported expression %s", expr)
+}
@@ -0,0 +1,80 @@
+package storage_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxql"
+)
+
+func TestParsePredicate(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		exp     []string // tag keys referenced by the expression
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			in:   "",
+		},
+		{
+			name: "tag equality",
+			in:   "host = 'a'",
+			exp:  []string{"host"},
+		},
+		{
+			name: "measurement and field",
+			in:   "_measurement = 'cpu' AND (_field = 'usage' OR host =~ /^b/)",
+			exp:  []string{models.MeasurementTagKey, models.FieldKeyTagKey, "host"},
+		},
+		{
+			name:    "field value comparison",
+			in:      "value > 1",
+			wantErr: true,
+		},
+		{
+			name:    "tag compared to identifier",
+			in:      "host = server01",
+			wantErr: true,
+		},
+		{
+			name:    "invalid syntax",
+			in:      "host = ",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := storage.ParsePredicate(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got expression %v", expr)
+				}
+				if got, exp := influxdb.ErrorCode(err), influxdb.EInvalid; got != exp {
+					t.Fatalf("unexpected error code: got %q, exp %q", got, exp)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []string
+			influxql.WalkFunc(expr, func(n influxql.Node) {
+				if ref, ok := n.(*influxql.VarRef); ok {
+					if ref.Type != influxql.Tag {
+						t.Errorf("unexpected type for %q: got %v, exp %v", ref.Val, ref.Type, influxql.Tag)
+					}
+					got = append(got, ref.Val)
+				}
+			})
+			if !reflect.DeepEqual(got, tt.exp) {
+				t.Fatalf("unexpected tag keys: got %q, exp %q", got, tt.exp)
+			}
+		})
+	}
+}
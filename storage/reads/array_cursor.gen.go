@@ -237,6 +237,511 @@ func (c *integerFloatCountArrayCursor) Next() *cursors.IntegerArray {
 	}
 }
 
+// floatWindowCountArrayCursor computes the number of points of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type floatWindowCountArrayCursor struct {
+	cursors.FloatArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	a     *cursors.FloatArray
+	i     int
+}
+
+func newFloatWindowCountArrayCursor(cur cursors.FloatArrayCursor, every int64) *floatWindowCountArrayCursor {
+	return &floatWindowCountArrayCursor{
+		FloatArrayCursor: cur,
+		every:            every,
+		res:              cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowCountArrayCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		start, stop int64
+		open        bool
+		acc         int64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.FloatArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t := c.a.Timestamps[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				start, stop = windowBounds(t, c.every)
+				acc = 1
+				open = true
+				continue
+			}
+			acc++
+		}
+		c.a, c.i = c.FloatArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// floatWindowFirstArrayCursor selects the first point of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type floatWindowFirstArrayCursor struct {
+	cursors.FloatArrayCursor
+	every int64
+	res   *cursors.FloatArray
+	a     *cursors.FloatArray
+	i     int
+}
+
+func newFloatWindowFirstArrayCursor(cur cursors.FloatArrayCursor, every int64) *floatWindowFirstArrayCursor {
+	return &floatWindowFirstArrayCursor{
+		FloatArrayCursor: cur,
+		every:            every,
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowFirstArrayCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatWindowFirstArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  float64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.FloatArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+			}
+		}
+		c.a, c.i = c.FloatArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// floatWindowLastArrayCursor selects the last point of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type floatWindowLastArrayCursor struct {
+	cursors.FloatArrayCursor
+	every int64
+	res   *cursors.FloatArray
+	a     *cursors.FloatArray
+	i     int
+}
+
+func newFloatWindowLastArrayCursor(cur cursors.FloatArrayCursor, every int64) *floatWindowLastArrayCursor {
+	return &floatWindowLastArrayCursor{
+		FloatArrayCursor: cur,
+		every:            every,
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowLastArrayCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatWindowLastArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  float64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.FloatArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+				continue
+			}
+			ts, acc = t, v
+		}
+		c.a, c.i = c.FloatArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// floatWindowSumArrayCursor computes the sum of the values of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type floatWindowSumArrayCursor struct {
+	cursors.FloatArrayCursor
+	every int64
+	res   *cursors.FloatArray
+	a     *cursors.FloatArray
+	i     int
+}
+
+func newFloatWindowSumArrayCursor(cur cursors.FloatArrayCursor, every int64) *floatWindowSumArrayCursor {
+	return &floatWindowSumArrayCursor{
+		FloatArrayCursor: cur,
+		every:            every,
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowSumArrayCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatWindowSumArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		start, stop int64
+		open        bool
+		acc         float64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.FloatArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				start, stop = windowBounds(t, c.every)
+				acc = v
+				open = true
+				continue
+			}
+			acc += v
+		}
+		c.a, c.i = c.FloatArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// floatWindowMeanArrayCursor computes the mean of the values of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type floatWindowMeanArrayCursor struct {
+	cursors.FloatArrayCursor
+	every int64
+	res   *cursors.FloatArray
+	a     *cursors.FloatArray
+	i     int
+}
+
+func newFloatWindowMeanArrayCursor(cur cursors.FloatArrayCursor, every int64) *floatWindowMeanArrayCursor {
+	return &floatWindowMeanArrayCursor{
+		FloatArrayCursor: cur,
+		every:            every,
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowMeanArrayCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatWindowMeanArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		start, stop int64
+		open        bool
+		sum         float64
+		n           int64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.FloatArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = sum / float64(n)
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				start, stop = windowBounds(t, c.every)
+				sum, n = float64(v), 1
+				open = true
+				continue
+			}
+			sum += float64(v)
+			n++
+		}
+		c.a, c.i = c.FloatArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = sum / float64(n)
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// floatWindowMinArrayCursor selects the point with the minimum value in each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type floatWindowMinArrayCursor struct {
+	cursors.FloatArrayCursor
+	every int64
+	res   *cursors.FloatArray
+	a     *cursors.FloatArray
+	i     int
+}
+
+func newFloatWindowMinArrayCursor(cur cursors.FloatArrayCursor, every int64) *floatWindowMinArrayCursor {
+	return &floatWindowMinArrayCursor{
+		FloatArrayCursor: cur,
+		every:            every,
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowMinArrayCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatWindowMinArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  float64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.FloatArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+				continue
+			}
+			if v < acc {
+				ts, acc = t, v
+			}
+		}
+		c.a, c.i = c.FloatArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// floatWindowMaxArrayCursor selects the point with the maximum value in each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type floatWindowMaxArrayCursor struct {
+	cursors.FloatArrayCursor
+	every int64
+	res   *cursors.FloatArray
+	a     *cursors.FloatArray
+	i     int
+}
+
+func newFloatWindowMaxArrayCursor(cur cursors.FloatArrayCursor, every int64) *floatWindowMaxArrayCursor {
+	return &floatWindowMaxArrayCursor{
+		FloatArrayCursor: cur,
+		every:            every,
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowMaxArrayCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatWindowMaxArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  float64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.FloatArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+				continue
+			}
+			if v > acc {
+				ts, acc = t, v
+			}
+		}
+		c.a, c.i = c.FloatArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
 type floatEmptyArrayCursor struct {
 	res cursors.FloatArray
 }
@@ -466,19 +971,538 @@ func (c *integerIntegerCountArrayCursor) Next() *cursors.IntegerArray {
 	}
 }
 
-type integerEmptyArrayCursor struct {
-	res cursors.IntegerArray
+// integerWindowCountArrayCursor computes the number of points of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type integerWindowCountArrayCursor struct {
+	cursors.IntegerArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	a     *cursors.IntegerArray
+	i     int
 }
 
-var IntegerEmptyArrayCursor cursors.IntegerArrayCursor = &integerEmptyArrayCursor{}
-
-func (c *integerEmptyArrayCursor) Err() error                  { return nil }
-func (c *integerEmptyArrayCursor) Close()                      {}
-func (c *integerEmptyArrayCursor) Stats() cursors.CursorStats  { return cursors.CursorStats{} }
-func (c *integerEmptyArrayCursor) Next() *cursors.IntegerArray { return &c.res }
+func newIntegerWindowCountArrayCursor(cur cursors.IntegerArrayCursor, every int64) *integerWindowCountArrayCursor {
+	return &integerWindowCountArrayCursor{
+		IntegerArrayCursor: cur,
+		every:              every,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
 
-// ********************
-// Unsigned Array Cursor
+func (c *integerWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		start, stop int64
+		open        bool
+		acc         int64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.IntegerArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t := c.a.Timestamps[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				start, stop = windowBounds(t, c.every)
+				acc = 1
+				open = true
+				continue
+			}
+			acc++
+		}
+		c.a, c.i = c.IntegerArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// integerWindowFirstArrayCursor selects the first point of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type integerWindowFirstArrayCursor struct {
+	cursors.IntegerArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	a     *cursors.IntegerArray
+	i     int
+}
+
+func newIntegerWindowFirstArrayCursor(cur cursors.IntegerArrayCursor, every int64) *integerWindowFirstArrayCursor {
+	return &integerWindowFirstArrayCursor{
+		IntegerArrayCursor: cur,
+		every:              every,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *integerWindowFirstArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowFirstArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  int64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.IntegerArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+			}
+		}
+		c.a, c.i = c.IntegerArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// integerWindowLastArrayCursor selects the last point of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type integerWindowLastArrayCursor struct {
+	cursors.IntegerArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	a     *cursors.IntegerArray
+	i     int
+}
+
+func newIntegerWindowLastArrayCursor(cur cursors.IntegerArrayCursor, every int64) *integerWindowLastArrayCursor {
+	return &integerWindowLastArrayCursor{
+		IntegerArrayCursor: cur,
+		every:              every,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *integerWindowLastArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowLastArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  int64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.IntegerArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+				continue
+			}
+			ts, acc = t, v
+		}
+		c.a, c.i = c.IntegerArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// integerWindowSumArrayCursor computes the sum of the values of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type integerWindowSumArrayCursor struct {
+	cursors.IntegerArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	a     *cursors.IntegerArray
+	i     int
+}
+
+func newIntegerWindowSumArrayCursor(cur cursors.IntegerArrayCursor, every int64) *integerWindowSumArrayCursor {
+	return &integerWindowSumArrayCursor{
+		IntegerArrayCursor: cur,
+		every:              every,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *integerWindowSumArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowSumArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		start, stop int64
+		open        bool
+		acc         int64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.IntegerArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				start, stop = windowBounds(t, c.every)
+				acc = v
+				open = true
+				continue
+			}
+			acc += v
+		}
+		c.a, c.i = c.IntegerArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// integerWindowMeanArrayCursor computes the mean of the values of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type integerWindowMeanArrayCursor struct {
+	cursors.IntegerArrayCursor
+	every int64
+	res   *cursors.FloatArray
+	a     *cursors.IntegerArray
+	i     int
+}
+
+func newIntegerWindowMeanArrayCursor(cur cursors.IntegerArrayCursor, every int64) *integerWindowMeanArrayCursor {
+	return &integerWindowMeanArrayCursor{
+		IntegerArrayCursor: cur,
+		every:              every,
+		res:                cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *integerWindowMeanArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowMeanArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		start, stop int64
+		open        bool
+		sum         float64
+		n           int64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.IntegerArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = sum / float64(n)
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				start, stop = windowBounds(t, c.every)
+				sum, n = float64(v), 1
+				open = true
+				continue
+			}
+			sum += float64(v)
+			n++
+		}
+		c.a, c.i = c.IntegerArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = sum / float64(n)
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// integerWindowMinArrayCursor selects the point with the minimum value in each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type integerWindowMinArrayCursor struct {
+	cursors.IntegerArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	a     *cursors.IntegerArray
+	i     int
+}
+
+func newIntegerWindowMinArrayCursor(cur cursors.IntegerArrayCursor, every int64) *integerWindowMinArrayCursor {
+	return &integerWindowMinArrayCursor{
+		IntegerArrayCursor: cur,
+		every:              every,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *integerWindowMinArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowMinArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  int64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.IntegerArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+				continue
+			}
+			if v < acc {
+				ts, acc = t, v
+			}
+		}
+		c.a, c.i = c.IntegerArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// integerWindowMaxArrayCursor selects the point with the maximum value in each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type integerWindowMaxArrayCursor struct {
+	cursors.IntegerArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	a     *cursors.IntegerArray
+	i     int
+}
+
+func newIntegerWindowMaxArrayCursor(cur cursors.IntegerArrayCursor, every int64) *integerWindowMaxArrayCursor {
+	return &integerWindowMaxArrayCursor{
+		IntegerArrayCursor: cur,
+		every:              every,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *integerWindowMaxArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowMaxArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  int64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.IntegerArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+				continue
+			}
+			if v > acc {
+				ts, acc = t, v
+			}
+		}
+		c.a, c.i = c.IntegerArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+type integerEmptyArrayCursor struct {
+	res cursors.IntegerArray
+}
+
+var IntegerEmptyArrayCursor cursors.IntegerArrayCursor = &integerEmptyArrayCursor{}
+
+func (c *integerEmptyArrayCursor) Err() error                  { return nil }
+func (c *integerEmptyArrayCursor) Close()                      {}
+func (c *integerEmptyArrayCursor) Stats() cursors.CursorStats  { return cursors.CursorStats{} }
+func (c *integerEmptyArrayCursor) Next() *cursors.IntegerArray { return &c.res }
+
+// ********************
+// Unsigned Array Cursor
 
 type unsignedArrayFilterCursor struct {
 	cursors.UnsignedArrayCursor
@@ -667,32 +1691,551 @@ func (c unsignedArraySumCursor) Next() *cursors.UnsignedArray {
 	}
 }
 
-type integerUnsignedCountArrayCursor struct {
+type integerUnsignedCountArrayCursor struct {
+	cursors.UnsignedArrayCursor
+}
+
+func (c *integerUnsignedCountArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *integerUnsignedCountArrayCursor) Next() *cursors.IntegerArray {
+	a := c.UnsignedArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return &cursors.IntegerArray{}
+	}
+
+	ts := a.Timestamps[0]
+	var acc int64
+	for {
+		acc += int64(len(a.Timestamps))
+		a = c.UnsignedArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			res := cursors.NewIntegerArrayLen(1)
+			res.Timestamps[0] = ts
+			res.Values[0] = acc
+			return res
+		}
+	}
+}
+
+// unsignedWindowCountArrayCursor computes the number of points of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type unsignedWindowCountArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	a     *cursors.UnsignedArray
+	i     int
+}
+
+func newUnsignedWindowCountArrayCursor(cur cursors.UnsignedArrayCursor, every int64) *unsignedWindowCountArrayCursor {
+	return &unsignedWindowCountArrayCursor{
+		UnsignedArrayCursor: cur,
+		every:               every,
+		res:                 cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *unsignedWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		start, stop int64
+		open        bool
+		acc         int64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.UnsignedArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t := c.a.Timestamps[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				start, stop = windowBounds(t, c.every)
+				acc = 1
+				open = true
+				continue
+			}
+			acc++
+		}
+		c.a, c.i = c.UnsignedArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// unsignedWindowFirstArrayCursor selects the first point of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type unsignedWindowFirstArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	every int64
+	res   *cursors.UnsignedArray
+	a     *cursors.UnsignedArray
+	i     int
+}
+
+func newUnsignedWindowFirstArrayCursor(cur cursors.UnsignedArrayCursor, every int64) *unsignedWindowFirstArrayCursor {
+	return &unsignedWindowFirstArrayCursor{
+		UnsignedArrayCursor: cur,
+		every:               every,
+		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *unsignedWindowFirstArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowFirstArrayCursor) Next() *cursors.UnsignedArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  uint64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.UnsignedArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+			}
+		}
+		c.a, c.i = c.UnsignedArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// unsignedWindowLastArrayCursor selects the last point of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type unsignedWindowLastArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	every int64
+	res   *cursors.UnsignedArray
+	a     *cursors.UnsignedArray
+	i     int
+}
+
+func newUnsignedWindowLastArrayCursor(cur cursors.UnsignedArrayCursor, every int64) *unsignedWindowLastArrayCursor {
+	return &unsignedWindowLastArrayCursor{
+		UnsignedArrayCursor: cur,
+		every:               every,
+		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *unsignedWindowLastArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowLastArrayCursor) Next() *cursors.UnsignedArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  uint64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.UnsignedArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+				continue
+			}
+			ts, acc = t, v
+		}
+		c.a, c.i = c.UnsignedArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// unsignedWindowSumArrayCursor computes the sum of the values of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type unsignedWindowSumArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	every int64
+	res   *cursors.UnsignedArray
+	a     *cursors.UnsignedArray
+	i     int
+}
+
+func newUnsignedWindowSumArrayCursor(cur cursors.UnsignedArrayCursor, every int64) *unsignedWindowSumArrayCursor {
+	return &unsignedWindowSumArrayCursor{
+		UnsignedArrayCursor: cur,
+		every:               every,
+		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *unsignedWindowSumArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowSumArrayCursor) Next() *cursors.UnsignedArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		start, stop int64
+		open        bool
+		acc         uint64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.UnsignedArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				start, stop = windowBounds(t, c.every)
+				acc = v
+				open = true
+				continue
+			}
+			acc += v
+		}
+		c.a, c.i = c.UnsignedArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// unsignedWindowMeanArrayCursor computes the mean of the values of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type unsignedWindowMeanArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	every int64
+	res   *cursors.FloatArray
+	a     *cursors.UnsignedArray
+	i     int
+}
+
+func newUnsignedWindowMeanArrayCursor(cur cursors.UnsignedArrayCursor, every int64) *unsignedWindowMeanArrayCursor {
+	return &unsignedWindowMeanArrayCursor{
+		UnsignedArrayCursor: cur,
+		every:               every,
+		res:                 cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *unsignedWindowMeanArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowMeanArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		start, stop int64
+		open        bool
+		sum         float64
+		n           int64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.UnsignedArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = sum / float64(n)
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				start, stop = windowBounds(t, c.every)
+				sum, n = float64(v), 1
+				open = true
+				continue
+			}
+			sum += float64(v)
+			n++
+		}
+		c.a, c.i = c.UnsignedArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = sum / float64(n)
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// unsignedWindowMinArrayCursor selects the point with the minimum value in each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type unsignedWindowMinArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	every int64
+	res   *cursors.UnsignedArray
+	a     *cursors.UnsignedArray
+	i     int
+}
+
+func newUnsignedWindowMinArrayCursor(cur cursors.UnsignedArrayCursor, every int64) *unsignedWindowMinArrayCursor {
+	return &unsignedWindowMinArrayCursor{
+		UnsignedArrayCursor: cur,
+		every:               every,
+		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *unsignedWindowMinArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowMinArrayCursor) Next() *cursors.UnsignedArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  uint64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.UnsignedArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+				continue
+			}
+			if v < acc {
+				ts, acc = t, v
+			}
+		}
+		c.a, c.i = c.UnsignedArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// unsignedWindowMaxArrayCursor selects the point with the maximum value in each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type unsignedWindowMaxArrayCursor struct {
 	cursors.UnsignedArrayCursor
+	every int64
+	res   *cursors.UnsignedArray
+	a     *cursors.UnsignedArray
+	i     int
 }
 
-func (c *integerUnsignedCountArrayCursor) Stats() cursors.CursorStats {
+func newUnsignedWindowMaxArrayCursor(cur cursors.UnsignedArrayCursor, every int64) *unsignedWindowMaxArrayCursor {
+	return &unsignedWindowMaxArrayCursor{
+		UnsignedArrayCursor: cur,
+		every:               every,
+		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *unsignedWindowMaxArrayCursor) Stats() cursors.CursorStats {
 	return c.UnsignedArrayCursor.Stats()
 }
 
-func (c *integerUnsignedCountArrayCursor) Next() *cursors.IntegerArray {
-	a := c.UnsignedArrayCursor.Next()
-	if len(a.Timestamps) == 0 {
-		return &cursors.IntegerArray{}
+func (c *unsignedWindowMaxArrayCursor) Next() *cursors.UnsignedArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  uint64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.UnsignedArrayCursor.Next(), 0
 	}
 
-	ts := a.Timestamps[0]
-	var acc int64
-	for {
-		acc += int64(len(a.Timestamps))
-		a = c.UnsignedArrayCursor.Next()
-		if len(a.Timestamps) == 0 {
-			res := cursors.NewIntegerArrayLen(1)
-			res.Timestamps[0] = ts
-			res.Values[0] = acc
-			return res
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+				continue
+			}
+			if v > acc {
+				ts, acc = t, v
+			}
 		}
+		c.a, c.i = c.UnsignedArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
 	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
 }
 
 type unsignedEmptyArrayCursor struct {
@@ -884,6 +2427,223 @@ func (c *integerStringCountArrayCursor) Next() *cursors.IntegerArray {
 	}
 }
 
+// stringWindowCountArrayCursor computes the number of points of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type stringWindowCountArrayCursor struct {
+	cursors.StringArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	a     *cursors.StringArray
+	i     int
+}
+
+func newStringWindowCountArrayCursor(cur cursors.StringArrayCursor, every int64) *stringWindowCountArrayCursor {
+	return &stringWindowCountArrayCursor{
+		StringArrayCursor: cur,
+		every:             every,
+		res:               cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *stringWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.StringArrayCursor.Stats()
+}
+
+func (c *stringWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		start, stop int64
+		open        bool
+		acc         int64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.StringArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t := c.a.Timestamps[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				start, stop = windowBounds(t, c.every)
+				acc = 1
+				open = true
+				continue
+			}
+			acc++
+		}
+		c.a, c.i = c.StringArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// stringWindowFirstArrayCursor selects the first point of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type stringWindowFirstArrayCursor struct {
+	cursors.StringArrayCursor
+	every int64
+	res   *cursors.StringArray
+	a     *cursors.StringArray
+	i     int
+}
+
+func newStringWindowFirstArrayCursor(cur cursors.StringArrayCursor, every int64) *stringWindowFirstArrayCursor {
+	return &stringWindowFirstArrayCursor{
+		StringArrayCursor: cur,
+		every:             every,
+		res:               cursors.NewStringArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *stringWindowFirstArrayCursor) Stats() cursors.CursorStats {
+	return c.StringArrayCursor.Stats()
+}
+
+func (c *stringWindowFirstArrayCursor) Next() *cursors.StringArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  string
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.StringArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+			}
+		}
+		c.a, c.i = c.StringArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// stringWindowLastArrayCursor selects the last point of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type stringWindowLastArrayCursor struct {
+	cursors.StringArrayCursor
+	every int64
+	res   *cursors.StringArray
+	a     *cursors.StringArray
+	i     int
+}
+
+func newStringWindowLastArrayCursor(cur cursors.StringArrayCursor, every int64) *stringWindowLastArrayCursor {
+	return &stringWindowLastArrayCursor{
+		StringArrayCursor: cur,
+		every:             every,
+		res:               cursors.NewStringArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *stringWindowLastArrayCursor) Stats() cursors.CursorStats { return c.StringArrayCursor.Stats() }
+
+func (c *stringWindowLastArrayCursor) Next() *cursors.StringArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  string
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.StringArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+				continue
+			}
+			ts, acc = t, v
+		}
+		c.a, c.i = c.StringArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
 type stringEmptyArrayCursor struct {
 	res cursors.StringArray
 }
@@ -1073,6 +2833,225 @@ func (c *integerBooleanCountArrayCursor) Next() *cursors.IntegerArray {
 	}
 }
 
+// booleanWindowCountArrayCursor computes the number of points of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type booleanWindowCountArrayCursor struct {
+	cursors.BooleanArrayCursor
+	every int64
+	res   *cursors.IntegerArray
+	a     *cursors.BooleanArray
+	i     int
+}
+
+func newBooleanWindowCountArrayCursor(cur cursors.BooleanArrayCursor, every int64) *booleanWindowCountArrayCursor {
+	return &booleanWindowCountArrayCursor{
+		BooleanArrayCursor: cur,
+		every:              every,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *booleanWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.BooleanArrayCursor.Stats()
+}
+
+func (c *booleanWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		start, stop int64
+		open        bool
+		acc         int64
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.BooleanArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t := c.a.Timestamps[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = start
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				start, stop = windowBounds(t, c.every)
+				acc = 1
+				open = true
+				continue
+			}
+			acc++
+		}
+		c.a, c.i = c.BooleanArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = start
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// booleanWindowFirstArrayCursor selects the first point of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type booleanWindowFirstArrayCursor struct {
+	cursors.BooleanArrayCursor
+	every int64
+	res   *cursors.BooleanArray
+	a     *cursors.BooleanArray
+	i     int
+}
+
+func newBooleanWindowFirstArrayCursor(cur cursors.BooleanArrayCursor, every int64) *booleanWindowFirstArrayCursor {
+	return &booleanWindowFirstArrayCursor{
+		BooleanArrayCursor: cur,
+		every:              every,
+		res:                cursors.NewBooleanArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *booleanWindowFirstArrayCursor) Stats() cursors.CursorStats {
+	return c.BooleanArrayCursor.Stats()
+}
+
+func (c *booleanWindowFirstArrayCursor) Next() *cursors.BooleanArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  bool
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.BooleanArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+			}
+		}
+		c.a, c.i = c.BooleanArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
+// booleanWindowLastArrayCursor selects the last point of each window of
+// every nanoseconds. If every is 0, the entire series is a single window.
+type booleanWindowLastArrayCursor struct {
+	cursors.BooleanArrayCursor
+	every int64
+	res   *cursors.BooleanArray
+	a     *cursors.BooleanArray
+	i     int
+}
+
+func newBooleanWindowLastArrayCursor(cur cursors.BooleanArrayCursor, every int64) *booleanWindowLastArrayCursor {
+	return &booleanWindowLastArrayCursor{
+		BooleanArrayCursor: cur,
+		every:              every,
+		res:                cursors.NewBooleanArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *booleanWindowLastArrayCursor) Stats() cursors.CursorStats {
+	return c.BooleanArrayCursor.Stats()
+}
+
+func (c *booleanWindowLastArrayCursor) Next() *cursors.BooleanArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var (
+		stop int64
+		open bool
+		ts   int64
+		acc  bool
+	)
+
+	if c.a == nil || c.i >= c.a.Len() {
+		c.a, c.i = c.BooleanArrayCursor.Next(), 0
+	}
+
+LOOP:
+	for c.a.Len() > 0 {
+		for ; c.i < c.a.Len(); c.i++ {
+			t, v := c.a.Timestamps[c.i], c.a.Values[c.i]
+			if open && c.every > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = acc
+				pos++
+				open = false
+				if pos >= MaxPointsPerBlock {
+					break LOOP
+				}
+			}
+			if !open {
+				_, stop = windowBounds(t, c.every)
+				ts, acc = t, v
+				open = true
+				continue
+			}
+			ts, acc = t, v
+		}
+		c.a, c.i = c.BooleanArrayCursor.Next(), 0
+	}
+
+	if open {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+
+	return c.res
+}
+
 type booleanEmptyArrayCursor struct {
 	res cursors.BooleanArray
 }
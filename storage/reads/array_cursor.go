@@ -3,7 +3,10 @@ package reads
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
 
+	"github.com/influxdata/flux"
 	"github.com/influxdata/influxdb/storage/reads/datatypes"
 	"github.com/influxdata/influxdb/tsdb/cursors"
 )
@@ -16,33 +19,179 @@ func (v *singleValue) Value(key string) (interface{}, bool) {
 	return v.v, true
 }
 
-func newAggregateArrayCursor(ctx context.Context, agg *datatypes.Aggregate, cursor cursors.Cursor) cursors.Cursor {
+func newAggregateArrayCursor(ctx context.Context, agg *datatypes.Aggregate, every int64, cursor cursors.Cursor) (cursors.Cursor, error) {
 	if cursor == nil {
-		return nil
+		return nil, nil
+	}
+
+	if every > 0 {
+		return newWindowAggregateArrayCursor(agg, every, cursor)
 	}
 
 	switch agg.Type {
 	case datatypes.AggregateTypeSum:
 		return newSumArrayCursor(cursor)
 	case datatypes.AggregateTypeCount:
-		return newCountArrayCursor(cursor)
+		return newCountArrayCursor(cursor), nil
+	case datatypes.AggregateTypeMin, datatypes.AggregateTypeMax, datatypes.AggregateTypeMean,
+		datatypes.AggregateTypeFirst, datatypes.AggregateTypeLast:
+		// a window of 0 spans the entire series
+		return newWindowAggregateArrayCursor(agg, 0, cursor)
 	default:
 		// TODO(sgc): should be validated higher up
 		panic("invalid aggregate")
 	}
 }
 
-func newSumArrayCursor(cur cursors.Cursor) cursors.Cursor {
+// newWindowAggregateArrayCursor returns a cursor which produces one point
+// per window of every nanoseconds, aligned to the Unix epoch.
+func newWindowAggregateArrayCursor(agg *datatypes.Aggregate, every int64, cursor cursors.Cursor) (cursors.Cursor, error) {
+	switch agg.Type {
+	case datatypes.AggregateTypeCount:
+		return newWindowCountArrayCursor(cursor, every), nil
+	case datatypes.AggregateTypeSum:
+		return newWindowSumArrayCursor(cursor, every)
+	case datatypes.AggregateTypeMean:
+		return newWindowMeanArrayCursor(cursor, every)
+	case datatypes.AggregateTypeMin:
+		return newWindowMinArrayCursor(cursor, every)
+	case datatypes.AggregateTypeMax:
+		return newWindowMaxArrayCursor(cursor, every)
+	case datatypes.AggregateTypeFirst:
+		return newWindowFirstArrayCursor(cursor, every), nil
+	case datatypes.AggregateTypeLast:
+		return newWindowLastArrayCursor(cursor, every), nil
+	default:
+		panic("invalid aggregate")
+	}
+}
+
+// unsupportedAggregateTypeError returns the error of an aggregate which does not
+// support the values of cur, such as the sum of a string field.
+func unsupportedAggregateTypeError(agg datatypes.Aggregate_AggregateType, cur cursors.Cursor) error {
+	var typ flux.ColType
+	switch cur.(type) {
+	case cursors.StringArrayCursor:
+		typ = flux.TString
+	case cursors.BooleanArrayCursor:
+		typ = flux.TBool
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+	return fmt.Errorf("unsupported input type for %s aggregate: %s", strings.ToLower(agg.String()), typ)
+}
+
+// cursorOrder returns the direction and the maximum number of points to
+// read from each series for req. The first and last selectors over the
+// entire range only require a single point, which is read from the start
+// or the end of the series respectively.
+func cursorOrder(req *datatypes.ReadRequest) (asc bool, limit int64) {
+	asc, limit = !req.Descending, req.PointsLimit
+	if req.Aggregate == nil || req.WindowEvery > 0 || limit < 0 {
+		return asc, limit
+	}
+
+	switch req.Aggregate.Type {
+	case datatypes.AggregateTypeFirst:
+		return true, 1
+	case datatypes.AggregateTypeLast:
+		return false, 1
+	}
+	return asc, limit
+}
+
+// windowBounds returns the window [start, stop) containing t. If every is
+// 0, the window is unbounded and starts at t.
+func windowBounds(t, every int64) (start, stop int64) {
+	if every <= 0 {
+		return t, math.MaxInt64
+	}
+	start = t - t%every
+	if t%every < 0 {
+		start -= every
+	}
+	return start, start + every
+}
+
+func newWindowCountArrayCursor(cur cursors.Cursor, every int64) cursors.Cursor {
 	switch cur := cur.(type) {
 	case cursors.FloatArrayCursor:
-		return newFloatArraySumCursor(cur)
+		return newFloatWindowCountArrayCursor(cur, every)
 	case cursors.IntegerArrayCursor:
-		return newIntegerArraySumCursor(cur)
+		return newIntegerWindowCountArrayCursor(cur, every)
 	case cursors.UnsignedArrayCursor:
-		return newUnsignedArraySumCursor(cur)
+		return newUnsignedWindowCountArrayCursor(cur, every)
+	case cursors.StringArrayCursor:
+		return newStringWindowCountArrayCursor(cur, every)
+	case cursors.BooleanArrayCursor:
+		return newBooleanWindowCountArrayCursor(cur, every)
 	default:
-		// TODO(sgc): propagate an error instead?
-		return nil
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
+func newWindowSumArrayCursor(cur cursors.Cursor, every int64) (cursors.Cursor, error) {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowSumArrayCursor(cur, every), nil
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowSumArrayCursor(cur, every), nil
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowSumArrayCursor(cur, every), nil
+	default:
+		return nil, unsupportedAggregateTypeError(datatypes.AggregateTypeSum, cur)
+	}
+}
+
+func newWindowMeanArrayCursor(cur cursors.Cursor, every int64) (cursors.Cursor, error) {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowMeanArrayCursor(cur, every), nil
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowMeanArrayCursor(cur, every), nil
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowMeanArrayCursor(cur, every), nil
+	default:
+		return nil, unsupportedAggregateTypeError(datatypes.AggregateTypeMean, cur)
+	}
+}
+
+func newWindowMinArrayCursor(cur cursors.Cursor, every int64) (cursors.Cursor, error) {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowMinArrayCursor(cur, every), nil
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowMinArrayCursor(cur, every), nil
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowMinArrayCursor(cur, every), nil
+	default:
+		return nil, unsupportedAggregateTypeError(datatypes.AggregateTypeMin, cur)
+	}
+}
+
+func newWindowMaxArrayCursor(cur cursors.Cursor, every int64) (cursors.Cursor, error) {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowMaxArrayCursor(cur, every), nil
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowMaxArrayCursor(cur, every), nil
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowMaxArrayCursor(cur, every), nil
+	default:
+		return nil, unsupportedAggregateTypeError(datatypes.AggregateTypeMax, cur)
+	}
+}
+
+func newSumArrayCursor(cur cursors.Cursor) (cursors.Cursor, error) {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatArraySumCursor(cur), nil
+	case cursors.IntegerArrayCursor:
+		return newIntegerArraySumCursor(cur), nil
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedArraySumCursor(cur), nil
+	default:
+		return nil, unsupportedAggregateTypeError(datatypes.AggregateTypeSum, cur)
 	}
 }
 
@@ -63,6 +212,40 @@ func newCountArrayCursor(cur cursors.Cursor) cursors.Cursor {
 	}
 }
 
+func newWindowFirstArrayCursor(cur cursors.Cursor, every int64) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowFirstArrayCursor(cur, every)
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowFirstArrayCursor(cur, every)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowFirstArrayCursor(cur, every)
+	case cursors.StringArrayCursor:
+		return newStringWindowFirstArrayCursor(cur, every)
+	case cursors.BooleanArrayCursor:
+		return newBooleanWindowFirstArrayCursor(cur, every)
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
+func newWindowLastArrayCursor(cur cursors.Cursor, every int64) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowLastArrayCursor(cur, every)
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowLastArrayCursor(cur, every)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowLastArrayCursor(cur, every)
+	case cursors.StringArrayCursor:
+		return newStringWindowLastArrayCursor(cur, every)
+	case cursors.BooleanArrayCursor:
+		return newBooleanWindowLastArrayCursor(cur, every)
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
 type cursorContext struct {
 	ctx   context.Context
 	req   *cursors.CursorRequest
@@ -158,6 +341,6 @@ func (m *multiShardArrayCursors) createCursor(row SeriesRow) cursors.Cursor {
 	}
 }
 
-func (m *multiShardArrayCursors) newAggregateCursor(ctx context.Context, agg *datatypes.Aggregate, cursor cursors.Cursor) cursors.Cursor {
-	return newAggregateArrayCursor(ctx, agg, cursor)
+func (m *multiShardArrayCursors) newAggregateCursor(ctx context.Context, agg *datatypes.Aggregate, every int64, cursor cursors.Cursor) (cursors.Cursor, error) {
+	return newAggregateArrayCursor(ctx, agg, every, cursor)
 }
@@ -0,0 +1,280 @@
+package reads
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+type integerArrayCursor struct {
+	arrays []*cursors.IntegerArray
+}
+
+func (c *integerArrayCursor) Close()                     {}
+func (c *integerArrayCursor) Err() error                 { return nil }
+func (c *integerArrayCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+func (c *integerArrayCursor) Next() *cursors.IntegerArray {
+	if len(c.arrays) == 0 {
+		return &cursors.IntegerArray{}
+	}
+	a := c.arrays[0]
+	c.arrays = c.arrays[1:]
+	return a
+}
+
+func newIntegerArrayCursor(blocks ...[][2]int64) *integerArrayCursor {
+	cur := &integerArrayCursor{}
+	for _, block := range blocks {
+		a := cursors.NewIntegerArrayLen(len(block))
+		for i, p := range block {
+			a.Timestamps[i], a.Values[i] = p[0], p[1]
+		}
+		cur.arrays = append(cur.arrays, a)
+	}
+	return cur
+}
+
+func TestWindowBounds(t *testing.T) {
+	tests := []struct {
+		t, every    int64
+		start, stop int64
+	}{
+		{t: 0, every: 10, start: 0, stop: 10},
+		{t: 9, every: 10, start: 0, stop: 10},
+		{t: 10, every: 10, start: 10, stop: 20},
+		{t: -1, every: 10, start: -10, stop: 0},
+		{t: -10, every: 10, start: -10, stop: 0},
+	}
+	for _, tt := range tests {
+		start, stop := windowBounds(tt.t, tt.every)
+		if start != tt.start || stop != tt.stop {
+			t.Errorf("windowBounds(%d, %d) = [%d, %d), want [%d, %d)", tt.t, tt.every, start, stop, tt.start, tt.stop)
+		}
+	}
+}
+
+func TestWindowAggregateArrayCursor(t *testing.T) {
+	// points span three windows of 10ns and two blocks
+	blocks := [][][2]int64{
+		{{1, 4}, {5, 2}, {12, 7}},
+		{{15, 3}, {18, 9}, {31, 5}},
+	}
+
+	tests := []struct {
+		name  string
+		agg   datatypes.Aggregate_AggregateType
+		every int64
+		ts    []int64
+		vs    interface{}
+	}{
+		{
+			name:  "count",
+			agg:   datatypes.AggregateTypeCount,
+			every: 10,
+			ts:    []int64{0, 10, 30},
+			vs:    []int64{2, 3, 1},
+		},
+		{
+			name:  "sum",
+			agg:   datatypes.AggregateTypeSum,
+			every: 10,
+			ts:    []int64{0, 10, 30},
+			vs:    []int64{6, 19, 5},
+		},
+		{
+			name:  "mean",
+			agg:   datatypes.AggregateTypeMean,
+			every: 10,
+			ts:    []int64{0, 10, 30},
+			vs:    []float64{3, 19.0 / 3, 5},
+		},
+		{
+			name:  "min",
+			agg:   datatypes.AggregateTypeMin,
+			every: 10,
+			ts:    []int64{5, 15, 31},
+			vs:    []int64{2, 3, 5},
+		},
+		{
+			name:  "max",
+			agg:   datatypes.AggregateTypeMax,
+			every: 10,
+			ts:    []int64{1, 18, 31},
+			vs:    []int64{4, 9, 5},
+		},
+		{
+			name:  "first",
+			agg:   datatypes.AggregateTypeFirst,
+			every: 10,
+			ts:    []int64{1, 12, 31},
+			vs:    []int64{4, 7, 5},
+		},
+		{
+			name:  "last",
+			agg:   datatypes.AggregateTypeLast,
+			every: 10,
+			ts:    []int64{5, 18, 31},
+			vs:    []int64{2, 9, 5},
+		},
+		{
+			name: "last of series",
+			agg:  datatypes.AggregateTypeLast,
+			ts:   []int64{31},
+			vs:   []int64{5},
+		},
+		{
+			name: "max of series",
+			agg:  datatypes.AggregateTypeMax,
+			ts:   []int64{18},
+			vs:   []int64{9},
+		},
+		{
+			name: "mean of series",
+			agg:  datatypes.AggregateTypeMean,
+			ts:   []int64{1},
+			vs:   []float64{5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cur, err := newAggregateArrayCursor(context.Background(), &datatypes.Aggregate{Type: tt.agg}, tt.every, newIntegerArrayCursor(blocks...))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var (
+				ts []int64
+				vs interface{}
+			)
+			switch cur := cur.(type) {
+			case cursors.IntegerArrayCursor:
+				var got []int64
+				for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+					ts = append(ts, a.Timestamps...)
+					got = append(got, a.Values...)
+				}
+				vs = got
+			case cursors.FloatArrayCursor:
+				var got []float64
+				for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+					ts = append(ts, a.Timestamps...)
+					got = append(got, a.Values...)
+				}
+				vs = got
+			default:
+				t.Fatalf("unexpected cursor type %T", cur)
+			}
+
+			if !cmp.Equal(ts, tt.ts) {
+				t.Errorf("unexpected timestamps -got/+exp\n%s", cmp.Diff(ts, tt.ts))
+			}
+			if !cmp.Equal(vs, tt.vs) {
+				t.Errorf("unexpected values -got/+exp\n%s", cmp.Diff(vs, tt.vs))
+			}
+		})
+	}
+}
+
+type stringArrayCursor struct{}
+
+func (c *stringArrayCursor) Close()                     {}
+func (c *stringArrayCursor) Err() error                 { return nil }
+func (c *stringArrayCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+func (c *stringArrayCursor) Next() *cursors.StringArray { return &cursors.StringArray{} }
+
+func TestAggregateArrayCursor_UnsupportedType(t *testing.T) {
+	for _, agg := range []datatypes.Aggregate_AggregateType{
+		datatypes.AggregateTypeSum,
+		datatypes.AggregateTypeMean,
+		datatypes.AggregateTypeMin,
+		datatypes.AggregateTypeMax,
+	} {
+		for _, every := range []int64{0, 10} {
+			cur, err := newAggregateArrayCursor(context.Background(), &datatypes.Aggregate{Type: agg}, every, &stringArrayCursor{})
+			if err == nil {
+				t.Errorf("expected an error for %s every %d, got cursor %T", agg, every, cur)
+			}
+		}
+	}
+}
+
+func TestWindowCountArrayCursor_MaxPointsPerBlock(t *testing.T) {
+	// one point per window produces more windows than fit in a single block
+	n := MaxPointsPerBlock + 10
+	block := make([][2]int64, n)
+	for i := range block {
+		block[i] = [2]int64{int64(i) * 10, 1}
+	}
+
+	cur := newWindowCountArrayCursor(newIntegerArrayCursor(block), 10).(cursors.IntegerArrayCursor)
+
+	a := cur.Next()
+	if got, exp := a.Len(), MaxPointsPerBlock; got != exp {
+		t.Fatalf("unexpected length of first block; got %d, exp %d", got, exp)
+	}
+	a = cur.Next()
+	if got, exp := a.Len(), 10; got != exp {
+		t.Fatalf("unexpected length of second block; got %d, exp %d", got, exp)
+	}
+	if got, exp := a.Timestamps[0], int64(MaxPointsPerBlock)*10; got != exp {
+		t.Errorf("unexpected first timestamp of second block; got %d, exp %d", got, exp)
+	}
+	if a = cur.Next(); a.Len() != 0 {
+		t.Errorf("expected cursor to be exhausted, got %d points", a.Len())
+	}
+}
+
+func TestCursorOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		req   datatypes.ReadRequest
+		asc   bool
+		limit int64
+	}{
+		{
+			name:  "no aggregate",
+			req:   datatypes.ReadRequest{PointsLimit: 10},
+			asc:   true,
+			limit: 10,
+		},
+		{
+			name:  "first",
+			req:   datatypes.ReadRequest{Aggregate: &datatypes.Aggregate{Type: datatypes.AggregateTypeFirst}, PointsLimit: math.MaxInt64},
+			asc:   true,
+			limit: 1,
+		},
+		{
+			name:  "last",
+			req:   datatypes.ReadRequest{Aggregate: &datatypes.Aggregate{Type: datatypes.AggregateTypeLast}, PointsLimit: math.MaxInt64},
+			asc:   false,
+			limit: 1,
+		},
+		{
+			name:  "windowed last",
+			req:   datatypes.ReadRequest{Aggregate: &datatypes.Aggregate{Type: datatypes.AggregateTypeLast}, WindowEvery: 10, PointsLimit: math.MaxInt64},
+			asc:   true,
+			limit: math.MaxInt64,
+		},
+		{
+			name:  "no points",
+			req:   datatypes.ReadRequest{Aggregate: &datatypes.Aggregate{Type: datatypes.AggregateTypeLast}, PointsLimit: -1},
+			asc:   true,
+			limit: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asc, limit := cursorOrder(&tt.req)
+			if asc != tt.asc || limit != tt.limit {
+				t.Errorf("cursorOrder() = (%t, %d), want (%t, %d)", asc, limit, tt.asc, tt.limit)
+			}
+		})
+	}
+}
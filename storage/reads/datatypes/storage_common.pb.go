@@ -101,18 +101,33 @@ const (
 	AggregateTypeNone  Aggregate_AggregateType = 0
 	AggregateTypeSum   Aggregate_AggregateType = 1
 	AggregateTypeCount Aggregate_AggregateType = 2
+	AggregateTypeMin   Aggregate_AggregateType = 3
+	AggregateTypeMax   Aggregate_AggregateType = 4
+	AggregateTypeMean  Aggregate_AggregateType = 5
+	AggregateTypeFirst Aggregate_AggregateType = 6
+	AggregateTypeLast  Aggregate_AggregateType = 7
 )
 
 var Aggregate_AggregateType_name = map[int32]string{
 	0: "NONE",
 	1: "SUM",
 	2: "COUNT",
+	3: "MIN",
+	4: "MAX",
+	5: "MEAN",
+	6: "FIRST",
+	7: "LAST",
 }
 
 var Aggregate_AggregateType_value = map[string]int32{
 	"NONE":  0,
 	"SUM":   1,
 	"COUNT": 2,
+	"MIN":   3,
+	"MAX":   4,
+	"MEAN":  5,
+	"FIRST": 6,
+	"LAST":  7,
 }
 
 func (x Aggregate_AggregateType) String() string {
@@ -248,6 +263,9 @@ type ReadRequest struct {
 	// Hints is a bitwise OR of HintFlags to control the behavior
 	// of the read request.
 	Hints HintFlags `protobuf:"fixed32,12,opt,name=hints,proto3,casttype=HintFlags" json:"hints,omitempty"`
+	// WindowEvery is the duration in nanoseconds of the windows of the aggregate.
+	// Specify 0 to aggregate the whole time range.
+	WindowEvery int64 `protobuf:"varint,14,opt,name=window_every,json=windowEvery,proto3" json:"window_every,omitempty"`
 }
 
 func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
@@ -1235,114 +1253,116 @@ func init() {
 func init() { proto.RegisterFile("storage_common.proto", fileDescriptor_715e4bf4cdf1f73d) }
 
 var fileDescriptor_715e4bf4cdf1f73d = []byte{
-	// 1701 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x58, 0xcd, 0x6f, 0x23, 0x49,
-	0x15, 0x77, 0xc7, 0xdf, 0xcf, 0x1f, 0xe9, 0xd4, 0x9a, 0xe0, 0xed, 0x61, 0xed, 0x5e, 0x0b, 0x2d,
-	0x81, 0xdd, 0x75, 0x96, 0xec, 0xae, 0x18, 0x0d, 0x70, 0x88, 0x33, 0x9e, 0x38, 0x4c, 0x62, 0x47,
-	0x6d, 0x67, 0xc5, 0x22, 0x21, 0xab, 0x12, 0x57, 0x7a, 0x5b, 0xd3, 0xee, 0x6e, 0xba, 0xcb, 0xab,
-	0x58, 0xe2, 0xc2, 0x89, 0x95, 0x4f, 0x70, 0x45, 0xb2, 0x84, 0xc4, 0x91, 0x3b, 0x7f, 0xc3, 0xdc,
-	0xd8, 0x23, 0x5c, 0x2c, 0xf0, 0x48, 0x48, 0xfc, 0x05, 0x48, 0x9c, 0x50, 0x55, 0x75, 0xd9, 0xed,
-	0xc9, 0x4c, 0xc6, 0xce, 0x09, 0xed, 0xad, 0xea, 0x7d, 0xfc, 0x5e, 0xbd, 0x57, 0xef, 0xa3, 0xba,
-	0xa1, 0x14, 0x50, 0xd7, 0xc7, 0x26, 0xe9, 0x5f, 0xb9, 0xc3, 0xa1, 0xeb, 0xd4, 0x3d, 0xdf, 0xa5,
-	0x2e, 0x7a, 0x60, 0x39, 0xd7, 0xf6, 0xe8, 0x66, 0x80, 0x29, 0xae, 0x7b, 0x36, 0xa6, 0xd7, 0xae,
-	0x3f, 0xac, 0x87, 0x92, 0x5a, 0xc9, 0x74, 0x4d, 0x97, 0xcb, 0xed, 0xb3, 0x95, 0x50, 0xd1, 0x1e,
-	0x98, 0xae, 0x6b, 0xda, 0x64, 0x9f, 0xef, 0x2e, 0x47, 0xd7, 0xfb, 0x64, 0xe8, 0xd1, 0x71, 0xc8,
-	0x7c, 0xfb, 0x65, 0x26, 0x76, 0x24, 0x6b, 0xdb, 0xf3, 0xc9, 0xc0, 0xba, 0xc2, 0x94, 0x08, 0x42,
-	0xed, 0xdf, 0x0a, 0xec, 0x18, 0x04, 0x0f, 0x9e, 0x58, 0x36, 0x25, 0xbe, 0x41, 0x7e, 0x35, 0x22,
-	0x01, 0x45, 0x4d, 0xc8, 0xf9, 0x04, 0x0f, 0xfa, 0x81, 0x3b, 0xf2, 0xaf, 0x48, 0x59, 0xd1, 0x95,
-	0xbd, 0xdc, 0x41, 0xa9, 0x2e, 0x70, 0xeb, 0x12, 0xb7, 0x7e, 0xe8, 0x8c, 0x1b, 0xc5, 0xf9, 0xac,
-	0x0a, 0x0c, 0xa1, 0xcb, 0x65, 0x0d, 0xf0, 0x17, 0x6b, 0x74, 0x0c, 0x49, 0x1f, 0x3b, 0x26, 0x29,
-	0x6f, 0x71, 0x80, 0xf7, 0xeb, 0x77, 0x38, 0x5a, 0xef, 0x59, 0x43, 0x12, 0x50, 0x3c, 0xf4, 0x0c,
-	0xa6, 0xd2, 0x48, 0x3c, 0x9f, 0x55, 0x63, 0x86, 0xd0, 0x47, 0x8f, 0x21, 0xbb, 0x38, 0x78, 0x39,
-	0xce, 0xc1, 0xde, 0xbb, 0x13, 0xec, 0x5c, 0x4a, 0x1b, 0x4b, 0xc5, 0xda, 0x7f, 0x32, 0x90, 0x63,
-	0x27, 0x7d, 0x8d, 0x97, 0x85, 0x7b, 0x7a, 0x69, 0xc3, 0x36, 0x95, 0x67, 0xef, 0xdf, 0xdb, 0xdf,
-	0x5d, 0xe6, 0xef, 0x7c, 0x56, 0x2d, 0xae, 0xd2, 0x8d, 0x22, 0x5d, 0xd9, 0xa3, 0x0a, 0xc0, 0x80,
-	0x04, 0x57, 0xc4, 0x19, 0x58, 0x8e, 0xc9, 0x63, 0x91, 0x31, 0x22, 0x14, 0xf4, 0x01, 0x80, 0xe9,
-	0xbb, 0x23, 0xaf, 0xff, 0x8c, 0x8c, 0x83, 0x72, 0x42, 0x8f, 0xef, 0x65, 0x1b, 0x85, 0xf9, 0xac,
-	0x9a, 0x3d, 0x66, 0xd4, 0xa7, 0x64, 0x1c, 0x18, 0x59, 0x53, 0x2e, 0xd1, 0x63, 0x48, 0xf2, 0x4d,
-	0x39, 0xa7, 0x2b, 0x7b, 0xc5, 0x83, 0xfa, 0x9d, 0x27, 0x8e, 0xc4, 0xae, 0xce, 0xd1, 0x0c, 0xa1,
-	0xcc, 0xae, 0x07, 0x9b, 0xa6, 0x4f, 0x4c, 0x76, 0x3d, 0xd9, 0x35, 0xae, 0xe7, 0x50, 0x4a, 0x1b,
-	0x4b, 0xc5, 0xd5, 0x4b, 0x4e, 0xde, 0xf3, 0x92, 0xd1, 0x01, 0xe4, 0x03, 0xe2, 0x5b, 0x24, 0xe8,
-	0xdb, 0xd6, 0xd0, 0xa2, 0xe5, 0x94, 0xae, 0xec, 0xc5, 0x1b, 0xdb, 0xf3, 0x59, 0x35, 0xd7, 0xe5,
-	0xf4, 0x53, 0x46, 0x36, 0x72, 0xc1, 0x72, 0x83, 0x3e, 0x85, 0x42, 0xa8, 0xe3, 0x5e, 0x5f, 0x07,
-	0x84, 0x96, 0xd3, 0x5c, 0x49, 0x9d, 0xcf, 0xaa, 0x79, 0xa1, 0xd4, 0xe1, 0x74, 0x23, 0x84, 0x16,
-	0x3b, 0x66, 0xca, 0x73, 0x2d, 0x87, 0x4a, 0x53, 0x99, 0xa5, 0xa9, 0x73, 0x4e, 0x0f, 0x4d, 0x79,
-	0xcb, 0x0d, 0xea, 0x41, 0x92, 0xfa, 0xf8, 0x8a, 0x94, 0x41, 0x8f, 0xef, 0xe5, 0x0e, 0x3e, 0x5e,
-	0x3b, 0xe0, 0x3d, 0xa6, 0xd5, 0x74, 0xa8, 0x3f, 0x6e, 0x64, 0xe7, 0xb3, 0x6a, 0x92, 0xef, 0x0d,
-	0x01, 0x86, 0x3e, 0x80, 0xe4, 0x17, 0xcc, 0x46, 0x39, 0xaf, 0x2b, 0x7b, 0xe9, 0xc6, 0x2e, 0x13,
-	0x68, 0x31, 0xc2, 0x7f, 0x67, 0xd5, 0x2c, 0x5b, 0x3c, 0xb1, 0xb1, 0x19, 0x18, 0x42, 0x48, 0x7b,
-	0x08, 0xb0, 0x44, 0x43, 0x2a, 0xc4, 0x9f, 0x91, 0x31, 0xaf, 0xf1, 0xac, 0xc1, 0x96, 0xa8, 0x04,
-	0xc9, 0x2f, 0xb1, 0x3d, 0x12, 0x69, 0x9c, 0x35, 0xc4, 0xe6, 0xd1, 0xd6, 0x43, 0xa5, 0xf6, 0x5b,
-	0x05, 0x92, 0xfc, 0xe6, 0xd1, 0x3b, 0x00, 0xc7, 0x46, 0xe7, 0xe2, 0xbc, 0xdf, 0xee, 0xb4, 0x9b,
-	0x6a, 0x4c, 0x2b, 0x4c, 0xa6, 0xba, 0x48, 0xb1, 0xb6, 0xeb, 0x10, 0xf4, 0x00, 0xb2, 0x82, 0x7d,
-	0x78, 0x7a, 0xaa, 0x2a, 0x5a, 0x7e, 0x32, 0xd5, 0x33, 0x9c, 0x7b, 0x68, 0xdb, 0xe8, 0x6d, 0xc8,
-	0x08, 0x66, 0xe3, 0x73, 0x75, 0x4b, 0xcb, 0x4d, 0xa6, 0x7a, 0x9a, 0xf3, 0x1a, 0x63, 0xf4, 0x2e,
-	0xe4, 0x05, 0xab, 0xf9, 0xf3, 0xa3, 0xe6, 0x79, 0x4f, 0x8d, 0x6b, 0xdb, 0x93, 0xa9, 0x9e, 0xe3,
-	0xec, 0xe6, 0xcd, 0x15, 0xf1, 0xa8, 0x96, 0xf8, 0xea, 0x4f, 0x95, 0x58, 0xed, 0xcf, 0x0a, 0x2c,
-	0x1d, 0x63, 0xe6, 0x5a, 0x27, 0xed, 0x9e, 0x3c, 0x0c, 0x37, 0xc7, 0xb8, 0xfc, 0x2c, 0xdf, 0x85,
-	0x62, 0xc8, 0xec, 0x9f, 0x77, 0x4e, 0xda, 0xbd, 0xae, 0xaa, 0x68, 0xea, 0x64, 0xaa, 0xe7, 0x85,
-	0x84, 0xb8, 0xaa, 0xa8, 0x54, 0xb7, 0x69, 0x9c, 0x34, 0xbb, 0xea, 0x56, 0x54, 0x4a, 0xa4, 0x01,
-	0xda, 0x87, 0x12, 0x97, 0xea, 0x1e, 0xb5, 0x9a, 0x67, 0x87, 0xcc, 0xbb, 0x7e, 0xef, 0xe4, 0xac,
-	0xa9, 0x26, 0xb4, 0x6f, 0x4d, 0xa6, 0xfa, 0x0e, 0x93, 0xed, 0x5e, 0x7d, 0x41, 0x86, 0xf8, 0xd0,
-	0xb6, 0x59, 0x21, 0x87, 0xa7, 0xfd, 0xab, 0x02, 0xd9, 0x45, 0xce, 0xa3, 0x16, 0x24, 0xe8, 0xd8,
-	0x13, 0x6d, 0xb5, 0x78, 0xf0, 0xc9, 0x7a, 0x95, 0xb2, 0x5c, 0xf5, 0xc6, 0x1e, 0x31, 0x38, 0x42,
-	0xed, 0x06, 0x0a, 0x2b, 0x64, 0x54, 0x85, 0x44, 0x18, 0x03, 0x7e, 0x9e, 0x15, 0x26, 0x0f, 0xc6,
-	0x3b, 0x10, 0xef, 0x5e, 0x9c, 0xa9, 0x8a, 0x56, 0x9a, 0x4c, 0x75, 0x75, 0x85, 0xdf, 0x1d, 0x0d,
-	0xd1, 0xbb, 0x90, 0x3c, 0xea, 0x5c, 0xb4, 0x7b, 0xea, 0x96, 0xb6, 0x3b, 0x99, 0xea, 0x68, 0x45,
-	0xe0, 0xc8, 0x1d, 0x39, 0x32, 0xfe, 0x1f, 0x42, 0xbc, 0x87, 0xcd, 0x68, 0xf2, 0xe4, 0x5f, 0x91,
-	0x3c, 0xf9, 0x30, 0x79, 0x6a, 0xbf, 0x2f, 0x42, 0x5e, 0x64, 0x73, 0xe0, 0xb9, 0x4e, 0x40, 0xd0,
-	0x19, 0xa4, 0xae, 0x7d, 0x3c, 0x24, 0x41, 0x59, 0xe1, 0x85, 0xb0, 0xbf, 0x46, 0x21, 0x08, 0xd5,
-	0xfa, 0x13, 0xa6, 0x17, 0xce, 0x87, 0x10, 0x44, 0xfb, 0x2a, 0x05, 0x49, 0x4e, 0x47, 0xa7, 0xb2,
-	0xa3, 0xa5, 0x79, 0x07, 0xf9, 0x64, 0x7d, 0x5c, 0x9e, 0x64, 0x1c, 0xa4, 0x15, 0x93, 0x9d, 0xad,
-	0x03, 0x29, 0x51, 0xf2, 0xe1, 0x0c, 0xfc, 0x74, 0x7d, 0x38, 0x91, 0x31, 0x12, 0x2f, 0x84, 0x41,
-	0x1e, 0xe4, 0xaf, 0x6d, 0x17, 0xd3, 0xbe, 0x68, 0x0a, 0xe1, 0xa4, 0x78, 0xb4, 0x81, 0xf7, 0x4c,
-	0x5b, 0xe4, 0xac, 0x08, 0x04, 0xef, 0x37, 0x11, 0x6a, 0x2b, 0x66, 0xe4, 0xae, 0x97, 0x5b, 0x74,
-	0x03, 0x45, 0xcb, 0xa1, 0xc4, 0x24, 0xbe, 0xb4, 0x29, 0x06, 0xe8, 0x4f, 0xd6, 0xb7, 0x79, 0x22,
-	0xf4, 0xa3, 0x56, 0x77, 0xe6, 0xb3, 0x6a, 0x61, 0x85, 0xde, 0x8a, 0x19, 0x05, 0x2b, 0x4a, 0x40,
-	0xbf, 0x86, 0xed, 0x91, 0x13, 0x58, 0xa6, 0x43, 0x06, 0xd2, 0x74, 0x82, 0x9b, 0xfe, 0xe9, 0xfa,
-	0xa6, 0x2f, 0x42, 0x80, 0xa8, 0x6d, 0xc4, 0xc6, 0xe4, 0x2a, 0xa3, 0x15, 0x33, 0x8a, 0xa3, 0x15,
-	0x0a, 0xf3, 0xfb, 0xd2, 0x75, 0x6d, 0x82, 0x1d, 0x69, 0x3c, 0xb9, 0xa9, 0xdf, 0x0d, 0xa1, 0x7f,
-	0xcb, 0xef, 0x15, 0x3a, 0xf3, 0xfb, 0x32, 0x4a, 0x40, 0x14, 0x0a, 0x01, 0xf5, 0x2d, 0xc7, 0x94,
-	0x86, 0x53, 0xdc, 0xf0, 0x8f, 0x37, 0xc8, 0x1d, 0xae, 0x1e, 0xb5, 0x2b, 0x66, 0x51, 0x84, 0xdc,
-	0x8a, 0x19, 0xf9, 0x20, 0xb2, 0x6f, 0xa4, 0x20, 0xc1, 0x90, 0xb5, 0x1b, 0x80, 0x65, 0x26, 0xa3,
-	0xf7, 0x20, 0x43, 0xb1, 0x29, 0x1e, 0x03, 0xac, 0xd2, 0xf2, 0x8d, 0xdc, 0x7c, 0x56, 0x4d, 0xf7,
-	0xb0, 0xc9, 0x9f, 0x02, 0x69, 0x2a, 0x16, 0xa8, 0x01, 0xc8, 0xc3, 0x3e, 0xb5, 0xa8, 0xe5, 0x3a,
-	0x4c, 0xba, 0xff, 0x25, 0xb6, 0x59, 0x76, 0x32, 0x8d, 0xd2, 0x7c, 0x56, 0x55, 0xcf, 0x25, 0xf7,
-	0x29, 0x19, 0x7f, 0x86, 0xed, 0xc0, 0x50, 0xbd, 0x97, 0x28, 0xda, 0x1f, 0x14, 0xc8, 0x45, 0xb2,
-	0x1e, 0x3d, 0x82, 0x04, 0xc5, 0xa6, 0xac, 0x70, 0xfd, 0xee, 0xd7, 0x10, 0x36, 0xc3, 0x92, 0xe6,
-	0x3a, 0xa8, 0x03, 0x59, 0x26, 0xd8, 0xe7, 0x8d, 0x72, 0x8b, 0x37, 0xca, 0x83, 0xf5, 0xe3, 0xf7,
-	0x18, 0x53, 0xcc, 0xdb, 0x64, 0x66, 0x10, 0xae, 0xb4, 0x9f, 0x81, 0xfa, 0x72, 0xe9, 0xb0, 0xb7,
-	0xd4, 0xe2, 0x75, 0x25, 0x8e, 0xa9, 0x1a, 0x11, 0x0a, 0xda, 0x85, 0x14, 0x6f, 0x5f, 0x22, 0x10,
-	0x8a, 0x11, 0xee, 0xb4, 0x53, 0x40, 0xb7, 0x4b, 0x62, 0x43, 0xb4, 0xf8, 0x02, 0xed, 0x0c, 0xde,
-	0x7a, 0x45, 0x96, 0x6f, 0x08, 0x97, 0x88, 0x1e, 0xee, 0x76, 0xde, 0x6e, 0x88, 0x96, 0x59, 0xa0,
-	0x3d, 0x85, 0x9d, 0x5b, 0xc9, 0xb8, 0x21, 0x58, 0x56, 0x82, 0xd5, 0xba, 0x90, 0xe5, 0x00, 0xe1,
-	0xa8, 0x4a, 0x85, 0x83, 0x36, 0xa6, 0xbd, 0x35, 0x99, 0xea, 0xdb, 0x0b, 0x56, 0x38, 0x6b, 0xab,
-	0x90, 0x5a, 0xcc, 0xeb, 0x55, 0x01, 0x71, 0x96, 0x70, 0x12, 0xfd, 0x45, 0x81, 0x8c, 0xbc, 0x6f,
-	0xf4, 0x1d, 0x48, 0x3e, 0x39, 0xed, 0x1c, 0xf6, 0xd4, 0x98, 0xb6, 0x33, 0x99, 0xea, 0x05, 0xc9,
-	0xe0, 0x57, 0x8f, 0x74, 0x48, 0x9f, 0xb4, 0x7b, 0xcd, 0xe3, 0xa6, 0x21, 0x21, 0x25, 0x3f, 0xbc,
-	0x4e, 0x54, 0x83, 0xcc, 0x45, 0xbb, 0x7b, 0x72, 0xdc, 0x6e, 0x3e, 0x56, 0xb7, 0xc4, 0x8c, 0x94,
-	0x22, 0xf2, 0x8e, 0x18, 0x4a, 0xa3, 0xd3, 0x39, 0x6d, 0x1e, 0xb6, 0xd5, 0xf8, 0x2a, 0x4a, 0x18,
-	0x77, 0x54, 0x81, 0x54, 0xb7, 0x67, 0x9c, 0xb4, 0x8f, 0xd5, 0x84, 0x86, 0x26, 0x53, 0xbd, 0x28,
-	0x05, 0x44, 0x28, 0xc3, 0x83, 0xff, 0x51, 0x81, 0xd2, 0x11, 0xf6, 0xf0, 0xa5, 0x65, 0x5b, 0xd4,
-	0x22, 0xc1, 0x62, 0x36, 0x76, 0x20, 0x71, 0x85, 0x3d, 0x59, 0x37, 0x77, 0xb7, 0x8d, 0x57, 0x01,
-	0x30, 0x62, 0xc0, 0x1f, 0x77, 0x06, 0x07, 0xd2, 0x7e, 0x04, 0xd9, 0x05, 0x69, 0xa3, 0xf7, 0xde,
-	0x36, 0x14, 0xf8, 0x33, 0x52, 0x22, 0xd7, 0x1e, 0xc2, 0x4b, 0xdf, 0x27, 0x4c, 0x39, 0xa0, 0xd8,
-	0xa7, 0x1c, 0x30, 0x6e, 0x88, 0x0d, 0x33, 0x42, 0x9c, 0x01, 0x07, 0x8c, 0x1b, 0x6c, 0x59, 0xfb,
-	0x97, 0x02, 0x45, 0xd9, 0x75, 0x96, 0xdf, 0x5f, 0xac, 0xd6, 0xd7, 0xfe, 0xca, 0xec, 0x61, 0x33,
-	0x90, 0xdf, 0x5f, 0x74, 0xb1, 0xfe, 0x7f, 0xfb, 0xca, 0xfc, 0xcd, 0x16, 0xa8, 0x3d, 0x6c, 0x7e,
-	0xc6, 0x53, 0xfe, 0x1b, 0xed, 0x2a, 0xfa, 0x36, 0xa4, 0xc3, 0xe1, 0xc2, 0x07, 0x7b, 0xd6, 0x48,
-	0x89, 0x71, 0x52, 0xab, 0x43, 0x49, 0xa4, 0xba, 0x8c, 0x42, 0x98, 0xd9, 0xcb, 0xc6, 0xc0, 0x67,
-	0x91, 0x6c, 0x0c, 0x07, 0x7f, 0x4f, 0x40, 0xba, 0x2b, 0x2c, 0xa1, 0x5f, 0x42, 0x82, 0xf5, 0x72,
-	0xb4, 0xb7, 0xee, 0xa7, 0x91, 0xf6, 0xfd, 0xb5, 0x07, 0xc3, 0x47, 0x0a, 0xb2, 0x00, 0x96, 0xff,
-	0x3b, 0xd0, 0x9b, 0x3f, 0x78, 0x57, 0x7e, 0x8c, 0x6c, 0x66, 0xea, 0x73, 0xc8, 0x47, 0xcb, 0x13,
-	0xed, 0xde, 0xba, 0xef, 0xe6, 0xd0, 0xa3, 0x63, 0xed, 0x87, 0x1b, 0x57, 0x38, 0x7a, 0x0a, 0xe2,
-	0xfb, 0xee, 0xb5, 0x98, 0x3f, 0xb8, 0x13, 0x73, 0xa5, 0xa8, 0xd1, 0x33, 0x90, 0xef, 0x01, 0xf4,
-	0xfe, 0x9b, 0x86, 0x74, 0xa4, 0x7e, 0xdf, 0x70, 0xee, 0x57, 0x25, 0xc0, 0x47, 0x0a, 0x72, 0x21,
-	0xbb, 0xa8, 0x0e, 0xf4, 0xe1, 0x9b, 0xcc, 0xad, 0x54, 0xd1, 0xbd, 0x0c, 0x36, 0xbe, 0xf7, 0xfc,
-	0x9f, 0x95, 0xd8, 0xf3, 0x79, 0x45, 0xf9, 0x7a, 0x5e, 0x51, 0xfe, 0x31, 0xaf, 0x28, 0xbf, 0x7b,
-	0x51, 0x89, 0x7d, 0xfd, 0xa2, 0x12, 0xfb, 0xdb, 0x8b, 0x4a, 0xec, 0x17, 0xfc, 0x95, 0xc1, 0x1e,
-	0x19, 0xc1, 0x65, 0x8a, 0x87, 0xf0, 0xe3, 0xff, 0x05, 0x00, 0x00, 0xff, 0xff, 0x3f, 0x26, 0xfa,
-	0x7c, 0xa5, 0x13, 0x00, 0x00,
+	// 1738 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdd, 0x58, 0xcd, 0x6f, 0x1b, 0x45,
+	0x14, 0x8f, 0xe3, 0xef, 0xe7, 0x8f, 0x6c, 0xa6, 0x26, 0xb8, 0x5b, 0x9a, 0x06, 0x0b, 0x95, 0x42,
+	0xa9, 0x03, 0x69, 0x2b, 0xaa, 0x02, 0x07, 0x3b, 0x75, 0x12, 0x53, 0x7f, 0x44, 0x6b, 0xa7, 0xb4,
+	0x48, 0xc8, 0xda, 0xc4, 0xeb, 0xed, 0xaa, 0xf6, 0xee, 0xb2, 0xbb, 0x6e, 0x13, 0x89, 0x0b, 0x27,
+	0x2a, 0x4e, 0x70, 0x05, 0x21, 0x21, 0x71, 0xec, 0x9d, 0xbf, 0xa1, 0xc7, 0x1e, 0xe1, 0x52, 0x41,
+	0x91, 0x90, 0x38, 0x73, 0xe3, 0xc4, 0x9b, 0x99, 0x5d, 0x7b, 0x37, 0x71, 0x13, 0x3b, 0x27, 0xc4,
+	0xc1, 0xca, 0xcc, 0xfb, 0xf8, 0xbd, 0x37, 0x6f, 0xde, 0xc7, 0x6c, 0x20, 0x67, 0x3b, 0x86, 0x25,
+	0xab, 0x4a, 0x67, 0xcf, 0x18, 0x0c, 0x0c, 0xbd, 0x68, 0x5a, 0x86, 0x63, 0x90, 0x73, 0x9a, 0xde,
+	0xeb, 0x0f, 0xf7, 0xbb, 0xb2, 0x23, 0x17, 0xcd, 0xbe, 0xec, 0xf4, 0x0c, 0x6b, 0x50, 0x74, 0x25,
+	0xc5, 0x9c, 0x6a, 0xa8, 0x06, 0x93, 0x5b, 0xa5, 0x2b, 0xae, 0x22, 0x9e, 0x53, 0x0d, 0x43, 0xed,
+	0x2b, 0xab, 0x6c, 0xb7, 0x3b, 0xec, 0xad, 0x2a, 0x03, 0xd3, 0x39, 0x70, 0x99, 0x67, 0x0f, 0x33,
+	0x65, 0xdd, 0x63, 0x2d, 0x98, 0x96, 0xd2, 0xd5, 0xf6, 0x64, 0x47, 0xe1, 0x84, 0xc2, 0x5f, 0x21,
+	0x58, 0x94, 0x14, 0xb9, 0xbb, 0xa1, 0xf5, 0x1d, 0xc5, 0x92, 0x94, 0xcf, 0x87, 0x8a, 0xed, 0x90,
+	0x0a, 0xa4, 0x2c, 0x24, 0x76, 0x6c, 0x63, 0x68, 0xed, 0x29, 0xf9, 0xd0, 0x4a, 0xe8, 0x52, 0x6a,
+	0x2d, 0x57, 0xe4, 0xb8, 0x45, 0x0f, 0xb7, 0x58, 0xd2, 0x0f, 0xca, 0xd9, 0x17, 0xcf, 0x2f, 0x00,
+	0x45, 0x68, 0x31, 0x59, 0x09, 0xac, 0xd1, 0x9a, 0x6c, 0x42, 0xd4, 0x92, 0x75, 0x55, 0xc9, 0xcf,
+	0x33, 0x80, 0xcb, 0xc5, 0x63, 0x0e, 0x5a, 0x6c, 0x6b, 0x03, 0x34, 0x2d, 0x0f, 0x4c, 0x89, 0xaa,
+	0x94, 0x23, 0x4f, 0x9f, 0x5f, 0x98, 0x93, 0xb8, 0x3e, 0xb9, 0x05, 0xc9, 0x91, 0xe3, 0xf9, 0x30,
+	0x03, 0xbb, 0x78, 0x2c, 0xd8, 0xb6, 0x27, 0x2d, 0x8d, 0x15, 0x0b, 0x4f, 0x92, 0x90, 0xa2, 0x9e,
+	0xbe, 0xe4, 0x94, 0x99, 0x53, 0x9e, 0xb2, 0x0f, 0x0b, 0x8e, 0xe7, 0x7b, 0xe7, 0xd4, 0xe7, 0x5d,
+	0xa2, 0xe7, 0x45, 0x2b, 0xd9, 0x20, 0x5d, 0xca, 0x3a, 0x81, 0x3d, 0x59, 0x06, 0xe8, 0x2a, 0xf6,
+	0x9e, 0xa2, 0x77, 0x35, 0x5d, 0x65, 0xb1, 0x48, 0x48, 0x3e, 0x0a, 0x79, 0x07, 0x40, 0xb5, 0x8c,
+	0xa1, 0xd9, 0x79, 0xa0, 0x1c, 0xd8, 0xf9, 0xc8, 0x4a, 0xf8, 0x52, 0xb2, 0x9c, 0x41, 0xdc, 0xe4,
+	0x26, 0xa5, 0xde, 0x46, 0xa2, 0x94, 0x54, 0xbd, 0x25, 0x06, 0x36, 0xca, 0x36, 0xf9, 0x14, 0x02,
+	0x65, 0xd7, 0x8a, 0xc7, 0x7a, 0xec, 0x8b, 0x5d, 0x91, 0xa1, 0x49, 0x5c, 0x99, 0x5e, 0x8f, 0xac,
+	0xaa, 0x96, 0xa2, 0xd2, 0xeb, 0x49, 0x4e, 0x71, 0x3d, 0x25, 0x4f, 0x5a, 0x1a, 0x2b, 0x06, 0x2f,
+	0x39, 0x7a, 0xca, 0x4b, 0x26, 0x6b, 0x90, 0xb6, 0x15, 0x4b, 0x53, 0xec, 0x4e, 0x5f, 0x1b, 0x68,
+	0x4e, 0x3e, 0x86, 0x40, 0xe1, 0xf2, 0x02, 0x46, 0x20, 0xd5, 0x62, 0xf4, 0x1a, 0x25, 0x4b, 0x29,
+	0x7b, 0xbc, 0x21, 0xd7, 0x21, 0xe3, 0xea, 0x18, 0xbd, 0x9e, 0xad, 0x38, 0xf9, 0x38, 0x53, 0x12,
+	0x50, 0x29, 0xcd, 0x95, 0x9a, 0x8c, 0x2e, 0xb9, 0xd0, 0x7c, 0x47, 0x4d, 0x99, 0x86, 0xa6, 0x3b,
+	0x9e, 0xa9, 0xc4, 0xd8, 0xd4, 0x36, 0xa3, 0xbb, 0xa6, 0xcc, 0xf1, 0x86, 0xb4, 0x21, 0xea, 0x58,
+	0x32, 0x66, 0x1b, 0xe0, 0xcd, 0xa4, 0xd6, 0xae, 0x4e, 0x1d, 0xf0, 0x36, 0xd5, 0xaa, 0xe8, 0x8e,
+	0x75, 0x50, 0x4e, 0xa2, 0x85, 0x28, 0xdb, 0x4b, 0x1c, 0x0c, 0x2f, 0x3d, 0x7a, 0x9f, 0xda, 0xc8,
+	0xa7, 0xd1, 0x85, 0x78, 0x79, 0x89, 0x0a, 0x6c, 0x51, 0xc2, 0x3f, 0x78, 0xf1, 0x74, 0xb1, 0xd1,
+	0x97, 0x55, 0x5b, 0xe2, 0x42, 0xd4, 0xef, 0x47, 0x9a, 0xde, 0x35, 0x1e, 0x75, 0x94, 0x87, 0x8a,
+	0x75, 0x90, 0xcf, 0x8e, 0xfd, 0xfe, 0x84, 0xd1, 0x2b, 0x94, 0x2c, 0xa5, 0x1e, 0x8d, 0x37, 0xe2,
+	0x0d, 0x80, 0xb1, 0x07, 0x44, 0x80, 0x30, 0xa6, 0x17, 0xeb, 0x0b, 0x49, 0x89, 0x2e, 0x49, 0x0e,
+	0xa2, 0x0f, 0xe5, 0xfe, 0x90, 0xa7, 0x7e, 0x52, 0xe2, 0x9b, 0x9b, 0xf3, 0x37, 0x42, 0x85, 0xaf,
+	0x42, 0x10, 0x65, 0xd9, 0x42, 0xce, 0x03, 0x6c, 0x4a, 0xcd, 0x9d, 0xed, 0x4e, 0xa3, 0xd9, 0xa8,
+	0x08, 0x73, 0x62, 0xe6, 0xeb, 0x1f, 0x56, 0x78, 0x5a, 0x36, 0x0c, 0x5d, 0x21, 0xe7, 0x20, 0xc9,
+	0xd9, 0xa5, 0x5a, 0x4d, 0x08, 0x89, 0x69, 0xe4, 0x26, 0x18, 0xb7, 0xd4, 0xef, 0x93, 0xb3, 0x90,
+	0xe0, 0xcc, 0xf2, 0x3d, 0x61, 0x5e, 0x4c, 0x21, 0x2f, 0xce, 0x78, 0xe5, 0x03, 0xf2, 0x3a, 0xa4,
+	0x39, 0xab, 0x72, 0x77, 0xbd, 0xb2, 0xdd, 0x16, 0xc2, 0xe2, 0x02, 0xb2, 0x53, 0x8c, 0x5d, 0xd9,
+	0xdf, 0x53, 0x4c, 0x47, 0x8c, 0x3c, 0xfe, 0x69, 0x79, 0xae, 0xf0, 0x24, 0x04, 0xe3, 0x60, 0x50,
+	0x73, 0x5b, 0xd5, 0x46, 0xdb, 0x73, 0x86, 0x99, 0xa3, 0x5c, 0xe6, 0xcb, 0x1b, 0x90, 0x75, 0x99,
+	0x9d, 0xed, 0x26, 0x2e, 0x5a, 0xe8, 0x90, 0x80, 0x12, 0x69, 0x2e, 0xc1, 0xaf, 0xd7, 0x2f, 0xd5,
+	0xaa, 0x48, 0xd5, 0x4a, 0x0b, 0x5d, 0xf3, 0x49, 0xf1, 0xd4, 0x21, 0xab, 0x90, 0x63, 0x52, 0xad,
+	0xf5, 0xad, 0x4a, 0xbd, 0x44, 0x4f, 0xd7, 0x69, 0x57, 0xeb, 0x15, 0x21, 0x22, 0xbe, 0x82, 0xb2,
+	0x8b, 0x54, 0xb6, 0xb5, 0x77, 0x5f, 0x19, 0xc8, 0x78, 0x4e, 0x5a, 0xfc, 0xae, 0xb7, 0x7f, 0xcf,
+	0x43, 0x72, 0x54, 0x27, 0x64, 0x0b, 0x22, 0xce, 0x81, 0xc9, 0x5b, 0x71, 0x76, 0xed, 0xda, 0x74,
+	0xd5, 0x35, 0x5e, 0xb5, 0x51, 0x57, 0x62, 0x08, 0x85, 0xef, 0xe7, 0x21, 0x13, 0xa0, 0x93, 0x0b,
+	0x10, 0x71, 0x83, 0xc0, 0x1c, 0x0a, 0x30, 0x59, 0x34, 0xce, 0x43, 0xb8, 0xb5, 0x53, 0xc7, 0x10,
+	0xe4, 0x90, 0x2f, 0x04, 0xf8, 0xad, 0xe1, 0x00, 0x2f, 0x20, 0xba, 0xde, 0xdc, 0x69, 0xb4, 0xf1,
+	0xf4, 0x4b, 0x28, 0x40, 0x02, 0x02, 0xeb, 0xc6, 0x50, 0x77, 0x28, 0x42, 0xbd, 0xda, 0xc0, 0xab,
+	0x39, 0x8a, 0x50, 0xd7, 0x74, 0xc6, 0x2e, 0xdd, 0xc5, 0x88, 0x4c, 0x60, 0xcb, 0xfb, 0xd4, 0xc1,
+	0x7a, 0xa5, 0xd4, 0x10, 0xa2, 0x13, 0x1c, 0xac, 0x2b, 0xb2, 0x4e, 0x3d, 0xd8, 0xa8, 0x4a, 0xad,
+	0xb6, 0x10, 0x9b, 0xe0, 0xc1, 0x86, 0x66, 0x61, 0xb3, 0x47, 0x8c, 0x5a, 0x09, 0x25, 0xe2, 0x13,
+	0x30, 0x6a, 0xb2, 0xed, 0xe5, 0xc8, 0x15, 0x08, 0xb7, 0x65, 0xd5, 0x9f, 0xe0, 0xe9, 0x09, 0x09,
+	0x9e, 0x76, 0x13, 0xbc, 0xf0, 0x6d, 0x16, 0xd2, 0xbc, 0x4a, 0x6d, 0xd3, 0xd0, 0x6d, 0x85, 0xd4,
+	0x21, 0xd6, 0xb3, 0x64, 0xec, 0xd8, 0xa8, 0x4b, 0x0b, 0x7c, 0x75, 0x8a, 0x02, 0xe7, 0xaa, 0xc5,
+	0x0d, 0xaa, 0xe7, 0xce, 0x3d, 0x17, 0x44, 0x7c, 0x1c, 0xc3, 0x93, 0xd1, 0x25, 0xa9, 0x79, 0x9d,
+	0x3a, 0xce, 0x3a, 0xe3, 0xb5, 0xe9, 0x71, 0x59, 0x21, 0x30, 0x90, 0xad, 0x39, 0xaf, 0x63, 0x37,
+	0x21, 0xc6, 0x5b, 0x99, 0x3b, 0xdb, 0xaf, 0x4f, 0x0f, 0xc7, 0xb3, 0xda, 0xc3, 0x73, 0x61, 0x88,
+	0x09, 0xe9, 0x5e, 0xdf, 0x90, 0x9d, 0x0e, 0x6f, 0x76, 0xee, 0x04, 0xbc, 0x39, 0xc3, 0xe9, 0xa9,
+	0x36, 0xaf, 0x2b, 0x1e, 0x08, 0xd6, 0x8f, 0x7c, 0x54, 0x34, 0x96, 0xea, 0x8d, 0xb7, 0x64, 0x1f,
+	0xb2, 0xf8, 0x57, 0x51, 0x15, 0xcb, 0xb3, 0xc9, 0x1f, 0x06, 0x1f, 0x4e, 0x6f, 0xb3, 0xca, 0xf5,
+	0xfd, 0x56, 0x17, 0xd1, 0x6a, 0x26, 0x40, 0x47, 0xbb, 0x19, 0xcd, 0x4f, 0x20, 0x5f, 0xc0, 0xc2,
+	0x50, 0xb7, 0x35, 0x55, 0x57, 0xba, 0x9e, 0xe9, 0x08, 0x33, 0xfd, 0xd1, 0xf4, 0xa6, 0x77, 0x5c,
+	0x00, 0xbf, 0x6d, 0x42, 0xc7, 0x7f, 0x90, 0x81, 0xc6, 0xb3, 0xc3, 0x00, 0x85, 0x9e, 0x7b, 0xd7,
+	0x30, 0xfa, 0x98, 0xf6, 0x9e, 0xf1, 0xe8, 0xac, 0xe7, 0x2e, 0x73, 0xfd, 0x23, 0xe7, 0x0e, 0xd0,
+	0xe9, 0xb9, 0x77, 0xfd, 0x04, 0xe2, 0xe0, 0x98, 0x74, 0x2c, 0x7c, 0x64, 0x78, 0x86, 0x63, 0xcc,
+	0xf0, 0x07, 0x33, 0xe4, 0x0e, 0x53, 0xf7, 0xdb, 0xe5, 0x33, 0xd6, 0x47, 0x46, 0xb3, 0x69, 0xdb,
+	0xb7, 0x2f, 0xc7, 0x20, 0x42, 0x91, 0xc5, 0x7d, 0x9c, 0x1e, 0xa3, 0x4c, 0x26, 0x17, 0x21, 0xe1,
+	0xc8, 0x2a, 0x7f, 0xe4, 0xd0, 0x4a, 0x4b, 0x97, 0x53, 0x88, 0x14, 0xc7, 0xda, 0x65, 0x4f, 0x9c,
+	0xb8, 0xc3, 0x17, 0xa4, 0x0c, 0xc4, 0x94, 0x2d, 0x47, 0x73, 0x34, 0x43, 0xa7, 0xd2, 0x1d, 0xac,
+	0x5b, 0x9a, 0x9d, 0x54, 0x23, 0x87, 0x1a, 0xc2, 0xb6, 0xc7, 0x45, 0xf1, 0x3b, 0xc8, 0x93, 0x04,
+	0xf3, 0x10, 0x45, 0xfc, 0x2e, 0x04, 0x29, 0x5f, 0xd6, 0x93, 0x9b, 0xd8, 0x8b, 0x71, 0x82, 0xb8,
+	0x15, 0xbe, 0x72, 0xfc, 0x2b, 0x4f, 0x56, 0xdd, 0x92, 0x66, 0x3a, 0x58, 0x78, 0x49, 0x2a, 0xd8,
+	0x61, 0xcd, 0x7c, 0x9e, 0x35, 0xf3, 0xb5, 0xe9, 0xe3, 0x77, 0x0b, 0x45, 0x58, 0x2b, 0x4f, 0x74,
+	0xdd, 0x95, 0xf8, 0x31, 0x08, 0x87, 0x4b, 0x87, 0xbe, 0x11, 0x47, 0xaf, 0x46, 0xee, 0xa6, 0x20,
+	0xf9, 0x28, 0x64, 0x09, 0x62, 0xac, 0x7d, 0xf1, 0x40, 0x84, 0x24, 0x77, 0x27, 0xd6, 0x80, 0x1c,
+	0x2d, 0x89, 0x19, 0xd1, 0xc2, 0x23, 0xb4, 0x3a, 0x9c, 0x99, 0x90, 0xe5, 0x33, 0xc2, 0x45, 0xfc,
+	0xce, 0x1d, 0xcd, 0xdb, 0x19, 0xd1, 0x12, 0x23, 0xb4, 0xdb, 0xb0, 0x78, 0x24, 0x19, 0x67, 0x04,
+	0x4b, 0x7a, 0x60, 0x85, 0x16, 0x24, 0x19, 0x80, 0x3b, 0x4d, 0x63, 0xee, 0x63, 0x60, 0x4e, 0x3c,
+	0x83, 0xa3, 0x66, 0x61, 0xc4, 0x72, 0xdf, 0x03, 0x28, 0x30, 0x7a, 0x53, 0x04, 0x05, 0xb8, 0x2f,
+	0xee, 0x24, 0xfa, 0x39, 0x04, 0x09, 0xef, 0xbe, 0xc9, 0x6b, 0x38, 0x06, 0x6a, 0xcd, 0x52, 0x1b,
+	0x31, 0x17, 0x51, 0x25, 0xe3, 0x31, 0xd8, 0xd5, 0x93, 0x15, 0x88, 0x23, 0x5e, 0x65, 0xb3, 0x22,
+	0x79, 0x90, 0x1e, 0xdf, 0xbd, 0x4e, 0x52, 0x80, 0xc4, 0x4e, 0xa3, 0x55, 0xdd, 0x6c, 0x54, 0x6e,
+	0xe1, 0x94, 0x66, 0x53, 0xd6, 0x13, 0xf1, 0xee, 0x88, 0xa2, 0x94, 0x9b, 0xcd, 0x1a, 0x1d, 0xb4,
+	0xe1, 0x20, 0x8a, 0x1b, 0x77, 0x8c, 0x4f, 0xac, 0xd5, 0x96, 0xaa, 0x8d, 0x4d, 0x9c, 0xd4, 0x04,
+	0x05, 0xb2, 0x9e, 0x00, 0x0f, 0xa5, 0xeb, 0xf8, 0x8f, 0x21, 0xc8, 0xad, 0xcb, 0xa6, 0xbc, 0xab,
+	0xf5, 0xb1, 0x8c, 0x14, 0x7b, 0x34, 0x1b, 0x9b, 0x10, 0xd9, 0x93, 0x4d, 0xaf, 0x6e, 0x8e, 0x6f,
+	0x1b, 0x93, 0x00, 0x28, 0xd1, 0x66, 0x0f, 0x50, 0x89, 0x01, 0x89, 0xef, 0x43, 0x72, 0x44, 0x9a,
+	0xe9, 0x4d, 0xba, 0x00, 0x19, 0xf6, 0x3c, 0xf6, 0x90, 0x0b, 0x37, 0xe0, 0xd0, 0x77, 0x17, 0x55,
+	0xc6, 0x9d, 0xe5, 0x30, 0xc0, 0xb0, 0xc4, 0x37, 0xd4, 0x08, 0x7e, 0x67, 0x31, 0xc0, 0xb0, 0x44,
+	0x97, 0x85, 0x3f, 0x43, 0xa8, 0xea, 0x76, 0x9d, 0xf1, 0x77, 0x25, 0xad, 0xf5, 0xa9, 0xbf, 0x9e,
+	0x51, 0xdd, 0xf6, 0xbe, 0x2b, 0x9d, 0xd1, 0xfa, 0xbf, 0xf6, 0xf5, 0xfc, 0xe5, 0x3c, 0x08, 0xe8,
+	0xe9, 0x1d, 0x96, 0xf2, 0xff, 0xeb, 0xa3, 0x92, 0x57, 0x21, 0xee, 0x0e, 0x17, 0x36, 0xd8, 0xb1,
+	0xd0, 0xf9, 0x38, 0x29, 0x14, 0x21, 0xc7, 0x53, 0xdd, 0x8b, 0x82, 0x9b, 0xd9, 0xe3, 0xc6, 0xc0,
+	0x66, 0x91, 0xd7, 0x18, 0xd6, 0x7e, 0x8d, 0x40, 0xbc, 0xc5, 0x2d, 0x91, 0xcf, 0x20, 0x42, 0x7b,
+	0x39, 0xb9, 0x34, 0xed, 0x27, 0x9f, 0xf8, 0xd6, 0xd4, 0x83, 0xe1, 0xdd, 0x10, 0xd1, 0x00, 0xc6,
+	0xff, 0xc7, 0x21, 0x27, 0x7f, 0xc8, 0x07, 0xfe, 0xe1, 0x33, 0x9b, 0xa9, 0x7b, 0x90, 0xf6, 0x97,
+	0x27, 0x59, 0x3a, 0x72, 0xdf, 0x15, 0xfa, 0xdf, 0x28, 0xf1, 0xbd, 0x99, 0x2b, 0x9c, 0xdc, 0x06,
+	0xfe, 0xdd, 0xfa, 0x52, 0xcc, 0xb7, 0x8f, 0xc5, 0x0c, 0x14, 0x35, 0x79, 0x00, 0xde, 0x7b, 0x80,
+	0x5c, 0x3e, 0x69, 0x48, 0xfb, 0xea, 0xf7, 0x04, 0xbf, 0x27, 0x25, 0x00, 0x06, 0xc5, 0x80, 0xe4,
+	0xa8, 0x3a, 0xc8, 0x95, 0x93, 0xcc, 0x05, 0xaa, 0xe8, 0x54, 0x06, 0xcb, 0x6f, 0x3e, 0xfd, 0x7d,
+	0x79, 0xee, 0xe9, 0x8b, 0xe5, 0xd0, 0x33, 0xfc, 0xfd, 0x86, 0xbf, 0x6f, 0xfe, 0x58, 0x9e, 0x7b,
+	0x86, 0xbf, 0x5f, 0xf0, 0xf7, 0x29, 0x7b, 0x65, 0xd0, 0x47, 0x86, 0xbd, 0x1b, 0x63, 0x21, 0xbc,
+	0xfa, 0x2f, 0xf8, 0x3e, 0xcf, 0x04, 0x7d, 0x14, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1868,6 +1888,11 @@ func (m *ReadRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n7
 	}
+	if m.WindowEvery != 0 {
+		dAtA[i] = 0x70
+		i++
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.WindowEvery))
+	}
 	return i, nil
 }
 
@@ -2639,6 +2664,9 @@ func (m *ReadRequest) Size() (n int) {
 		l = m.ReadSource.Size()
 		n += 1 + l + sovStorageCommon(uint64(l))
 	}
+	if m.WindowEvery != 0 {
+		n += 1 + sovStorageCommon(uint64(m.WindowEvery))
+	}
 	return n
 }
 
@@ -3611,6 +3639,25 @@ func (m *ReadRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WindowEvery", wireType)
+			}
+			m.WindowEvery = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WindowEvery |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStorageCommon(dAtA[iNdEx:])
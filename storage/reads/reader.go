@@ -3,7 +3,9 @@ package reads
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/gogo/protobuf/types"
@@ -101,6 +103,52 @@ func (r *storeReader) ReadTagValues(ctx context.Context, spec influxdb.ReadTagVa
 	}, nil
 }
 
+func (r *storeReader) ReadMeasurementFields(ctx context.Context, spec influxdb.ReadMeasurementFieldsSpec, alloc *memory.Allocator) (influxdb.TableIterator, error) {
+	s, ok := r.s.(MeasurementFieldsStore)
+	if !ok {
+		return nil, errors.New("store does not support reading measurement fields")
+	}
+
+	var predicate *datatypes.Predicate
+	if spec.Predicate != nil {
+		p, err := toStoragePredicate(spec.Predicate)
+		if err != nil {
+			return nil, err
+		}
+		predicate = p
+	}
+
+	return &measurementFieldsIterator{
+		ctx:       ctx,
+		bounds:    spec.Bounds,
+		s:         r.s,
+		fs:        s,
+		readSpec:  spec,
+		predicate: predicate,
+		alloc:     alloc,
+	}, nil
+}
+
+func (r *storeReader) ReadWindowAggregate(ctx context.Context, spec influxdb.ReadWindowAggregateSpec, alloc *memory.Allocator) (influxdb.TableIterator, error) {
+	var predicate *datatypes.Predicate
+	if spec.Predicate != nil {
+		p, err := toStoragePredicate(spec.Predicate)
+		if err != nil {
+			return nil, err
+		}
+		predicate = p
+	}
+
+	return &windowAggregateIterator{
+		ctx:       ctx,
+		bounds:    spec.Bounds,
+		s:         r.s,
+		readSpec:  spec,
+		predicate: predicate,
+		alloc:     alloc,
+	}, nil
+}
+
 func (r *storeReader) Close() {}
 
 type simpleTableIterator struct {
@@ -284,6 +332,9 @@ func (bi *tableIterator) Do(f func(flux.Table) error) error {
 		if req.Hints.NoPoints() {
 			return bi.handleGroupReadNoPoints(f, rs)
 		}
+		if req.Aggregate != nil {
+			return bi.handleGroupAggregate(f, rs)
+		}
 		return bi.handleGroupRead(f, rs)
 
 	default:
@@ -449,6 +500,9 @@ READ:
 		}
 
 		if cur == nil {
+			if err := gc.Err(); err != nil {
+				return err
+			}
 			gc.Close()
 			gc = rs.Next()
 			continue
@@ -503,6 +557,126 @@ READ:
 	return rs.Err()
 }
 
+// handleGroupAggregate reduces each group to a single row. The
+// cursors of a group produce the count or sum of each series, so the
+// value for the group is the sum of those.
+func (bi *tableIterator) handleGroupAggregate(f func(flux.Table) error, rs GroupResultSet) error {
+	defer rs.Close()
+
+	for gc := rs.Next(); gc != nil; gc = rs.Next() {
+		var (
+			typ  flux.ColType
+			isum int64
+			usum uint64
+			fsum float64
+		)
+		for gc.Next() {
+			cur := gc.Cursor()
+			if cur == nil {
+				continue
+			}
+
+			var t flux.ColType
+			switch typedCur := cur.(type) {
+			case cursors.IntegerArrayCursor:
+				t = flux.TInt
+				for a := typedCur.Next(); a.Len() > 0; a = typedCur.Next() {
+					for _, v := range a.Values {
+						isum += v
+					}
+				}
+			case cursors.UnsignedArrayCursor:
+				t = flux.TUInt
+				for a := typedCur.Next(); a.Len() > 0; a = typedCur.Next() {
+					for _, v := range a.Values {
+						usum += v
+					}
+				}
+			case cursors.FloatArrayCursor:
+				t = flux.TFloat
+				for a := typedCur.Next(); a.Len() > 0; a = typedCur.Next() {
+					for _, v := range a.Values {
+						fsum += v
+					}
+				}
+			default:
+				cur.Close()
+				gc.Close()
+				return fmt.Errorf("unsupported aggregate cursor type: %T", typedCur)
+			}
+
+			stats := cur.Stats()
+			bi.stats.ScannedValues += stats.ScannedValues
+			bi.stats.ScannedBytes += stats.ScannedBytes
+			cur.Close()
+
+			if typ != flux.TInvalid && typ != t {
+				gc.Close()
+				return fmt.Errorf("schema collision: cannot aggregate %s and %s values in the same group", typ, t)
+			}
+			typ = t
+		}
+		if err := gc.Err(); err != nil {
+			gc.Close()
+			return err
+		}
+
+		key := groupKeyForGroup(gc.PartitionKeyVals(), &bi.readSpec, bi.bounds)
+		gc.Close()
+
+		if typ == flux.TInvalid {
+			// no data for any series in the group
+			continue
+		}
+
+		builder := execute.NewColListTableBuilder(key, bi.alloc)
+		if err := execute.AddTableKeyCols(key, builder); err != nil {
+			return err
+		}
+		valueIdx, err := builder.AddCol(flux.ColMeta{
+			Label: execute.DefaultValueColLabel,
+			Type:  typ,
+		})
+		if err != nil {
+			return err
+		}
+		if err := execute.AppendKeyValues(key, builder); err != nil {
+			return err
+		}
+
+		switch typ {
+		case flux.TInt:
+			err = builder.AppendInt(valueIdx, isum)
+		case flux.TUInt:
+			err = builder.AppendUInt(valueIdx, usum)
+		case flux.TFloat:
+			err = builder.AppendFloat(valueIdx, fsum)
+		}
+		if err != nil {
+			return err
+		}
+
+		tbl, err := builder.Table()
+		if err != nil {
+			return err
+		}
+		tbl.RefCount(1)
+
+		// Release the references to the arrays held by the builder.
+		builder.ClearData()
+		if err := f(tbl); err != nil {
+			return err
+		}
+
+		select {
+		case <-bi.ctx.Done():
+			return bi.ctx.Err()
+		default:
+		}
+	}
+	return rs.Err()
+}
+
 func (bi *tableIterator) handleGroupReadNoPoints(f func(flux.Table) error, rs GroupResultSet) error {
 	// these resources must be closed if not nil on return
 	var (
@@ -709,23 +883,44 @@ func determineTableColsForGroup(tagKeys [][]byte, typ flux.ColType) ([]flux.ColM
 	return cols, defs
 }
 
+// groupKeyForGroup returns the group key of a group of the series read with the
+// group keys of the read spec. Like the group() function, the key is made of exactly
+// those columns, in the order of the columns of the table: the bounds, if they are
+// among the group keys, then the tags sorted by key.
 func groupKeyForGroup(kv [][]byte, readSpec *influxdb.ReadSpec, bnds execute.Bounds) flux.GroupKey {
-	cols := make([]flux.ColMeta, 2, len(readSpec.GroupKeys)+2)
-	vs := make([]values.Value, 2, len(readSpec.GroupKeys)+2)
-	cols[0] = flux.ColMeta{
-		Label: execute.DefaultStartColLabel,
-		Type:  flux.TTime,
+	var hasStart, hasStop bool
+	tags := make([]int, 0, len(readSpec.GroupKeys))
+	for i, k := range readSpec.GroupKeys {
+		switch k {
+		case execute.DefaultStartColLabel:
+			hasStart = true
+		case execute.DefaultStopColLabel:
+			hasStop = true
+		default:
+			tags = append(tags, i)
+		}
 	}
-	vs[0] = values.NewTime(bnds.Start)
-	cols[1] = flux.ColMeta{
-		Label: execute.DefaultStopColLabel,
-		Type:  flux.TTime,
+	sort.Slice(tags, func(i, j int) bool {
+		return readSpec.GroupKeys[tags[i]] < readSpec.GroupKeys[tags[j]]
+	})
+
+	cols := make([]flux.ColMeta, 0, len(readSpec.GroupKeys))
+	vs := make([]values.Value, 0, len(readSpec.GroupKeys))
+	if hasStart {
+		cols = append(cols, flux.ColMeta{
+			Label: execute.DefaultStartColLabel,
+			Type:  flux.TTime,
+		})
+		vs = append(vs, values.NewTime(bnds.Start))
 	}
-	vs[1] = values.NewTime(bnds.Stop)
-	for i := range readSpec.GroupKeys {
-		if readSpec.GroupKeys[i] == execute.DefaultStartColLabel || readSpec.GroupKeys[i] == execute.DefaultStopColLabel {
-			continue
-		}
+	if hasStop {
+		cols = append(cols, flux.ColMeta{
+			Label: execute.DefaultStopColLabel,
+			Type:  flux.TTime,
+		})
+		vs = append(vs, values.NewTime(bnds.Stop))
+	}
+	for _, i := range tags {
 		cols = append(cols, flux.ColMeta{
 			Label: readSpec.GroupKeys[i],
 			Type:  flux.TString,
@@ -780,7 +975,14 @@ func (ti *tagKeysIterator) handleRead(f func(flux.Table) error, rs cursors.Strin
 	defer builder.ClearData()
 
 	for rs.Next() {
-		if err := builder.AppendString(valueIdx, rs.Value()); err != nil {
+		v := rs.Value()
+		switch v {
+		case models.MeasurementTagKey:
+			v = "_measurement"
+		case models.FieldKeyTagKey:
+			v = "_field"
+		}
+		if err := builder.AppendString(valueIdx, v); err != nil {
 			return err
 		}
 	}
@@ -886,3 +1088,368 @@ func (ti *tagValuesIterator) handleRead(f func(flux.Table) error, rs cursors.Str
 func (ti *tagValuesIterator) Statistics() cursors.CursorStats {
 	return cursors.CursorStats{}
 }
+
+type measurementFieldsIterator struct {
+	ctx       context.Context
+	bounds    execute.Bounds
+	s         Store
+	fs        MeasurementFieldsStore
+	readSpec  influxdb.ReadMeasurementFieldsSpec
+	predicate *datatypes.Predicate
+	alloc     *memory.Allocator
+}
+
+func (mi *measurementFieldsIterator) Do(f func(flux.Table) error) error {
+	src := mi.s.GetSource(
+		uint64(mi.readSpec.OrganizationID),
+		uint64(mi.readSpec.BucketID),
+	)
+
+	var req datatypes.TagKeysRequest
+	if any, err := types.MarshalAny(src); err != nil {
+		return err
+	} else {
+		req.TagsSource = any
+	}
+	req.Predicate = mi.predicate
+	req.Range.Start = int64(mi.bounds.Start)
+	req.Range.End = int64(mi.bounds.Stop)
+
+	fields, err := mi.fs.MeasurementFields(mi.ctx, &req)
+	if err != nil {
+		return err
+	}
+	return mi.handleRead(f, fields)
+}
+
+func (mi *measurementFieldsIterator) handleRead(f func(flux.Table) error, fields []MeasurementField) error {
+	key := execute.NewGroupKey(nil, nil)
+	builder := execute.NewColListTableBuilder(key, mi.alloc)
+	cols := []flux.ColMeta{
+		{Label: "_measurement", Type: flux.TString},
+		{Label: execute.DefaultValueColLabel, Type: flux.TString},
+		{Label: "type", Type: flux.TString},
+	}
+	for _, col := range cols {
+		if _, err := builder.AddCol(col); err != nil {
+			return err
+		}
+	}
+	defer builder.ClearData()
+
+	for _, field := range fields {
+		if err := builder.AppendString(0, field.Measurement); err != nil {
+			return err
+		}
+		if err := builder.AppendString(1, field.Field); err != nil {
+			return err
+		}
+		if err := builder.AppendString(2, fieldTypeName(field.Type)); err != nil {
+			return err
+		}
+	}
+
+	tbl, err := builder.Table()
+	if err != nil {
+		return err
+	}
+	tbl.RefCount(1)
+
+	// Release the references to the arrays held by the builder.
+	builder.ClearData()
+	return f(tbl)
+}
+
+func (mi *measurementFieldsIterator) Statistics() cursors.CursorStats {
+	return cursors.CursorStats{}
+}
+
+// fieldTypeName returns the name of the Flux type of the values of a field of type t.
+func fieldTypeName(t models.FieldType) string {
+	switch t {
+	case models.Float:
+		return flux.TFloat.String()
+	case models.Integer:
+		return flux.TInt.String()
+	case models.Unsigned:
+		return flux.TUInt.String()
+	case models.String:
+		return flux.TString.String()
+	case models.Boolean:
+		return flux.TBool.String()
+	default:
+		return flux.TInvalid.String()
+	}
+}
+
+type windowAggregateIterator struct {
+	ctx       context.Context
+	bounds    execute.Bounds
+	s         Store
+	readSpec  influxdb.ReadWindowAggregateSpec
+	predicate *datatypes.Predicate
+	stats     cursors.CursorStats
+	alloc     *memory.Allocator
+}
+
+func (wai *windowAggregateIterator) Statistics() cursors.CursorStats { return wai.stats }
+
+func (wai *windowAggregateIterator) Do(f func(flux.Table) error) error {
+	src := wai.s.GetSource(
+		uint64(wai.readSpec.OrganizationID),
+		uint64(wai.readSpec.BucketID),
+	)
+
+	// Setup read request
+	var req datatypes.ReadRequest
+	if any, err := types.MarshalAny(src); err != nil {
+		return err
+	} else {
+		req.ReadSource = any
+	}
+	req.Predicate = wai.predicate
+	req.TimestampRange.Start = int64(wai.bounds.Start)
+	req.TimestampRange.End = int64(wai.bounds.Stop)
+	req.Group = datatypes.GroupAll
+	req.WindowEvery = wai.readSpec.WindowEvery
+
+	if len(wai.readSpec.Aggregates) != 1 {
+		return fmt.Errorf("window aggregate read requires exactly one aggregate, got %d", len(wai.readSpec.Aggregates))
+	}
+	agg, err := determineAggregateMethod(string(wai.readSpec.Aggregates[0]))
+	if err != nil {
+		return err
+	} else if agg == datatypes.AggregateTypeNone {
+		return errors.New("window aggregate read requires an aggregate")
+	}
+	req.Aggregate = &datatypes.Aggregate{Type: agg}
+
+	rs, err := wai.s.Read(wai.ctx, &req)
+	if err != nil {
+		return err
+	}
+
+	if rs == nil {
+		return nil
+	}
+	return wai.handleRead(f, rs, agg)
+}
+
+// isSelector returns true if the aggregate selects an existing point,
+// in which case the time of that point is retained.
+func isSelector(agg datatypes.Aggregate_AggregateType) bool {
+	switch agg {
+	case datatypes.AggregateTypeMin, datatypes.AggregateTypeMax,
+		datatypes.AggregateTypeFirst, datatypes.AggregateTypeLast:
+		return true
+	default:
+		return false
+	}
+}
+
+func (wai *windowAggregateIterator) handleRead(f func(flux.Table) error, rs ResultSet, agg datatypes.Aggregate_AggregateType) error {
+	defer rs.Close()
+
+	for rs.Next() {
+		cur := rs.Cursor()
+		if cur == nil {
+			// no data for series key + field combination
+			continue
+		}
+
+		err := wai.readCursor(f, rs.Tags(), cur, agg)
+		stats := cur.Stats()
+		wai.stats.ScannedValues += stats.ScannedValues
+		wai.stats.ScannedBytes += stats.ScannedBytes
+		cur.Close()
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-wai.ctx.Done():
+			return wai.ctx.Err()
+		default:
+		}
+	}
+	return rs.Err()
+}
+
+// readCursor produces a table with a single row for every window
+// returned by the aggregate cursor. If empty windows are created,
+// the windows of the series without any points are produced too.
+func (wai *windowAggregateIterator) readCursor(f func(flux.Table) error, tags models.Tags, cur cursors.Cursor, agg datatypes.Aggregate_AggregateType) error {
+	selector := isSelector(agg)
+
+	// next is the start of the window following the last one produced.
+	next, produced := int64(wai.bounds.Start), false
+	produce := func(typ flux.ColType, ts int64, appendValue func(b *execute.ColListTableBuilder, j int) error) error {
+		wstart, wstop := windowBounds(ts, wai.readSpec.WindowEvery)
+		if err := wai.produceEmpty(f, tags, typ, agg, next, wstart); err != nil {
+			return err
+		}
+		next, produced = wstop, true
+		return wai.produce(f, tags, typ, ts, selector, appendValue)
+	}
+
+	var typ flux.ColType
+	switch typedCur := cur.(type) {
+	case cursors.IntegerArrayCursor:
+		typ = flux.TInt
+		for a := typedCur.Next(); a.Len() > 0; a = typedCur.Next() {
+			for i, v := range a.Values {
+				if err := produce(typ, a.Timestamps[i], func(b *execute.ColListTableBuilder, j int) error {
+					return b.AppendInt(j, v)
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	case cursors.FloatArrayCursor:
+		typ = flux.TFloat
+		for a := typedCur.Next(); a.Len() > 0; a = typedCur.Next() {
+			for i, v := range a.Values {
+				if err := produce(typ, a.Timestamps[i], func(b *execute.ColListTableBuilder, j int) error {
+					return b.AppendFloat(j, v)
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	case cursors.UnsignedArrayCursor:
+		typ = flux.TUInt
+		for a := typedCur.Next(); a.Len() > 0; a = typedCur.Next() {
+			for i, v := range a.Values {
+				if err := produce(typ, a.Timestamps[i], func(b *execute.ColListTableBuilder, j int) error {
+					return b.AppendUInt(j, v)
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	case cursors.BooleanArrayCursor:
+		typ = flux.TBool
+		for a := typedCur.Next(); a.Len() > 0; a = typedCur.Next() {
+			for i, v := range a.Values {
+				if err := produce(typ, a.Timestamps[i], func(b *execute.ColListTableBuilder, j int) error {
+					return b.AppendBool(j, v)
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	case cursors.StringArrayCursor:
+		typ = flux.TString
+		for a := typedCur.Next(); a.Len() > 0; a = typedCur.Next() {
+			for i, v := range a.Values {
+				if err := produce(typ, a.Timestamps[i], func(b *execute.ColListTableBuilder, j int) error {
+					return b.AppendString(j, v)
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		panic(fmt.Sprintf("unreachable: %T", typedCur))
+	}
+
+	if !produced {
+		return nil
+	}
+	return wai.produceEmpty(f, tags, typ, agg, next, int64(wai.bounds.Stop))
+}
+
+// produceEmpty produces the windows of the series starting within [from, to), which
+// do not have any points, if empty windows are created. As when Flux aggregates an
+// empty window, the count of the window is 0 and the value of other aggregates is
+// null, while selectors do not produce a row.
+func (wai *windowAggregateIterator) produceEmpty(f func(flux.Table) error, tags models.Tags, typ flux.ColType, agg datatypes.Aggregate_AggregateType, from, to int64) error {
+	if !wai.readSpec.CreateEmpty || wai.readSpec.WindowEvery <= 0 || isSelector(agg) {
+		return nil
+	}
+
+	for start := from; start < to; {
+		_, stop := windowBounds(start, wai.readSpec.WindowEvery)
+		if err := wai.produce(f, tags, typ, start, false, func(b *execute.ColListTableBuilder, j int) error {
+			if agg == datatypes.AggregateTypeCount {
+				return b.AppendInt(j, 0)
+			}
+			return b.AppendNil(j)
+		}); err != nil {
+			return err
+		}
+		start = stop
+	}
+	return nil
+}
+
+// produce builds the table for the window containing ts. The table
+// has the same shape as the output of the equivalent Flux window and
+// aggregate: aggregates retain the group key and value columns while
+// selectors retain all of the columns of the point. If no window was
+// requested, the window is the entire range of the read.
+func (wai *windowAggregateIterator) produce(f func(flux.Table) error, tags models.Tags, typ flux.ColType, ts int64, selector bool, appendValue func(b *execute.ColListTableBuilder, j int) error) error {
+	start, stop := int64(wai.bounds.Start), int64(wai.bounds.Stop)
+	if wai.readSpec.WindowEvery > 0 {
+		wstart, wstop := windowBounds(ts, wai.readSpec.WindowEvery)
+		if wstart > start {
+			start = wstart
+		}
+		if wstop < stop {
+			stop = wstop
+		}
+	}
+
+	key := defaultGroupKeyForSeries(tags, execute.Bounds{
+		Start: execute.Time(start),
+		Stop:  execute.Time(stop),
+	})
+
+	var cols []flux.ColMeta
+	if selector {
+		cols, _ = determineTableColsForSeries(tags, typ)
+	} else {
+		cols = append(cols, key.Cols()...)
+		cols = append(cols, flux.ColMeta{
+			Label: execute.DefaultValueColLabel,
+			Type:  typ,
+		})
+	}
+
+	builder := execute.NewColListTableBuilder(key, wai.alloc)
+	defer builder.ClearData()
+	for _, c := range cols {
+		if _, err := builder.AddCol(c); err != nil {
+			return err
+		}
+	}
+
+	for j, c := range cols {
+		var err error
+		switch c.Label {
+		case execute.DefaultStartColLabel:
+			err = builder.AppendTime(j, execute.Time(start))
+		case execute.DefaultStopColLabel:
+			err = builder.AppendTime(j, execute.Time(stop))
+		case execute.DefaultTimeColLabel:
+			err = builder.AppendTime(j, execute.Time(ts))
+		case execute.DefaultValueColLabel:
+			err = appendValue(builder, j)
+		default:
+			err = builder.AppendString(j, tags.GetString(c.Label))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	tbl, err := builder.Table()
+	if err != nil {
+		return err
+	}
+	tbl.RefCount(1)
+
+	// Release the references to the arrays held by the builder.
+	builder.ClearData()
+	return f(tbl)
+}
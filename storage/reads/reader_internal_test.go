@@ -0,0 +1,123 @@
+package reads
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+)
+
+func TestGroupKeyForGroup(t *testing.T) {
+	bnds := execute.Bounds{Start: 5, Stop: 10}
+	for _, tt := range []struct {
+		name string
+		keys []string
+		kv   [][]byte
+		exp  flux.GroupKey
+	}{
+		{
+			name: "tags",
+			keys: []string{"region", "host"},
+			kv:   [][]byte{[]byte("west"), []byte("a")},
+			exp: execute.NewGroupKey(
+				[]flux.ColMeta{{Label: "host", Type: flux.TString}, {Label: "region", Type: flux.TString}},
+				[]values.Value{values.NewString("a"), values.NewString("west")},
+			),
+		},
+		{
+			name: "bounds",
+			keys: []string{"host", "_stop", "_start"},
+			kv:   [][]byte{[]byte("a"), nil, nil},
+			exp: execute.NewGroupKey(
+				[]flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "host", Type: flux.TString},
+				},
+				[]values.Value{values.NewTime(5), values.NewTime(10), values.NewString("a")},
+			),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := groupKeyForGroup(tt.kv, &influxdb.ReadSpec{GroupKeys: tt.keys}, bnds)
+			if !got.Equal(tt.exp) {
+				t.Fatalf("got group key %v, exp %v", got, tt.exp)
+			}
+		})
+	}
+}
+
+func TestWindowAggregateIterator_CreateEmpty(t *testing.T) {
+	type row struct {
+		start int64
+		value interface{}
+	}
+
+	// The windows within [5, 40) are [5, 10), [10, 20), [20, 30) and [30, 40).
+	for _, tt := range []struct {
+		name   string
+		agg    datatypes.Aggregate_AggregateType
+		points [][2]int64
+		exp    []row
+	}{
+		{
+			name:   "sum",
+			agg:    datatypes.AggregateTypeSum,
+			points: [][2]int64{{10, 1}, {30, 2}},
+			exp:    []row{{5, nil}, {10, int64(1)}, {20, nil}, {30, int64(2)}},
+		},
+		{
+			name:   "count",
+			agg:    datatypes.AggregateTypeCount,
+			points: [][2]int64{{10, 3}},
+			exp:    []row{{5, int64(0)}, {10, int64(3)}, {20, int64(0)}, {30, int64(0)}},
+		},
+		{
+			name:   "max",
+			agg:    datatypes.AggregateTypeMax,
+			points: [][2]int64{{12, 1}, {31, 2}},
+			exp:    []row{{10, int64(1)}, {30, int64(2)}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			wai := &windowAggregateIterator{
+				ctx:    context.Background(),
+				bounds: execute.Bounds{Start: 5, Stop: 40},
+				readSpec: influxdb.ReadWindowAggregateSpec{
+					WindowEvery: 10,
+					CreateEmpty: true,
+				},
+				alloc: &memory.Allocator{},
+			}
+
+			var got []row
+			err := wai.readCursor(func(tbl flux.Table) error {
+				return tbl.Do(func(cr flux.ColReader) error {
+					startIdx := execute.ColIdx(execute.DefaultStartColLabel, cr.Cols())
+					valueIdx := execute.ColIdx(execute.DefaultValueColLabel, cr.Cols())
+					for i := 0; i < cr.Len(); i++ {
+						r := row{start: int64(execute.ValueForRow(cr, i, startIdx).Time())}
+						if v := execute.ValueForRow(cr, i, valueIdx); !v.IsNull() {
+							r.value = v.Int()
+						}
+						got = append(got, r)
+					}
+					return nil
+				})
+			}, models.NewTags(map[string]string{"host": "a"}), newIntegerArrayCursor(tt.points), tt.agg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.exp) {
+				t.Fatalf("got rows %v, exp %v", got, tt.exp)
+			}
+		})
+	}
+}
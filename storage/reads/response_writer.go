@@ -77,6 +77,9 @@ func (w *ResponseWriter) WriteResultSet(rs ResultSet) error {
 			return w.err
 		}
 	}
+	if err := rs.Err(); err != nil {
+		return err
+	}
 
 	stats := rs.Stats()
 	w.stream.SetTrailer(metadata.Pairs(
@@ -106,6 +109,10 @@ func (w *ResponseWriter) WriteGroupResultSet(rs GroupResultSet) error {
 			}
 			stats.Add(gc.Stats())
 		}
+		if err := gc.Err(); err != nil {
+			gc.Close()
+			return err
+		}
 		gc.Close()
 		gc = rs.Next()
 	}
@@ -11,23 +11,27 @@ import (
 
 type multiShardCursors interface {
 	createCursor(row SeriesRow) cursors.Cursor
-	newAggregateCursor(ctx context.Context, agg *datatypes.Aggregate, cursor cursors.Cursor) cursors.Cursor
+	newAggregateCursor(ctx context.Context, agg *datatypes.Aggregate, every int64, cursor cursors.Cursor) (cursors.Cursor, error)
 }
 
 type resultSet struct {
-	ctx context.Context
-	agg *datatypes.Aggregate
-	cur SeriesCursor
-	row SeriesRow
-	mb  multiShardCursors
+	ctx   context.Context
+	agg   *datatypes.Aggregate
+	every int64
+	cur   SeriesCursor
+	row   SeriesRow
+	mb    multiShardCursors
+	err   error
 }
 
 func NewResultSet(ctx context.Context, req *datatypes.ReadRequest, cur SeriesCursor) ResultSet {
+	asc, limit := cursorOrder(req)
 	return &resultSet{
-		ctx: ctx,
-		agg: req.Aggregate,
-		cur: cur,
-		mb:  newMultiShardArrayCursors(ctx, req.TimestampRange.Start, req.TimestampRange.End, !req.Descending, req.PointsLimit),
+		ctx:   ctx,
+		agg:   req.Aggregate,
+		every: req.WindowEvery,
+		cur:   cur,
+		mb:    newMultiShardArrayCursors(ctx, req.TimestampRange.Start, req.TimestampRange.End, asc, limit),
 	}
 }
 
@@ -39,7 +43,7 @@ func NewResultSetFromFilter(ctx context.Context, req *datatypes.ReadFilterReques
 	}
 }
 
-func (r *resultSet) Err() error { return nil }
+func (r *resultSet) Err() error { return r.err }
 
 // Close closes the result set. Close is idempotent.
 func (r *resultSet) Close() {
@@ -52,7 +56,7 @@ func (r *resultSet) Close() {
 
 // Next returns true if there are more results available.
 func (r *resultSet) Next() bool {
-	if r == nil {
+	if r == nil || r.err != nil {
 		return false
 	}
 
@@ -66,10 +70,13 @@ func (r *resultSet) Next() bool {
 	return true
 }
 
+// Cursor returns the cursor of the current series. If the aggregate of the
+// request does not support the type of the series, Cursor returns nil and
+// the result set stops with the error.
 func (r *resultSet) Cursor() cursors.Cursor {
 	cur := r.mb.createCursor(r.row)
 	if r.agg != nil {
-		cur = r.mb.newAggregateCursor(r.ctx, r.agg, cur)
+		cur, r.err = r.mb.newAggregateCursor(r.ctx, r.agg, r.every, cur)
 	}
 	return cur
 }
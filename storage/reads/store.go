@@ -81,3 +81,18 @@ type Store interface {
 	TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) (cursors.StringIterator, error)
 	TagValues(ctx context.Context, req *datatypes.TagValuesRequest) (cursors.StringIterator, error)
 }
+
+// MeasurementField describes a field of a measurement.
+type MeasurementField struct {
+	Measurement string
+	Field       string
+	Type        models.FieldType
+}
+
+// MeasurementFieldsStore is implemented by a Store able to describe the fields
+// of the measurements of a bucket.
+type MeasurementFieldsStore interface {
+	// MeasurementFields returns the fields of the series selected by req,
+	// sorted by measurement and field key.
+	MeasurementFields(ctx context.Context, req *datatypes.TagKeysRequest) ([]MeasurementField, error)
+}
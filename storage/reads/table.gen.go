@@ -239,6 +239,7 @@ func (t *floatGroupTable) advanceCursor() bool {
 			return true
 		}
 	}
+	t.err = t.gc.Err()
 	return false
 }
 
@@ -474,6 +475,7 @@ func (t *integerGroupTable) advanceCursor() bool {
 			return true
 		}
 	}
+	t.err = t.gc.Err()
 	return false
 }
 
@@ -709,6 +711,7 @@ func (t *unsignedGroupTable) advanceCursor() bool {
 			return true
 		}
 	}
+	t.err = t.gc.Err()
 	return false
 }
 
@@ -944,6 +947,7 @@ func (t *stringGroupTable) advanceCursor() bool {
 			return true
 		}
 	}
+	t.err = t.gc.Err()
 	return false
 }
 
@@ -1179,6 +1183,7 @@ func (t *booleanGroupTable) advanceCursor() bool {
 			return true
 		}
 	}
+	t.err = t.gc.Err()
 	return false
 }
 
@@ -0,0 +1,267 @@
+package readservice
+
+import (
+	"container/list"
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/influxdata/flux"
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/storage"
+)
+
+// A ResultCache keeps the series read by the queries of dashboards, which run
+// the same queries over a range of time moving forward with each refresh.
+//
+// Only the data older than the last snapshot of the engine cache is kept, as
+// the recent data is still likely to change. The next run of a query reads the
+// data it needs from the cache and only the more recent tail of its range
+// from the engine, which extends the cached data up to the new last snapshot.
+//
+// The cache must observe the changes of the engine it caches the data of, by
+// creating the engine with the storage.WithChangeObserver option. The cached
+// data overlapping the range of time of a write or delete is evicted.
+type ResultCache struct {
+	maxValues int
+
+	mu      sync.Mutex
+	values  int
+	lru     *list.List // of *cacheEntry, most recently used first
+	entries map[string]*list.Element
+	pending map[*entryBuilder]struct{}
+}
+
+var _ storage.ChangeObserver = (*ResultCache)(nil)
+
+// NewResultCache returns a cache holding up to maxValues values.
+func NewResultCache(maxValues int) *ResultCache {
+	return &ResultCache{
+		maxValues: maxValues,
+		lru:       list.New(),
+		entries:   make(map[string]*list.Element),
+		pending:   make(map[*entryBuilder]struct{}),
+	}
+}
+
+// cacheEntry is the data of the series read by a query from a bucket with a
+// predicate, for the times in [start, end).
+type cacheEntry struct {
+	key             string
+	orgID, bucketID platform.ID
+	start, end      int64
+
+	series []*cachedSeries
+	index  map[string]*cachedSeries
+	values int
+}
+
+// cachedSeries is the data of a series of a cache entry.
+type cachedSeries struct {
+	key    string
+	tags   models.Tags
+	arrays cachedArrays
+}
+
+// cachedArrays is the immutable data of a cached series, implemented for each
+// type of value by a slice of arrays in ascending order of time.
+type cachedArrays interface {
+	// trim returns the arrays of the values at or after start.
+	trim(start int64) cachedArrays
+
+	// len returns the number of values of the arrays.
+	len() int
+}
+
+// BucketRangeChanged evicts the data cached from the bucket within [min, max],
+// and discards the data being read into the cache for it.
+func (c *ResultCache) BucketRangeChanged(orgID, bucketID platform.ID, min, max int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if e := elem.Value.(*cacheEntry); e.overlaps(orgID, bucketID, min, max) {
+			c.removeLocked(elem)
+		}
+		elem = next
+	}
+
+	for b := range c.pending {
+		if b.overlaps(orgID, bucketID, min, max) {
+			b.invalid = true
+		}
+	}
+}
+
+func (e *cacheEntry) overlaps(orgID, bucketID platform.ID, min, max int64) bool {
+	return e.orgID == orgID && e.bucketID == bucketID && min < e.end && max >= e.start
+}
+
+// begin looks up the data cached for the key, and starts reading the data of
+// the range [start, end] into the cache, for the values before the watermark.
+//
+// If the returned entry is not nil, it has the data for the times from start
+// up to its end, and the more recent data must be read from the engine. The
+// returned builder is nil if no data can be added to the cache.
+func (c *ResultCache) begin(key string, orgID, bucketID platform.ID, start, end, watermark int64) (*cacheEntry, *entryBuilder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var entry *cacheEntry
+	if elem, ok := c.entries[key]; ok {
+		if e := elem.Value.(*cacheEntry); start >= e.start && start < e.end {
+			c.lru.MoveToFront(elem)
+			entry = e
+		}
+	}
+
+	if entry != nil && end < entry.end {
+		// The cache has all of the data.
+		return entry, nil
+	}
+	if watermark > end {
+		watermark = end + 1
+	}
+	if watermark <= start || (entry != nil && watermark <= entry.end) {
+		// No more data can be cached.
+		return entry, nil
+	}
+
+	b := &entryBuilder{
+		cache: c,
+		entry: &cacheEntry{
+			key:      key,
+			orgID:    orgID,
+			bucketID: bucketID,
+			start:    start,
+			end:      watermark,
+			index:    make(map[string]*cachedSeries),
+		},
+	}
+	c.pending[b] = struct{}{}
+	return entry, b
+}
+
+// commit adds the entry built by b to the cache, unless its data changed
+// while it was read.
+func (c *ResultCache) commit(b *entryBuilder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.pending, b)
+	if b.invalid || b.entry.values > c.maxValues {
+		return
+	}
+
+	if elem, ok := c.entries[b.entry.key]; ok {
+		c.removeLocked(elem)
+	}
+	c.entries[b.entry.key] = c.lru.PushFront(b.entry)
+	c.values += b.entry.values
+
+	for c.values > c.maxValues {
+		c.removeLocked(c.lru.Back())
+	}
+}
+
+// abort forgets the entry built by b.
+func (c *ResultCache) abort(b *entryBuilder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pending, b)
+}
+
+func (c *ResultCache) removeLocked(elem *list.Element) {
+	e := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, e.key)
+	c.values -= e.values
+}
+
+// entryBuilder collects the data of a new cache entry, while the series are read.
+type entryBuilder struct {
+	cache *ResultCache
+	entry *cacheEntry
+
+	// incomplete is set when the data of a series was not entirely read.
+	incomplete bool
+
+	// invalid is set, under the lock of the cache, when the data of the entry
+	// changed while it was read.
+	invalid bool
+}
+
+func (b *entryBuilder) overlaps(orgID, bucketID platform.ID, min, max int64) bool {
+	return b.entry.overlaps(orgID, bucketID, min, max)
+}
+
+// addSeries records the arrays of a series, once it has been read.
+func (b *entryBuilder) addSeries(key string, tags models.Tags, arrays cachedArrays, drained bool) {
+	if !drained {
+		b.incomplete = true
+		return
+	}
+	if arrays == nil {
+		return
+	}
+	if n := arrays.len(); n > 0 {
+		s := &cachedSeries{key: key, tags: tags, arrays: arrays}
+		b.entry.series = append(b.entry.series, s)
+		b.entry.index[key] = s
+		b.entry.values += n
+	}
+}
+
+// finish adds the entry to the cache if all of its series have been read.
+func (b *entryBuilder) finish(err error) {
+	if err != nil || b.incomplete {
+		b.cache.abort(b)
+		return
+	}
+	b.cache.commit(b)
+}
+
+type cacheScopeContextKey struct{}
+
+// withCacheScope returns a context whose reads are cached under key.
+func withCacheScope(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, cacheScopeContextKey{}, key)
+}
+
+// cacheScopeFromContext returns the key of the context, if its reads are cached.
+func cacheScopeFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(cacheScopeContextKey{}).(string)
+	return key, ok
+}
+
+// cacheScopeKey returns the key of the reads of the query of req, which is the
+// same for the queries of the organization whose text only differs by whitespace.
+func cacheScopeKey(req *query.Request) string {
+	var compilerType flux.CompilerType
+	if req.Compiler != nil {
+		compilerType = req.Compiler.CompilerType()
+	}
+	text := strings.Join(strings.Fields(query.CompilerText(req.Compiler)), " ")
+	return req.OrganizationID.String() + "\x00" + string(compilerType) + "\x00" + text
+}
+
+// CachingProxyQueryService is a query.ProxyQueryService caching the data read by the
+// queries in the ResultCache given to AddControllerConfigDependencies.
+type CachingProxyQueryService struct {
+	query.ProxyQueryService
+}
+
+// NewCachingProxyQueryService returns a proxy query service caching the data read
+// by the queries of pqs.
+func NewCachingProxyQueryService(pqs query.ProxyQueryService) *CachingProxyQueryService {
+	return &CachingProxyQueryService{ProxyQueryService: pqs}
+}
+
+// Query performs the query, reading the data through the cache.
+func (s *CachingProxyQueryService) Query(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
+	ctx = withCacheScope(ctx, cacheScopeKey(&req.Request))
+	return s.ProxyQueryService.Query(ctx, w, req)
+}
@@ -0,0 +1,793 @@
+// Generated by tmpl
+// https://github.com/benbjohnson/tmpl
+//
+// DO NOT EDIT!
+// Source: cache_cursor.gen.go.tmpl
+
+package readservice
+
+import (
+	"sort"
+
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+// newCacheCursor returns a cursor reading the cached values followed by the
+// values of tail, or nil if there are neither. It returns false if the type
+// of the values of tail is not the type of the cached values, in which case
+// only tail is read.
+func newCacheCursor(cached cachedArrays, tail cursors.Cursor, opts cacheCursorOptions) (cursors.Cursor, bool) {
+	switch tail := tail.(type) {
+
+	case cursors.FloatArrayCursor:
+		a, ok := cached.(floatCachedArrays)
+		if !ok && cached != nil {
+			opts.done = nil
+			return newFloatCacheCursor(nil, tail, opts), false
+		}
+		return newFloatCacheCursor(a, tail, opts), true
+
+	case cursors.IntegerArrayCursor:
+		a, ok := cached.(integerCachedArrays)
+		if !ok && cached != nil {
+			opts.done = nil
+			return newIntegerCacheCursor(nil, tail, opts), false
+		}
+		return newIntegerCacheCursor(a, tail, opts), true
+
+	case cursors.UnsignedArrayCursor:
+		a, ok := cached.(unsignedCachedArrays)
+		if !ok && cached != nil {
+			opts.done = nil
+			return newUnsignedCacheCursor(nil, tail, opts), false
+		}
+		return newUnsignedCacheCursor(a, tail, opts), true
+
+	case cursors.StringArrayCursor:
+		a, ok := cached.(stringCachedArrays)
+		if !ok && cached != nil {
+			opts.done = nil
+			return newStringCacheCursor(nil, tail, opts), false
+		}
+		return newStringCacheCursor(a, tail, opts), true
+
+	case cursors.BooleanArrayCursor:
+		a, ok := cached.(booleanCachedArrays)
+		if !ok && cached != nil {
+			opts.done = nil
+			return newBooleanCacheCursor(nil, tail, opts), false
+		}
+		return newBooleanCacheCursor(a, tail, opts), true
+
+	case nil:
+		switch cached := cached.(type) {
+
+		case floatCachedArrays:
+			return newFloatCacheCursor(cached, nil, opts), true
+
+		case integerCachedArrays:
+			return newIntegerCacheCursor(cached, nil, opts), true
+
+		case unsignedCachedArrays:
+			return newUnsignedCacheCursor(cached, nil, opts), true
+
+		case stringCachedArrays:
+			return newStringCacheCursor(cached, nil, opts), true
+
+		case booleanCachedArrays:
+			return newBooleanCacheCursor(cached, nil, opts), true
+
+		}
+	}
+	return nil, true
+}
+
+// floatCachedArrays are the cached float values of a series.
+type floatCachedArrays []*cursors.FloatArray
+
+func (a floatCachedArrays) trim(start int64) cachedArrays {
+	for len(a) > 0 && a[0].MaxTime() < start {
+		a = a[1:]
+	}
+	if len(a) == 0 || a[0].MinTime() >= start {
+		return a
+	}
+
+	// The arrays are shared, so the first one is sliced rather than modified.
+	first := a[0]
+	i := sort.Search(first.Len(), func(i int) bool { return first.Timestamps[i] >= start })
+	trimmed := make(floatCachedArrays, len(a))
+	trimmed[0] = &cursors.FloatArray{
+		Timestamps: first.Timestamps[i:],
+		Values:     first.Values[i:],
+	}
+	copy(trimmed[1:], a[1:])
+	return trimmed
+}
+
+func (a floatCachedArrays) len() int {
+	var n int
+	for _, arr := range a {
+		n += arr.Len()
+	}
+	return n
+}
+
+// floatCacheCursor reads the cached values of a series up to end, followed
+// by the values read from tail, adding those before the watermark to the cache.
+type floatCacheCursor struct {
+	cached floatCachedArrays
+	tail   cursors.FloatArrayCursor
+	opts   cacheCursorOptions
+
+	recorded floatCachedArrays
+	drained  bool
+	closed   bool
+	res      cursors.FloatArray
+}
+
+func newFloatCacheCursor(cached floatCachedArrays, tail cursors.FloatArrayCursor, opts cacheCursorOptions) *floatCacheCursor {
+	c := &floatCacheCursor{
+		cached: cached,
+		tail:   tail,
+		opts:   opts,
+	}
+	if opts.done != nil {
+		c.recorded = append(c.recorded, cached...)
+	}
+	return c
+}
+
+func (c *floatCacheCursor) Next() *cursors.FloatArray {
+	for len(c.cached) > 0 {
+		a := c.cached[0]
+		c.cached = c.cached[1:]
+		if a.MinTime() > c.opts.end {
+			c.cached = nil
+			break
+		}
+
+		// The arrays are shared, so they are sliced rather than returned.
+		if a.MaxTime() <= c.opts.end {
+			return &cursors.FloatArray{
+				Timestamps: a.Timestamps,
+				Values:     a.Values,
+			}
+		}
+		i := sort.Search(a.Len(), func(i int) bool { return a.Timestamps[i] > c.opts.end })
+		c.cached = nil
+		return &cursors.FloatArray{
+			Timestamps: a.Timestamps[:i],
+			Values:     a.Values[:i],
+		}
+	}
+
+	if c.tail == nil {
+		c.drained = true
+		return &c.res
+	}
+
+	a := c.tail.Next()
+	if a.Len() == 0 {
+		c.drained = true
+		return a
+	}
+	if c.opts.done != nil {
+		c.record(a)
+	}
+	return a
+}
+
+// record adds a copy of the values of a before the watermark to the cache, as
+// the arrays of the tail are reused.
+func (c *floatCacheCursor) record(a *cursors.FloatArray) {
+	n := sort.Search(a.Len(), func(i int) bool { return a.Timestamps[i] >= c.opts.watermark })
+	if n == 0 {
+		return
+	}
+
+	r := &cursors.FloatArray{
+		Timestamps: make([]int64, n),
+		Values:     make([]float64, n),
+	}
+	copy(r.Timestamps, a.Timestamps[:n])
+	copy(r.Values, a.Values[:n])
+	c.recorded = append(c.recorded, r)
+}
+
+func (c *floatCacheCursor) Close() {
+	if c.closed {
+		return
+	}
+	c.closed = true
+
+	err := c.Err()
+	if c.tail != nil {
+		c.tail.Close()
+	}
+	if c.opts.done != nil {
+		c.opts.done(c.recorded, c.drained && err == nil)
+	}
+}
+
+func (c *floatCacheCursor) Err() error {
+	if c.tail != nil {
+		return c.tail.Err()
+	}
+	return nil
+}
+
+func (c *floatCacheCursor) Stats() cursors.CursorStats {
+	if c.tail != nil {
+		return c.tail.Stats()
+	}
+	return cursors.CursorStats{}
+}
+
+// integerCachedArrays are the cached integer values of a series.
+type integerCachedArrays []*cursors.IntegerArray
+
+func (a integerCachedArrays) trim(start int64) cachedArrays {
+	for len(a) > 0 && a[0].MaxTime() < start {
+		a = a[1:]
+	}
+	if len(a) == 0 || a[0].MinTime() >= start {
+		return a
+	}
+
+	// The arrays are shared, so the first one is sliced rather than modified.
+	first := a[0]
+	i := sort.Search(first.Len(), func(i int) bool { return first.Timestamps[i] >= start })
+	trimmed := make(integerCachedArrays, len(a))
+	trimmed[0] = &cursors.IntegerArray{
+		Timestamps: first.Timestamps[i:],
+		Values:     first.Values[i:],
+	}
+	copy(trimmed[1:], a[1:])
+	return trimmed
+}
+
+func (a integerCachedArrays) len() int {
+	var n int
+	for _, arr := range a {
+		n += arr.Len()
+	}
+	return n
+}
+
+// integerCacheCursor reads the cached values of a series up to end, followed
+// by the values read from tail, adding those before the watermark to the cache.
+type integerCacheCursor struct {
+	cached integerCachedArrays
+	tail   cursors.IntegerArrayCursor
+	opts   cacheCursorOptions
+
+	recorded integerCachedArrays
+	drained  bool
+	closed   bool
+	res      cursors.IntegerArray
+}
+
+func newIntegerCacheCursor(cached integerCachedArrays, tail cursors.IntegerArrayCursor, opts cacheCursorOptions) *integerCacheCursor {
+	c := &integerCacheCursor{
+		cached: cached,
+		tail:   tail,
+		opts:   opts,
+	}
+	if opts.done != nil {
+		c.recorded = append(c.recorded, cached...)
+	}
+	return c
+}
+
+func (c *integerCacheCursor) Next() *cursors.IntegerArray {
+	for len(c.cached) > 0 {
+		a := c.cached[0]
+		c.cached = c.cached[1:]
+		if a.MinTime() > c.opts.end {
+			c.cached = nil
+			break
+		}
+
+		// The arrays are shared, so they are sliced rather than returned.
+		if a.MaxTime() <= c.opts.end {
+			return &cursors.IntegerArray{
+				Timestamps: a.Timestamps,
+				Values:     a.Values,
+			}
+		}
+		i := sort.Search(a.Len(), func(i int) bool { return a.Timestamps[i] > c.opts.end })
+		c.cached = nil
+		return &cursors.IntegerArray{
+			Timestamps: a.Timestamps[:i],
+			Values:     a.Values[:i],
+		}
+	}
+
+	if c.tail == nil {
+		c.drained = true
+		return &c.res
+	}
+
+	a := c.tail.Next()
+	if a.Len() == 0 {
+		c.drained = true
+		return a
+	}
+	if c.opts.done != nil {
+		c.record(a)
+	}
+	return a
+}
+
+// record adds a copy of the values of a before the watermark to the cache, as
+// the arrays of the tail are reused.
+func (c *integerCacheCursor) record(a *cursors.IntegerArray) {
+	n := sort.Search(a.Len(), func(i int) bool { return a.Timestamps[i] >= c.opts.watermark })
+	if n == 0 {
+		return
+	}
+
+	r := &cursors.IntegerArray{
+		Timestamps: make([]int64, n),
+		Values:     make([]int64, n),
+	}
+	copy(r.Timestamps, a.Timestamps[:n])
+	copy(r.Values, a.Values[:n])
+	c.recorded = append(c.recorded, r)
+}
+
+func (c *integerCacheCursor) Close() {
+	if c.closed {
+		return
+	}
+	c.closed = true
+
+	err := c.Err()
+	if c.tail != nil {
+		c.tail.Close()
+	}
+	if c.opts.done != nil {
+		c.opts.done(c.recorded, c.drained && err == nil)
+	}
+}
+
+func (c *integerCacheCursor) Err() error {
+	if c.tail != nil {
+		return c.tail.Err()
+	}
+	return nil
+}
+
+func (c *integerCacheCursor) Stats() cursors.CursorStats {
+	if c.tail != nil {
+		return c.tail.Stats()
+	}
+	return cursors.CursorStats{}
+}
+
+// unsignedCachedArrays are the cached unsigned values of a series.
+type unsignedCachedArrays []*cursors.UnsignedArray
+
+func (a unsignedCachedArrays) trim(start int64) cachedArrays {
+	for len(a) > 0 && a[0].MaxTime() < start {
+		a = a[1:]
+	}
+	if len(a) == 0 || a[0].MinTime() >= start {
+		return a
+	}
+
+	// The arrays are shared, so the first one is sliced rather than modified.
+	first := a[0]
+	i := sort.Search(first.Len(), func(i int) bool { return first.Timestamps[i] >= start })
+	trimmed := make(unsignedCachedArrays, len(a))
+	trimmed[0] = &cursors.UnsignedArray{
+		Timestamps: first.Timestamps[i:],
+		Values:     first.Values[i:],
+	}
+	copy(trimmed[1:], a[1:])
+	return trimmed
+}
+
+func (a unsignedCachedArrays) len() int {
+	var n int
+	for _, arr := range a {
+		n += arr.Len()
+	}
+	return n
+}
+
+// unsignedCacheCursor reads the cached values of a series up to end, followed
+// by the values read from tail, adding those before the watermark to the cache.
+type unsignedCacheCursor struct {
+	cached unsignedCachedArrays
+	tail   cursors.UnsignedArrayCursor
+	opts   cacheCursorOptions
+
+	recorded unsignedCachedArrays
+	drained  bool
+	closed   bool
+	res      cursors.UnsignedArray
+}
+
+func newUnsignedCacheCursor(cached unsignedCachedArrays, tail cursors.UnsignedArrayCursor, opts cacheCursorOptions) *unsignedCacheCursor {
+	c := &unsignedCacheCursor{
+		cached: cached,
+		tail:   tail,
+		opts:   opts,
+	}
+	if opts.done != nil {
+		c.recorded = append(c.recorded, cached...)
+	}
+	return c
+}
+
+func (c *unsignedCacheCursor) Next() *cursors.UnsignedArray {
+	for len(c.cached) > 0 {
+		a := c.cached[0]
+		c.cached = c.cached[1:]
+		if a.MinTime() > c.opts.end {
+			c.cached = nil
+			break
+		}
+
+		// The arrays are shared, so they are sliced rather than returned.
+		if a.MaxTime() <= c.opts.end {
+			return &cursors.UnsignedArray{
+				Timestamps: a.Timestamps,
+				Values:     a.Values,
+			}
+		}
+		i := sort.Search(a.Len(), func(i int) bool { return a.Timestamps[i] > c.opts.end })
+		c.cached = nil
+		return &cursors.UnsignedArray{
+			Timestamps: a.Timestamps[:i],
+			Values:     a.Values[:i],
+		}
+	}
+
+	if c.tail == nil {
+		c.drained = true
+		return &c.res
+	}
+
+	a := c.tail.Next()
+	if a.Len() == 0 {
+		c.drained = true
+		return a
+	}
+	if c.opts.done != nil {
+		c.record(a)
+	}
+	return a
+}
+
+// record adds a copy of the values of a before the watermark to the cache, as
+// the arrays of the tail are reused.
+func (c *unsignedCacheCursor) record(a *cursors.UnsignedArray) {
+	n := sort.Search(a.Len(), func(i int) bool { return a.Timestamps[i] >= c.opts.watermark })
+	if n == 0 {
+		return
+	}
+
+	r := &cursors.UnsignedArray{
+		Timestamps: make([]int64, n),
+		Values:     make([]uint64, n),
+	}
+	copy(r.Timestamps, a.Timestamps[:n])
+	copy(r.Values, a.Values[:n])
+	c.recorded = append(c.recorded, r)
+}
+
+func (c *unsignedCacheCursor) Close() {
+	if c.closed {
+		return
+	}
+	c.closed = true
+
+	err := c.Err()
+	if c.tail != nil {
+		c.tail.Close()
+	}
+	if c.opts.done != nil {
+		c.opts.done(c.recorded, c.drained && err == nil)
+	}
+}
+
+func (c *unsignedCacheCursor) Err() error {
+	if c.tail != nil {
+		return c.tail.Err()
+	}
+	return nil
+}
+
+func (c *unsignedCacheCursor) Stats() cursors.CursorStats {
+	if c.tail != nil {
+		return c.tail.Stats()
+	}
+	return cursors.CursorStats{}
+}
+
+// stringCachedArrays are the cached string values of a series.
+type stringCachedArrays []*cursors.StringArray
+
+func (a stringCachedArrays) trim(start int64) cachedArrays {
+	for len(a) > 0 && a[0].MaxTime() < start {
+		a = a[1:]
+	}
+	if len(a) == 0 || a[0].MinTime() >= start {
+		return a
+	}
+
+	// The arrays are shared, so the first one is sliced rather than modified.
+	first := a[0]
+	i := sort.Search(first.Len(), func(i int) bool { return first.Timestamps[i] >= start })
+	trimmed := make(stringCachedArrays, len(a))
+	trimmed[0] = &cursors.StringArray{
+		Timestamps: first.Timestamps[i:],
+		Values:     first.Values[i:],
+	}
+	copy(trimmed[1:], a[1:])
+	return trimmed
+}
+
+func (a stringCachedArrays) len() int {
+	var n int
+	for _, arr := range a {
+		n += arr.Len()
+	}
+	return n
+}
+
+// stringCacheCursor reads the cached values of a series up to end, followed
+// by the values read from tail, adding those before the watermark to the cache.
+type stringCacheCursor struct {
+	cached stringCachedArrays
+	tail   cursors.StringArrayCursor
+	opts   cacheCursorOptions
+
+	recorded stringCachedArrays
+	drained  bool
+	closed   bool
+	res      cursors.StringArray
+}
+
+func newStringCacheCursor(cached stringCachedArrays, tail cursors.StringArrayCursor, opts cacheCursorOptions) *stringCacheCursor {
+	c := &stringCacheCursor{
+		cached: cached,
+		tail:   tail,
+		opts:   opts,
+	}
+	if opts.done != nil {
+		c.recorded = append(c.recorded, cached...)
+	}
+	return c
+}
+
+func (c *stringCacheCursor) Next() *cursors.StringArray {
+	for len(c.cached) > 0 {
+		a := c.cached[0]
+		c.cached = c.cached[1:]
+		if a.MinTime() > c.opts.end {
+			c.cached = nil
+			break
+		}
+
+		// The arrays are shared, so they are sliced rather than returned.
+		if a.MaxTime() <= c.opts.end {
+			return &cursors.StringArray{
+				Timestamps: a.Timestamps,
+				Values:     a.Values,
+			}
+		}
+		i := sort.Search(a.Len(), func(i int) bool { return a.Timestamps[i] > c.opts.end })
+		c.cached = nil
+		return &cursors.StringArray{
+			Timestamps: a.Timestamps[:i],
+			Values:     a.Values[:i],
+		}
+	}
+
+	if c.tail == nil {
+		c.drained = true
+		return &c.res
+	}
+
+	a := c.tail.Next()
+	if a.Len() == 0 {
+		c.drained = true
+		return a
+	}
+	if c.opts.done != nil {
+		c.record(a)
+	}
+	return a
+}
+
+// record adds a copy of the values of a before the watermark to the cache, as
+// the arrays of the tail are reused.
+func (c *stringCacheCursor) record(a *cursors.StringArray) {
+	n := sort.Search(a.Len(), func(i int) bool { return a.Timestamps[i] >= c.opts.watermark })
+	if n == 0 {
+		return
+	}
+
+	r := &cursors.StringArray{
+		Timestamps: make([]int64, n),
+		Values:     make([]string, n),
+	}
+	copy(r.Timestamps, a.Timestamps[:n])
+	copy(r.Values, a.Values[:n])
+	c.recorded = append(c.recorded, r)
+}
+
+func (c *stringCacheCursor) Close() {
+	if c.closed {
+		return
+	}
+	c.closed = true
+
+	err := c.Err()
+	if c.tail != nil {
+		c.tail.Close()
+	}
+	if c.opts.done != nil {
+		c.opts.done(c.recorded, c.drained && err == nil)
+	}
+}
+
+func (c *stringCacheCursor) Err() error {
+	if c.tail != nil {
+		return c.tail.Err()
+	}
+	return nil
+}
+
+func (c *stringCacheCursor) Stats() cursors.CursorStats {
+	if c.tail != nil {
+		return c.tail.Stats()
+	}
+	return cursors.CursorStats{}
+}
+
+// booleanCachedArrays are the cached boolean values of a series.
+type booleanCachedArrays []*cursors.BooleanArray
+
+func (a booleanCachedArrays) trim(start int64) cachedArrays {
+	for len(a) > 0 && a[0].MaxTime() < start {
+		a = a[1:]
+	}
+	if len(a) == 0 || a[0].MinTime() >= start {
+		return a
+	}
+
+	// The arrays are shared, so the first one is sliced rather than modified.
+	first := a[0]
+	i := sort.Search(first.Len(), func(i int) bool { return first.Timestamps[i] >= start })
+	trimmed := make(booleanCachedArrays, len(a))
+	trimmed[0] = &cursors.BooleanArray{
+		Timestamps: first.Timestamps[i:],
+		Values:     first.Values[i:],
+	}
+	copy(trimmed[1:], a[1:])
+	return trimmed
+}
+
+func (a booleanCachedArrays) len() int {
+	var n int
+	for _, arr := range a {
+		n += arr.Len()
+	}
+	return n
+}
+
+// booleanCacheCursor reads the cached values of a series up to end, followed
+// by the values read from tail, adding those before the watermark to the cache.
+type booleanCacheCursor struct {
+	cached booleanCachedArrays
+	tail   cursors.BooleanArrayCursor
+	opts   cacheCursorOptions
+
+	recorded booleanCachedArrays
+	drained  bool
+	closed   bool
+	res      cursors.BooleanArray
+}
+
+func newBooleanCacheCursor(cached booleanCachedArrays, tail cursors.BooleanArrayCursor, opts cacheCursorOptions) *booleanCacheCursor {
+	c := &booleanCacheCursor{
+		cached: cached,
+		tail:   tail,
+		opts:   opts,
+	}
+	if opts.done != nil {
+		c.recorded = append(c.recorded, cached...)
+	}
+	return c
+}
+
+func (c *booleanCacheCursor) Next() *cursors.BooleanArray {
+	for len(c.cached) > 0 {
+		a := c.cached[0]
+		c.cached = c.cached[1:]
+		if a.MinTime() > c.opts.end {
+			c.cached = nil
+			break
+		}
+
+		// The arrays are shared, so they are sliced rather than returned.
+		if a.MaxTime() <= c.opts.end {
+			return &cursors.BooleanArray{
+				Timestamps: a.Timestamps,
+				Values:     a.Values,
+			}
+		}
+		i := sort.Search(a.Len(), func(i int) bool { return a.Timestamps[i] > c.opts.end })
+		c.cached = nil
+		return &cursors.BooleanArray{
+			Timestamps: a.Timestamps[:i],
+			Values:     a.Values[:i],
+		}
+	}
+
+	if c.tail == nil {
+		c.drained = true
+		return &c.res
+	}
+
+	a := c.tail.Next()
+	if a.Len() == 0 {
+		c.drained = true
+		return a
+	}
+	if c.opts.done != nil {
+		c.record(a)
+	}
+	return a
+}
+
+// record adds a copy of the values of a before the watermark to the cache, as
+// the arrays of the tail are reused.
+func (c *booleanCacheCursor) record(a *cursors.BooleanArray) {
+	n := sort.Search(a.Len(), func(i int) bool { return a.Timestamps[i] >= c.opts.watermark })
+	if n == 0 {
+		return
+	}
+
+	r := &cursors.BooleanArray{
+		Timestamps: make([]int64, n),
+		Values:     make([]bool, n),
+	}
+	copy(r.Timestamps, a.Timestamps[:n])
+	copy(r.Values, a.Values[:n])
+	c.recorded = append(c.recorded, r)
+}
+
+func (c *booleanCacheCursor) Close() {
+	if c.closed {
+		return
+	}
+	c.closed = true
+
+	err := c.Err()
+	if c.tail != nil {
+		c.tail.Close()
+	}
+	if c.opts.done != nil {
+		c.opts.done(c.recorded, c.drained && err == nil)
+	}
+}
+
+func (c *booleanCacheCursor) Err() error {
+	if c.tail != nil {
+		return c.tail.Err()
+	}
+	return nil
+}
+
+func (c *booleanCacheCursor) Stats() cursors.CursorStats {
+	if c.tail != nil {
+		return c.tail.Stats()
+	}
+	return cursors.CursorStats{}
+}
@@ -0,0 +1,216 @@
+package readservice
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+// series is the data of a series of a mock result set.
+type series struct {
+	host       string
+	timestamps []int64
+}
+
+// resultSet is a mock result set of float series.
+type resultSet struct {
+	series []series
+	i      int
+}
+
+func (r *resultSet) Next() bool {
+	r.i++
+	return r.i <= len(r.series)
+}
+
+func (r *resultSet) Cursor() cursors.Cursor {
+	return &floatCursor{timestamps: r.series[r.i-1].timestamps}
+}
+
+func (r *resultSet) Tags() models.Tags {
+	return models.NewTags(map[string]string{"host": r.series[r.i-1].host})
+}
+
+func (r *resultSet) Close()                     {}
+func (r *resultSet) Err() error                 { return nil }
+func (r *resultSet) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+// floatCursor returns its values two at a time, reusing its array like the
+// engine cursors.
+type floatCursor struct {
+	timestamps []int64
+	res        cursors.FloatArray
+}
+
+func (c *floatCursor) Next() *cursors.FloatArray {
+	n := 2
+	if len(c.timestamps) < n {
+		n = len(c.timestamps)
+	}
+	c.res.Timestamps = append(c.res.Timestamps[:0], c.timestamps[:n]...)
+	c.res.Values = c.res.Values[:0]
+	for _, t := range c.timestamps[:n] {
+		c.res.Values = append(c.res.Values, float64(t))
+	}
+	c.timestamps = c.timestamps[n:]
+	return &c.res
+}
+
+func (c *floatCursor) Close()                     {}
+func (c *floatCursor) Err() error                 { return nil }
+func (c *floatCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+// readAll returns the timestamps of the series of rs by host.
+func readAll(t *testing.T, rs reads.ResultSet) map[string][]int64 {
+	t.Helper()
+
+	got := make(map[string][]int64)
+	for rs.Next() {
+		host := rs.Tags().GetString("host")
+		cur := rs.Cursor().(cursors.FloatArrayCursor)
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, ts := range a.Timestamps {
+				if a.Values[i] != float64(ts) {
+					t.Fatalf("unexpected value %v at %d", a.Values[i], ts)
+				}
+			}
+			got[host] = append(got[host], a.Timestamps...)
+		}
+		cur.Close()
+	}
+	rs.Close()
+	if err := rs.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestResultCache_ReadFilter(t *testing.T) {
+	const (
+		key      = "query"
+		orgID    = influxdb.ID(1)
+		bucketID = influxdb.ID(2)
+	)
+
+	// data is the series of the engine, by host.
+	data := map[string][]int64{
+		"a": {1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		"b": {2, 4, 6, 8},
+	}
+	cache := NewResultCache(100)
+	var ranges []datatypes.TimestampRange
+	read := func(ctx context.Context, req *datatypes.ReadFilterRequest) (reads.ResultSet, error) {
+		ranges = append(ranges, req.Range)
+		rs := &resultSet{}
+		for _, host := range []string{"a", "b"} {
+			s := series{host: host}
+			for _, ts := range data[host] {
+				if ts >= req.Range.Start && ts <= req.Range.End {
+					s.timestamps = append(s.timestamps, ts)
+				}
+			}
+			rs.series = append(rs.series, s)
+		}
+		return rs, nil
+	}
+	readFilter := func(start, end, watermark int64) map[string][]int64 {
+		t.Helper()
+		req := &datatypes.ReadFilterRequest{Range: datatypes.TimestampRange{Start: start, End: end}}
+		rs, err := cache.readFilter(context.Background(), key, orgID, bucketID, req, watermark, read)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return readAll(t, rs)
+	}
+
+	// The first read caches the values before the watermark.
+	if got, exp := readFilter(1, 8, 6), map[string][]int64{"a": {1, 2, 3, 4, 5, 6, 7, 8}, "b": {2, 4, 6, 8}}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got %v, exp %v", got, exp)
+	}
+
+	// The next read only reads the data from the end of the cached data, and
+	// extends the cached data up to the new watermark.
+	ranges = nil
+	if got, exp := readFilter(3, 10, 9), map[string][]int64{"a": {3, 4, 5, 6, 7, 8, 9, 10}, "b": {4, 6, 8}}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got %v, exp %v", got, exp)
+	}
+	if exp := []datatypes.TimestampRange{{Start: 6, End: 10}}; !reflect.DeepEqual(ranges, exp) {
+		t.Fatalf("got reads %v, exp %v", ranges, exp)
+	}
+
+	// A read within the cached data reads nothing from the engine.
+	ranges = nil
+	if got, exp := readFilter(4, 7, 9), map[string][]int64{"a": {4, 5, 6, 7}, "b": {4, 6}}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got %v, exp %v", got, exp)
+	}
+	if len(ranges) != 0 {
+		t.Fatalf("got reads %v, exp none", ranges)
+	}
+
+	// A write evicts the data overlapping it, so the next read reads all of its
+	// range from the engine.
+	data["b"] = []int64{2, 4, 5, 6, 8}
+	cache.BucketRangeChanged(orgID, bucketID, 5, 5)
+
+	ranges = nil
+	if got, exp := readFilter(4, 7, 9), map[string][]int64{"a": {4, 5, 6, 7}, "b": {4, 5, 6}}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got %v, exp %v", got, exp)
+	}
+	if exp := []datatypes.TimestampRange{{Start: 4, End: 7}}; !reflect.DeepEqual(ranges, exp) {
+		t.Fatalf("got reads %v, exp %v", ranges, exp)
+	}
+}
+
+func TestResultCache_BucketRangeChanged(t *testing.T) {
+	const (
+		orgID    = influxdb.ID(1)
+		bucketID = influxdb.ID(2)
+	)
+
+	cache := NewResultCache(100)
+	add := func(key string, start, end int64) {
+		t.Helper()
+		_, b := cache.begin(key, orgID, bucketID, start, end, end+1)
+		if b == nil {
+			t.Fatalf("expected %q to be cached", key)
+		}
+		b.addSeries("series", nil, floatCachedArrays{{Timestamps: []int64{start}, Values: []float64{0}}}, true)
+		b.finish(nil)
+	}
+	add("a", 0, 9)
+	add("b", 10, 19)
+
+	// A change of another bucket, or of another range, evicts nothing.
+	cache.BucketRangeChanged(orgID, 3, 0, 100)
+	cache.BucketRangeChanged(orgID, bucketID, 20, 30)
+	for _, key := range []string{"a", "b"} {
+		if _, ok := cache.entries[key]; !ok {
+			t.Fatalf("expected %q to be cached", key)
+		}
+	}
+
+	// A change during a read discards its data.
+	_, b := cache.begin("c", orgID, bucketID, 20, 29, 30)
+	cache.BucketRangeChanged(orgID, bucketID, 25, 25)
+	b.finish(nil)
+	if _, ok := cache.entries["c"]; ok {
+		t.Fatal("expected c not to be cached")
+	}
+
+	cache.BucketRangeChanged(orgID, bucketID, 9, 9)
+	if _, ok := cache.entries["a"]; ok {
+		t.Fatal("expected a to be evicted")
+	}
+	if _, ok := cache.entries["b"]; !ok {
+		t.Fatal("expected b to be cached")
+	}
+	if cache.values != 1 {
+		t.Fatalf("got %d cached values, exp 1", cache.values)
+	}
+}
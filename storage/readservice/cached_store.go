@@ -0,0 +1,265 @@
+package readservice
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+// cachingStore is a store reading the series of the queries with a cache scope
+// through the cache.
+type cachingStore struct {
+	*store
+	cache *ResultCache
+}
+
+func newCachingStore(s *store, cache *ResultCache) *cachingStore {
+	return &cachingStore{store: s, cache: cache}
+}
+
+func (s *cachingStore) ReadFilter(ctx context.Context, req *datatypes.ReadFilterRequest) (reads.ResultSet, error) {
+	scope, ok := cacheScopeFromContext(ctx)
+	if !ok {
+		return s.store.ReadFilter(ctx, req)
+	}
+
+	if req.ReadSource == nil {
+		return nil, errors.New("missing read source")
+	}
+
+	source, err := getReadSource(*req.ReadSource)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := readFilterCacheKey(scope, source, req.Predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	// The data written before the last snapshot is unlikely to change.
+	watermark := int64(math.MinInt64)
+	if t := s.engine.LastSnapshotTime(); !t.IsZero() {
+		watermark = t.UnixNano()
+	}
+
+	orgID, bucketID := influxdb.ID(source.OrganizationID), influxdb.ID(source.BucketID)
+	return s.cache.readFilter(ctx, key, orgID, bucketID, req, watermark, s.store.ReadFilter)
+}
+
+// readFilter reads the series of req through the cache, reading the data which
+// is not cached with read.
+func (c *ResultCache) readFilter(
+	ctx context.Context,
+	key string,
+	orgID, bucketID influxdb.ID,
+	req *datatypes.ReadFilterRequest,
+	watermark int64,
+	read func(context.Context, *datatypes.ReadFilterRequest) (reads.ResultSet, error),
+) (reads.ResultSet, error) {
+	start, end := req.Range.Start, req.Range.End
+	entry, b := c.begin(key, orgID, bucketID, start, end, watermark)
+
+	rs := &cacheResultSet{
+		start: start,
+		end:   end,
+		entry: entry,
+		b:     b,
+		seen:  make(map[string]struct{}),
+	}
+	if b != nil {
+		rs.watermark = b.entry.end
+	}
+
+	// Only the data more recent than the cached data is read from the engine.
+	if entry == nil || end >= entry.end {
+		tailReq := *req
+		if entry != nil {
+			tailReq.Range.Start = entry.end
+		}
+
+		tail, err := read(ctx, &tailReq)
+		if err != nil {
+			if b != nil {
+				b.finish(err)
+			}
+			return nil, err
+		}
+		rs.rs = tail
+	}
+
+	if rs.rs == nil && entry == nil {
+		if b != nil {
+			b.finish(nil)
+		}
+		return nil, nil
+	}
+	return rs, nil
+}
+
+// readFilterCacheKey returns the key of the data read with predicate from the
+// source by the queries of the cache scope.
+func readFilterCacheKey(scope string, source readSource, predicate *datatypes.Predicate) (string, error) {
+	var p []byte
+	if predicate != nil {
+		var err error
+		if p, err = predicate.Marshal(); err != nil {
+			return "", err
+		}
+	}
+	return scope + "\x00" + influxdb.ID(source.OrganizationID).String() + influxdb.ID(source.BucketID).String() + "\x00" + string(p), nil
+}
+
+// cacheResultSet is the result set of the series read from the engine, followed
+// by the series only found in the cache. The cached data of each series is
+// followed by the data read from the engine.
+type cacheResultSet struct {
+	rs                    reads.ResultSet // nil once all of its series have been read
+	start, end, watermark int64
+
+	entry *cacheEntry   // the cached data, if any
+	b     *entryBuilder // the new cache entry, if any
+	seen  map[string]struct{}
+
+	remaining []*cachedSeries // the series only found in the cache
+	started   bool
+
+	key    string
+	tags   models.Tags
+	series *cachedSeries // the cached data of the current series, if any
+	fromRS bool          // the current series was read from rs
+
+	pending bool // the cursor of the current series has not been created
+	err     error
+	stats   cursors.CursorStats
+	closed  bool
+}
+
+func (r *cacheResultSet) Next() bool {
+	if r.pending && r.b != nil {
+		// The data of the previous series was not read.
+		r.b.incomplete = true
+	}
+	r.pending = false
+
+	if r.rs != nil {
+		if r.rs.Next() {
+			r.tags = r.rs.Tags()
+			r.key = string(r.tags.HashKey())
+			r.series, r.fromRS, r.pending = nil, true, true
+			if r.entry != nil {
+				r.series = r.entry.index[r.key]
+			}
+			r.seen[r.key] = struct{}{}
+			return true
+		}
+
+		r.err = r.rs.Err()
+		r.stats = r.rs.Stats()
+		r.rs.Close()
+		r.rs = nil
+	}
+
+	if r.err != nil {
+		return false
+	}
+
+	if !r.started {
+		r.started = true
+		if r.entry != nil {
+			for _, s := range r.entry.series {
+				if _, ok := r.seen[s.key]; !ok {
+					r.remaining = append(r.remaining, s)
+				}
+			}
+		}
+	}
+	if len(r.remaining) == 0 {
+		return false
+	}
+
+	r.series, r.remaining = r.remaining[0], r.remaining[1:]
+	r.key, r.tags = r.series.key, r.series.tags
+	r.fromRS, r.pending = false, true
+	return true
+}
+
+func (r *cacheResultSet) Cursor() cursors.Cursor {
+	r.pending = false
+
+	var tail cursors.Cursor
+	if r.fromRS {
+		tail = r.rs.Cursor()
+	}
+
+	var cached cachedArrays
+	if r.series != nil {
+		cached = r.series.arrays.trim(r.start)
+	}
+
+	opts := cacheCursorOptions{
+		end:       r.end,
+		watermark: r.watermark,
+	}
+	if r.b != nil {
+		key, tags := r.key, r.tags.Clone()
+		opts.done = func(arrays cachedArrays, drained bool) {
+			r.b.addSeries(key, tags, arrays, drained)
+		}
+	}
+
+	cur, ok := newCacheCursor(cached, tail, opts)
+	if !ok && r.b != nil {
+		// The type of the series changed since it was cached.
+		r.b.incomplete = true
+	}
+	return cur
+}
+
+func (r *cacheResultSet) Tags() models.Tags { return r.tags }
+
+func (r *cacheResultSet) Close() {
+	if r.closed {
+		return
+	}
+	r.closed = true
+
+	if r.rs != nil {
+		r.rs.Close()
+		r.rs = nil
+	}
+	if r.b != nil {
+		if !r.started || r.pending || len(r.remaining) > 0 {
+			// The result set was not entirely read.
+			r.b.incomplete = true
+		}
+		r.b.finish(r.err)
+	}
+}
+
+func (r *cacheResultSet) Err() error { return r.err }
+
+// Stats returns the stats of the data read from the engine, once the result
+// set has been read.
+func (r *cacheResultSet) Stats() cursors.CursorStats { return r.stats }
+
+// cacheCursorOptions configures a cursor reading the cached data of a series,
+// followed by its data read from the engine.
+type cacheCursorOptions struct {
+	// end is the time of the last value read.
+	end int64
+
+	// watermark is the time before which the values read from the engine are
+	// added to the cache.
+	watermark int64
+
+	// done receives the cached data of the series once the cursor is closed,
+	// and whether all of it was read.
+	done func(arrays cachedArrays, drained bool)
+}
@@ -0,0 +1,3 @@
+package readservice
+
+//go:generate env GO111MODULE=on go run github.com/benbjohnson/tmpl -data=@types.tmpldata cache_cursor.gen.go.tmpl
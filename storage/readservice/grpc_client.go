@@ -0,0 +1,174 @@
+package readservice
+
+import (
+	"context"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// GRPCStore is a reads.Store reading from a remote GRPCServer.
+type GRPCStore struct {
+	client datatypes.StorageClient
+}
+
+var _ reads.Store = (*GRPCStore)(nil)
+
+// NewGRPCStore returns a GRPCStore issuing its requests over conn.
+// The connection should be dialed with TokenCredentials.
+func NewGRPCStore(conn *grpc.ClientConn) *GRPCStore {
+	return &GRPCStore{client: datatypes.NewStorageClient(conn)}
+}
+
+// Read reads the series matching req, each series as its own group.
+func (s *GRPCStore) Read(ctx context.Context, req *datatypes.ReadRequest) (reads.ResultSet, error) {
+	r := *req
+	r.Group = datatypes.GroupAll
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := s.client.Read(ctx, &r)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &resultSetStreamReader{
+		ResultSetStreamReader: reads.NewResultSetStreamReader(reads.NewStorageReadClient(stream)),
+		cancel:                cancel,
+	}, nil
+}
+
+// ReadFilter reads the series matching req.
+func (s *GRPCStore) ReadFilter(ctx context.Context, req *datatypes.ReadFilterRequest) (reads.ResultSet, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := s.client.ReadFilter(ctx, req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &resultSetStreamReader{
+		ResultSetStreamReader: reads.NewResultSetStreamReader(reads.NewStorageReadClient(stream)),
+		cancel:                cancel,
+	}, nil
+}
+
+// GroupRead reads the series matching req, grouped by req.Group.
+func (s *GRPCStore) GroupRead(ctx context.Context, req *datatypes.ReadRequest) (reads.GroupResultSet, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := s.client.Read(ctx, req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &groupResultSetStreamReader{
+		GroupResultSetStreamReader: reads.NewGroupResultSetStreamReader(reads.NewStorageReadClient(stream)),
+		cancel:                     cancel,
+	}, nil
+}
+
+// GetSource returns the read source understood by the GRPCServer.
+func (s *GRPCStore) GetSource(orgID, bucketID uint64) proto.Message {
+	return &readSource{
+		BucketID:       bucketID,
+		OrganizationID: orgID,
+	}
+}
+
+// TagKeys reads the tag keys matching req.
+func (s *GRPCStore) TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) (cursors.StringIterator, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := s.client.TagKeys(ctx, req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return newStringIteratorStreamReader(stream, cancel), nil
+}
+
+// TagValues reads the values of the tag key in req.
+func (s *GRPCStore) TagValues(ctx context.Context, req *datatypes.TagValuesRequest) (cursors.StringIterator, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := s.client.TagValues(ctx, req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return newStringIteratorStreamReader(stream, cancel), nil
+}
+
+// resultSetStreamReader cancels the request of the stream when closed,
+// so that the server stops sending a result set that was not read to the end.
+type resultSetStreamReader struct {
+	*reads.ResultSetStreamReader
+	cancel context.CancelFunc
+}
+
+func (r *resultSetStreamReader) Close() {
+	r.ResultSetStreamReader.Close()
+	r.cancel()
+}
+
+// groupResultSetStreamReader is the reads.GroupResultSet equivalent of resultSetStreamReader.
+type groupResultSetStreamReader struct {
+	*reads.GroupResultSetStreamReader
+	cancel context.CancelFunc
+}
+
+func (r *groupResultSetStreamReader) Close() {
+	r.GroupResultSetStreamReader.Close()
+	r.cancel()
+}
+
+// stringIteratorStreamReader releases the request of the stream once it is
+// exhausted and does not report the end of the stream as an error.
+type stringIteratorStreamReader struct {
+	*reads.StringIteratorStreamReader
+	cancel context.CancelFunc
+}
+
+func newStringIteratorStreamReader(stream reads.StringValuesStreamReader, cancel context.CancelFunc) *stringIteratorStreamReader {
+	return &stringIteratorStreamReader{
+		StringIteratorStreamReader: reads.NewStringIteratorStreamReader(stream),
+		cancel:                     cancel,
+	}
+}
+
+func (r *stringIteratorStreamReader) Next() bool {
+	if r.StringIteratorStreamReader.Next() {
+		return true
+	}
+	r.cancel()
+	return false
+}
+
+func (r *stringIteratorStreamReader) Err() error {
+	if err := r.StringIteratorStreamReader.Err(); err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// tokenCredentials attaches a token to the metadata of every request.
+type tokenCredentials struct {
+	token    string
+	insecure bool
+}
+
+// TokenCredentials returns per-RPC credentials authorizing requests to a
+// GRPCServer with token. Unless insecure is set, the token is only sent over
+// a secure transport.
+func TokenCredentials(token string, insecure bool) credentials.PerRPCCredentials {
+	return tokenCredentials{token: token, insecure: insecure}
+}
+
+func (c tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{authorizationKey: tokenScheme + c.token}, nil
+}
+
+func (c tokenCredentials) RequireTransportSecurity() bool {
+	return !c.insecure
+}
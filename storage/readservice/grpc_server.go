@@ -0,0 +1,250 @@
+package readservice
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// authorizationKey is the gRPC metadata key carrying the token of a request.
+	authorizationKey = "authorization"
+
+	// tokenScheme prefixes the token, as it does in the HTTP Authorization header.
+	tokenScheme = "Token "
+)
+
+// GRPCServer exposes a reads.Store as a datatypes.StorageServer.
+// Every request must carry a token with read access to the bucket it reads.
+type GRPCServer struct {
+	Store                reads.Store
+	AuthorizationService influxdb.AuthorizationService
+	Logger               *zap.Logger
+}
+
+// NewGRPCServer returns a GRPCServer serving reads from the storage engine.
+func NewGRPCServer(engine *storage.Engine, authSvc influxdb.AuthorizationService) *GRPCServer {
+	return &GRPCServer{
+		Store:                newStore(engine),
+		AuthorizationService: authSvc,
+		Logger:               zap.NewNop(),
+	}
+}
+
+// Register registers s as the Storage service of srv.
+func (s *GRPCServer) Register(srv *grpc.Server) {
+	datatypes.RegisterStorageServer(srv, s)
+}
+
+// Read streams the series matching req. Requests grouped by anything
+// other than GroupAll are served by the GroupRead method of the store.
+func (s *GRPCServer) Read(req *datatypes.ReadRequest, stream datatypes.Storage_ReadServer) error {
+	ctx := stream.Context()
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if err := s.authorize(ctx, req.ReadSource); err != nil {
+		return err
+	}
+
+	switch req.Group {
+	case datatypes.GroupBy, datatypes.GroupExcept:
+		if len(req.GroupKeys) == 0 {
+			return status.Error(codes.InvalidArgument, "read: GroupKeys must not be empty when GroupBy or GroupExcept specified")
+		}
+	case datatypes.GroupNone, datatypes.GroupAll:
+		if len(req.GroupKeys) > 0 {
+			return status.Error(codes.InvalidArgument, "read: GroupKeys must be empty when GroupNone or GroupAll specified")
+		}
+	}
+
+	w := reads.NewResponseWriter(stream, req.Hints)
+	if req.Group == datatypes.GroupAll {
+		rs, err := s.Store.Read(ctx, req)
+		if err != nil {
+			return s.internalError("read", err)
+		}
+		if rs == nil {
+			return nil
+		}
+		defer rs.Close()
+
+		if err := w.WriteResultSet(rs); err != nil {
+			return err
+		}
+	} else {
+		rs, err := s.Store.GroupRead(ctx, req)
+		if err != nil {
+			return s.internalError("group read", err)
+		}
+		if rs == nil {
+			return nil
+		}
+		defer rs.Close()
+
+		if err := w.WriteGroupResultSet(rs); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Err()
+}
+
+// ReadFilter streams the series matching req without any points aggregation.
+func (s *GRPCServer) ReadFilter(req *datatypes.ReadFilterRequest, stream datatypes.Storage_ReadFilterServer) error {
+	ctx := stream.Context()
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if err := s.authorize(ctx, req.ReadSource); err != nil {
+		return err
+	}
+
+	rs, err := s.Store.ReadFilter(ctx, req)
+	if err != nil {
+		return s.internalError("read filter", err)
+	}
+	if rs == nil {
+		return nil
+	}
+	defer rs.Close()
+
+	w := reads.NewResponseWriter(stream, 0)
+	if err := w.WriteResultSet(rs); err != nil {
+		return err
+	}
+	w.Flush()
+	return w.Err()
+}
+
+// Capabilities returns no capabilities; all requests are served by the store.
+func (s *GRPCServer) Capabilities(ctx context.Context, _ *types.Empty) (*datatypes.CapabilitiesResponse, error) {
+	return &datatypes.CapabilitiesResponse{}, nil
+}
+
+// Hints returns no hints.
+func (s *GRPCServer) Hints(ctx context.Context, _ *types.Empty) (*datatypes.HintsResponse, error) {
+	return &datatypes.HintsResponse{}, nil
+}
+
+// TagKeys streams the tag keys matching req.
+func (s *GRPCServer) TagKeys(req *datatypes.TagKeysRequest, stream datatypes.Storage_TagKeysServer) error {
+	ctx := stream.Context()
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if err := s.authorize(ctx, req.TagsSource); err != nil {
+		return err
+	}
+
+	iter, err := s.Store.TagKeys(ctx, req)
+	if err != nil {
+		return s.internalError("tag keys", err)
+	}
+	if iter == nil {
+		return nil
+	}
+
+	w := reads.NewStringIteratorWriter(stream)
+	if err := w.WriteStringIterator(iter); err != nil {
+		return err
+	}
+	w.Flush()
+	return w.Err()
+}
+
+// TagValues streams the values of the tag key in req.
+func (s *GRPCServer) TagValues(req *datatypes.TagValuesRequest, stream datatypes.Storage_TagValuesServer) error {
+	ctx := stream.Context()
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if err := s.authorize(ctx, req.TagsSource); err != nil {
+		return err
+	}
+
+	iter, err := s.Store.TagValues(ctx, req)
+	if err != nil {
+		return s.internalError("tag values", err)
+	}
+	if iter == nil {
+		return nil
+	}
+
+	w := reads.NewStringIteratorWriter(stream)
+	if err := w.WriteStringIterator(iter); err != nil {
+		return err
+	}
+	w.Flush()
+	return w.Err()
+}
+
+// authorize verifies that the token of the incoming request grants read
+// access to the bucket identified by source.
+func (s *GRPCServer) authorize(ctx context.Context, source *types.Any) error {
+	if source == nil {
+		return status.Error(codes.InvalidArgument, "missing read source")
+	}
+	src, err := getReadSource(*source)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid read source: %v", err)
+	}
+
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	a, err := s.AuthorizationService.FindAuthorizationByToken(ctx, token)
+	if err != nil {
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			return status.Error(codes.Unauthenticated, "token is invalid")
+		}
+		return s.internalError("authorize", err)
+	}
+	if !a.IsActive() {
+		return status.Error(codes.Unauthenticated, "token is inactive")
+	}
+
+	p, err := influxdb.NewPermissionAtID(influxdb.ID(src.BucketID), influxdb.ReadAction, influxdb.BucketsResourceType, influxdb.ID(src.OrganizationID))
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if !a.Allowed(*p) {
+		return status.Errorf(codes.PermissionDenied, "token is not authorized to %s", p)
+	}
+	return nil
+}
+
+// internalError logs err and converts it to a gRPC status error.
+func (s *GRPCServer) internalError(op string, err error) error {
+	s.Logger.Info("Failed to serve storage request", zap.String("op", op), zap.Error(err))
+	return status.Error(codes.Internal, err.Error())
+}
+
+// tokenFromContext returns the token of the authorization metadata of an incoming request.
+func tokenFromContext(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "authorization metadata is missing")
+	}
+	vs := md.Get(authorizationKey)
+	if len(vs) == 0 {
+		return "", status.Error(codes.Unauthenticated, "authorization metadata is missing")
+	}
+	if !strings.HasPrefix(vs[0], tokenScheme) {
+		return "", status.Error(codes.Unauthenticated, "authorization scheme is invalid")
+	}
+	return vs[0][len(tokenScheme):], nil
+}
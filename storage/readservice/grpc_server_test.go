@@ -0,0 +1,113 @@
+package readservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCServer_authorize(t *testing.T) {
+	const (
+		orgID    = influxdb.ID(1)
+		bucketID = influxdb.ID(2)
+	)
+
+	readBucket, err := influxdb.NewPermissionAtID(bucketID, influxdb.ReadAction, influxdb.BucketsResourceType, orgID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeBucket, err := influxdb.NewPermissionAtID(bucketID, influxdb.WriteAction, influxdb.BucketsResourceType, orgID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auths := map[string]*influxdb.Authorization{
+		"reader":   {Status: influxdb.Active, Permissions: []influxdb.Permission{*readBucket}},
+		"writer":   {Status: influxdb.Active, Permissions: []influxdb.Permission{*writeBucket}},
+		"inactive": {Status: influxdb.Inactive, Permissions: []influxdb.Permission{*readBucket}},
+	}
+
+	authSvc := mock.NewAuthorizationService()
+	authSvc.FindAuthorizationByTokenFn = func(ctx context.Context, token string) (*influxdb.Authorization, error) {
+		if a, ok := auths[token]; ok {
+			return a, nil
+		}
+		return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "authorization not found"}
+	}
+
+	source, err := types.MarshalAny(&readSource{OrganizationID: uint64(orgID), BucketID: uint64(bucketID)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		md     metadata.MD
+		source *types.Any
+		code   codes.Code
+	}{
+		{
+			name:   "authorized",
+			md:     metadata.Pairs(authorizationKey, "Token reader"),
+			source: source,
+			code:   codes.OK,
+		},
+		{
+			name:   "missing source",
+			md:     metadata.Pairs(authorizationKey, "Token reader"),
+			source: nil,
+			code:   codes.InvalidArgument,
+		},
+		{
+			name:   "missing token",
+			source: source,
+			code:   codes.Unauthenticated,
+		},
+		{
+			name:   "bad scheme",
+			md:     metadata.Pairs(authorizationKey, "Bearer reader"),
+			source: source,
+			code:   codes.Unauthenticated,
+		},
+		{
+			name:   "unknown token",
+			md:     metadata.Pairs(authorizationKey, "Token nobody"),
+			source: source,
+			code:   codes.Unauthenticated,
+		},
+		{
+			name:   "inactive token",
+			md:     metadata.Pairs(authorizationKey, "Token inactive"),
+			source: source,
+			code:   codes.Unauthenticated,
+		},
+		{
+			name:   "no read permission",
+			md:     metadata.Pairs(authorizationKey, "Token writer"),
+			source: source,
+			code:   codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &GRPCServer{AuthorizationService: authSvc}
+
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			err := s.authorize(ctx, tt.source)
+			if got := status.Code(err); got != tt.code {
+				t.Errorf("unexpected status code; got %v, exp %v: %v", got, tt.code, err)
+			}
+		})
+	}
+}
@@ -18,6 +18,21 @@ func NewProxyQueryService(queryController *pcontrol.Controller) query.ProxyQuery
 	}
 }
 
+// DependenciesOption configures the dependencies set up by AddControllerConfigDependencies.
+type DependenciesOption func(*dependenciesConfig)
+
+type dependenciesConfig struct {
+	cache *ResultCache
+}
+
+// WithResultCache makes the "from" flux function read the data through cache,
+// for the queries of a CachingProxyQueryService.
+func WithResultCache(cache *ResultCache) DependenciesOption {
+	return func(c *dependenciesConfig) {
+		c.cache = cache
+	}
+}
+
 // AddControllerConfigDependencies sets up the dependencies on cc
 // such that "from" and "to" flux functions will work correctly.
 func AddControllerConfigDependencies(
@@ -25,11 +40,22 @@ func AddControllerConfigDependencies(
 	engine *storage.Engine,
 	bucketSvc platform.BucketService,
 	orgSvc platform.OrganizationService,
+	options ...DependenciesOption,
 ) error {
+	var config dependenciesConfig
+	for _, option := range options {
+		option(&config)
+	}
+
+	var s reads.Store = newStore(engine)
+	if config.cache != nil {
+		s = newCachingStore(newStore(engine), config.cache)
+	}
+
 	bucketLookupSvc := query.FromBucketService(bucketSvc)
 	orgLookupSvc := query.FromOrganizationService(orgSvc)
 	err := influxdb.InjectFromDependencies(cc.ExecutorDependencies, influxdb.Dependencies{
-		Reader:             reads.NewReader(newStore(engine)),
+		Reader:             reads.NewReader(s),
 		BucketLookup:       bucketLookupSvc,
 		OrganizationLookup: orgLookupSvc,
 	})
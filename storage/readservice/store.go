@@ -149,21 +149,9 @@ func (s *store) TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) (cur
 		req.Range.End = models.MaxNanoTime
 	}
 
-	var expr influxql.Expr
-	var err error
-	if root := req.Predicate.GetRoot(); root != nil {
-		expr, err = reads.NodeToExpr(root, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		if found := reads.HasFieldValueKey(expr); found {
-			return nil, errors.New("field values unsupported")
-		}
-		expr = influxql.Reduce(influxql.CloneExpr(expr), nil)
-		if reads.IsTrueBooleanLiteral(expr) {
-			expr = nil
-		}
+	expr, err := tagPredicateExpr(req.Predicate)
+	if err != nil {
+		return nil, err
 	}
 
 	readSource, err := getReadSource(*req.TagsSource)
@@ -192,21 +180,9 @@ func (s *store) TagValues(ctx context.Context, req *datatypes.TagValuesRequest)
 		return nil, errors.New("missing tag key")
 	}
 
-	var expr influxql.Expr
-	var err error
-	if root := req.Predicate.GetRoot(); root != nil {
-		expr, err = reads.NodeToExpr(root, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		if found := reads.HasFieldValueKey(expr); found {
-			return nil, errors.New("field values unsupported")
-		}
-		expr = influxql.Reduce(influxql.CloneExpr(expr), nil)
-		if reads.IsTrueBooleanLiteral(expr) {
-			expr = nil
-		}
+	expr, err := tagPredicateExpr(req.Predicate)
+	if err != nil {
+		return nil, err
 	}
 
 	readSource, err := getReadSource(*req.TagsSource)
@@ -216,6 +192,67 @@ func (s *store) TagValues(ctx context.Context, req *datatypes.TagValuesRequest)
 	return s.engine.TagValues(ctx, influxdb.ID(readSource.OrganizationID), influxdb.ID(readSource.BucketID), req.TagKey, req.Range.Start, req.Range.End, expr)
 }
 
+// MeasurementFields returns the fields of the series selected by req.
+func (s *store) MeasurementFields(ctx context.Context, req *datatypes.TagKeysRequest) ([]reads.MeasurementField, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if req.TagsSource == nil {
+		return nil, errors.New("missing tags source")
+	}
+
+	if req.Range.Start == 0 {
+		req.Range.Start = models.MinNanoTime
+	}
+	if req.Range.End == 0 {
+		req.Range.End = models.MaxNanoTime
+	}
+
+	expr, err := tagPredicateExpr(req.Predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	readSource, err := getReadSource(*req.TagsSource)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldTypes, err := s.engine.MeasurementFields(ctx, influxdb.ID(readSource.OrganizationID), influxdb.ID(readSource.BucketID), req.Range.Start, req.Range.End, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]reads.MeasurementField, len(fieldTypes))
+	for i, t := range fieldTypes {
+		fields[i] = reads.MeasurementField{Measurement: t.Measurement, Field: t.Field, Type: t.Type}
+	}
+	return fields, nil
+}
+
+// tagPredicateExpr converts a predicate on tags to an expression, which is nil
+// if the predicate is empty or always true.
+func tagPredicateExpr(predicate *datatypes.Predicate) (influxql.Expr, error) {
+	root := predicate.GetRoot()
+	if root == nil {
+		return nil, nil
+	}
+
+	expr, err := reads.NodeToExpr(root, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if found := reads.HasFieldValueKey(expr); found {
+		return nil, errors.New("field values unsupported")
+	}
+	expr = influxql.Reduce(influxql.CloneExpr(expr), nil)
+	if reads.IsTrueBooleanLiteral(expr) {
+		expr = nil
+	}
+	return expr, nil
+}
+
 // this is easier than fooling around with .proto files.
 
 type readSource struct {
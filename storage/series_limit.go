@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// An OrganizationFinder is responsible for providing access to organizations by ID.
+type OrganizationFinder interface {
+	FindOrganizationByID(context.Context, influxdb.ID) (*influxdb.Organization, error)
+}
+
+// A SeriesLimitInvalidator discards the series limits it cached for the buckets.
+// It must be called after a bucket or an organization has been created or updated.
+type SeriesLimitInvalidator interface {
+	InvalidateSeriesLimits()
+}
+
+// WithSeriesLimits enforces the series cardinality limits of the buckets, and the
+// default limits of their organizations, when writing points to the engine.
+//
+// The limits are cached until InvalidateSeriesLimits is called, which the
+// BucketService and OrganizationService of the engine do on updates.
+func WithSeriesLimits(buckets BucketFinder, orgs OrganizationFinder) Option {
+	return func(e *Engine) {
+		e.seriesLimiter = &seriesLimiter{
+			BucketService:       buckets,
+			OrganizationService: orgs,
+			limits:              make(map[influxdb.ID]int64),
+		}
+	}
+}
+
+// InvalidateSeriesLimits discards the cached series limits of the buckets, which
+// are looked up again by the next writes.
+func (e *Engine) InvalidateSeriesLimits() {
+	if e.seriesLimiter != nil {
+		e.seriesLimiter.invalidate()
+	}
+}
+
+// The seriesLimiter looks up the maximum number of series of buckets.
+type seriesLimiter struct {
+	BucketService       BucketFinder
+	OrganizationService OrganizationFinder
+
+	mu     sync.RWMutex
+	limits map[influxdb.ID]int64 // keyed by bucket ID
+}
+
+// MaxSeries returns the maximum number of series of the bucket, which defaults
+// to the limit of its organization. A limit of zero means the number of series
+// is not limited, which is also the case of the buckets without a record, such
+// as the system buckets.
+func (l *seriesLimiter) MaxSeries(ctx context.Context, orgID, bucketID influxdb.ID) (int64, error) {
+	l.mu.RLock()
+	limit, ok := l.limits[bucketID]
+	l.mu.RUnlock()
+	if ok {
+		return limit, nil
+	}
+
+	limit, err := l.findMaxSeries(ctx, orgID, bucketID)
+	if err != nil {
+		return 0, err
+	}
+
+	l.mu.Lock()
+	l.limits[bucketID] = limit
+	l.mu.Unlock()
+	return limit, nil
+}
+
+func (l *seriesLimiter) findMaxSeries(ctx context.Context, orgID, bucketID influxdb.ID) (int64, error) {
+	buckets, _, err := l.BucketService.FindBuckets(ctx, influxdb.BucketFilter{ID: &bucketID})
+	if influxdb.ErrorCode(err) == influxdb.ENotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	} else if len(buckets) == 0 {
+		return 0, nil
+	} else if buckets[0].MaxSeries > 0 {
+		return buckets[0].MaxSeries, nil
+	}
+
+	org, err := l.OrganizationService.FindOrganizationByID(ctx, orgID)
+	if influxdb.ErrorCode(err) == influxdb.ENotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return org.MaxSeries, nil
+}
+
+func (l *seriesLimiter) invalidate() {
+	l.mu.Lock()
+	l.limits = make(map[influxdb.ID]int64)
+	l.mu.Unlock()
+}
+
+// seriesQuota is the number of series which may still be created in a bucket.
+type seriesQuota struct {
+	bucketID  influxdb.ID
+	limit     int64 // zero if the number of series is not limited
+	counted   bool  // available has been computed from the series of the bucket
+	available int64
+}
+
+// limitSeries drops the points of the collection which would create new series
+// in buckets that have reached their maximum number of series. The limits are not
+// enforced atomically, so concurrent writes may exceed them by a few series.
+func (e *Engine) limitSeries(ctx context.Context, collection *tsdb.SeriesCollection) error {
+	if e.seriesLimiter == nil {
+		return nil
+	}
+
+	quotas := make(map[string]*seriesQuota)
+	created := make(map[string]struct{})
+
+	// The cardinality of the buckets is only computed when a batch creates new
+	// series in a limited bucket.
+	var stats map[string]int
+
+	j := 0
+	for iter := collection.Iterator(); iter.Next(); {
+		name := iter.Name()
+
+		quota, ok := quotas[string(name)]
+		if !ok {
+			quota = &seriesQuota{}
+
+			var encoded [16]byte
+			if len(name) == len(encoded) {
+				copy(encoded[:], name)
+				orgID, bucketID := tsdb.DecodeName(encoded)
+
+				limit, err := e.seriesLimiter.MaxSeries(ctx, orgID, bucketID)
+				if err != nil {
+					return err
+				}
+				quota.bucketID, quota.limit = bucketID, limit
+			}
+			quotas[string(name)] = quota
+		}
+
+		// Points of existing series, or of series already created by this batch,
+		// are always written.
+		if _, ok := created[string(iter.Key())]; ok || quota.limit == 0 || e.sfile.HasSeries(name, iter.Tags(), nil) {
+			collection.Copy(j, iter.Index())
+			j++
+			continue
+		}
+
+		if !quota.counted {
+			if stats == nil {
+				stats = e.index.MeasurementCardinalityStats()
+			}
+			quota.counted = true
+			if quota.available = quota.limit - int64(stats[string(name)]); quota.available < 0 {
+				quota.available = 0
+			}
+		}
+
+		if quota.available == 0 {
+			collection.Drop(iter.Index(), fmt.Sprintf("max series exceeded for bucket %s", quota.bucketID))
+			continue
+		}
+
+		quota.available--
+		created[string(iter.Key())] = struct{}{}
+		collection.Copy(j, iter.Index())
+		j++
+	}
+	collection.Truncate(j)
+
+	return nil
+}
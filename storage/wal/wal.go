@@ -63,6 +63,10 @@ const (
 
 	// DeleteBucketRangeWALEntryType indicates a delete bucket range entry.
 	DeleteBucketRangeWALEntryType WalEntryType = 0x04
+
+	// DeleteBucketRangePredicateWALEntryType indicates a delete bucket range
+	// entry restricted to the series matching a predicate.
+	DeleteBucketRangePredicateWALEntryType WalEntryType = 0x05
 )
 
 var (
@@ -538,6 +542,28 @@ func (l *WAL) DeleteBucketRange(orgID, bucketID influxdb.ID, min, max int64) (in
 	return id, nil
 }
 
+// DeleteBucketRangePredicate deletes the data inside of the bucket between the two times
+// for the series matching the predicate, returning the segment ID for the operation.
+func (l *WAL) DeleteBucketRangePredicate(orgID, bucketID influxdb.ID, min, max int64, pred []byte) (int, error) {
+	if !l.enabled {
+		return -1, nil
+	}
+
+	entry := &DeleteBucketRangePredicateWALEntry{
+		OrgID:     orgID,
+		BucketID:  bucketID,
+		Min:       min,
+		Max:       max,
+		Predicate: pred,
+	}
+
+	id, err := l.writeToLog(entry)
+	if err != nil {
+		return -1, err
+	}
+	return id, nil
+}
+
 // Close will finish any flush that is currently in progress and close file handles.
 func (l *WAL) Close() error {
 	l.mu.Lock()
@@ -1056,6 +1082,81 @@ func (w *DeleteBucketRangeWALEntry) Type() WalEntryType {
 	return DeleteBucketRangeWALEntryType
 }
 
+// DeleteBucketRangePredicateWALEntry represents the deletion of data in a bucket
+// for the series matching a predicate.
+type DeleteBucketRangePredicateWALEntry struct {
+	OrgID     influxdb.ID
+	BucketID  influxdb.ID
+	Min, Max  int64
+	Predicate []byte
+}
+
+// MarshalBinary returns a binary representation of the entry in a new byte slice.
+func (w *DeleteBucketRangePredicateWALEntry) MarshalBinary() ([]byte, error) {
+	b := make([]byte, w.MarshalSize())
+	return w.Encode(b)
+}
+
+// UnmarshalBinary deserializes the byte slice into w.
+func (w *DeleteBucketRangePredicateWALEntry) UnmarshalBinary(b []byte) error {
+	if len(b) < 2*influxdb.IDLength+16 {
+		return ErrWALCorrupt
+	}
+
+	if err := w.OrgID.Decode(b[0:influxdb.IDLength]); err != nil {
+		return err
+	}
+	if err := w.BucketID.Decode(b[influxdb.IDLength : 2*influxdb.IDLength]); err != nil {
+		return err
+	}
+	w.Min = int64(binary.BigEndian.Uint64(b[2*influxdb.IDLength : 2*influxdb.IDLength+8]))
+	w.Max = int64(binary.BigEndian.Uint64(b[2*influxdb.IDLength+8 : 2*influxdb.IDLength+16]))
+
+	w.Predicate = nil
+	if pred := b[2*influxdb.IDLength+16:]; len(pred) > 0 {
+		w.Predicate = make([]byte, len(pred))
+		copy(w.Predicate, pred)
+	}
+
+	return nil
+}
+
+// MarshalSize returns the number of bytes the entry takes when marshaled.
+func (w *DeleteBucketRangePredicateWALEntry) MarshalSize() int {
+	return 2*influxdb.IDLength + 16 + len(w.Predicate)
+}
+
+// Encode converts the entry into a byte stream using b if it is large enough.
+// If b is too small, a newly allocated slice is returned.
+func (w *DeleteBucketRangePredicateWALEntry) Encode(b []byte) ([]byte, error) {
+	sz := w.MarshalSize()
+	if len(b) < sz {
+		b = make([]byte, sz)
+	}
+
+	orgID, err := w.OrgID.Encode()
+	if err != nil {
+		return nil, err
+	}
+	bucketID, err := w.BucketID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	copy(b, orgID)
+	copy(b[influxdb.IDLength:], bucketID)
+	binary.BigEndian.PutUint64(b[2*influxdb.IDLength:], uint64(w.Min))
+	binary.BigEndian.PutUint64(b[2*influxdb.IDLength+8:], uint64(w.Max))
+	copy(b[2*influxdb.IDLength+16:], w.Predicate)
+
+	return b[:sz], nil
+}
+
+// Type returns DeleteBucketRangePredicateWALEntryType.
+func (w *DeleteBucketRangePredicateWALEntry) Type() WalEntryType {
+	return DeleteBucketRangePredicateWALEntryType
+}
+
 // WALSegmentWriter writes WAL segments.
 type WALSegmentWriter struct {
 	bw   *bufio.Writer
@@ -1201,6 +1302,8 @@ func (r *WALSegmentReader) Next() bool {
 		}
 	case DeleteBucketRangeWALEntryType:
 		r.entry = &DeleteBucketRangeWALEntry{}
+	case DeleteBucketRangePredicateWALEntryType:
+		r.entry = &DeleteBucketRangePredicateWALEntry{}
 	default:
 		r.err = fmt.Errorf("unknown wal entry type: %v", entryType)
 		return true
@@ -471,6 +471,32 @@ func TestDeleteBucketRangeWALEntry_UnmarshalBinary(t *testing.T) {
 	}
 }
 
+func TestDeleteBucketRangePredicateWALEntry_UnmarshalBinary(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		in := &DeleteBucketRangePredicateWALEntry{
+			OrgID:     influxdb.ID(rand.Int63()) + 1,
+			BucketID:  influxdb.ID(rand.Int63()) + 1,
+			Min:       rand.Int63(),
+			Max:       rand.Int63(),
+			Predicate: []byte(fmt.Sprintf("host = 'server%d'", rand.Int())),
+		}
+
+		b, err := in.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error, got %v", err)
+		}
+
+		out := &DeleteBucketRangePredicateWALEntry{}
+		if err := out.UnmarshalBinary(b); err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		if !reflect.DeepEqual(in, out) {
+			t.Errorf("got %+v, expected %+v", out, in)
+		}
+	}
+}
+
 func TestWriteWALSegment_UnmarshalBinary_DeleteBucketRangeWALCorrupt(t *testing.T) {
 	w := &DeleteBucketRangeWALEntry{
 		OrgID:    influxdb.ID(1),
@@ -20,6 +20,10 @@ const (
 
 	TaskStatusActive   = "active"
 	TaskStatusInactive = "inactive"
+
+	BackfillStatusActive   = "active"
+	BackfillStatusCanceled = "canceled"
+	BackfillStatusDone     = "done"
 )
 
 // Task is a task. 🎊
@@ -37,6 +41,10 @@ type Task struct {
 	LatestCompleted string `json:"latestCompleted,omitempty"`
 	CreatedAt       string `json:"createdAt,omitempty"`
 	UpdatedAt       string `json:"updatedAt,omitempty"`
+
+	// TriggerTaskID is the ID of the task whose successful runs trigger a run of this task,
+	// scheduled for the same time. A triggered task does not run on its own schedule.
+	TriggerTaskID ID `json:"triggerTaskID,omitempty"`
 }
 
 // EffectiveCron returns the effective cron string of the options.
@@ -63,7 +71,23 @@ type Run struct {
 	StartedAt    string `json:"startedAt,omitempty"`
 	FinishedAt   string `json:"finishedAt,omitempty"`
 	RequestedAt  string `json:"requestedAt,omitempty"`
+	BackfillID   ID     `json:"backfillID,omitempty"`
 	Log          []Log  `json:"log"`
+
+	// Statistics are the statistics of the query of a successful run.
+	Statistics *RunStatistics `json:"statistics,omitempty"`
+}
+
+// RunStatistics are the statistics of the execution of the query of a run.
+type RunStatistics struct {
+	CompileDuration time.Duration `json:"compileDuration"`
+	ExecuteDuration time.Duration `json:"executeDuration"`
+	// MaxAllocated is the maximum number of bytes allocated by the query.
+	MaxAllocated int64 `json:"maxAllocated"`
+	// PointsRead is the number of values read from storage.
+	PointsRead int64 `json:"pointsRead"`
+	// RowsWritten is the number of rows written by the to() calls of the query.
+	RowsWritten int64 `json:"rowsWritten"`
 }
 
 // ScheduledForTime gives the time.Time that the run is scheduled for.
@@ -81,6 +105,23 @@ func (r *Run) RequestedAtTime() (time.Time, error) {
 	return time.Parse(time.RFC3339, r.RequestedAt)
 }
 
+// Backfill is a request to run a task for each time it is scheduled for within a range.
+type Backfill struct {
+	ID          ID     `json:"id"`
+	TaskID      ID     `json:"taskID"`
+	Status      string `json:"status"`
+	Start       string `json:"start"`
+	Stop        string `json:"stop"`
+	RequestedAt string `json:"requestedAt"`
+
+	// Runs is the number of runs the backfill queued.
+	Runs int `json:"runs"`
+
+	// Queued and Running are the number of runs of the backfill not yet started, or not yet finished.
+	Queued  int `json:"queued"`
+	Running int `json:"running"`
+}
+
 // Log represents a link to a log resource
 type Log struct {
 	Time    string `json:"time"`
@@ -128,6 +169,16 @@ type TaskService interface {
 	// ForceRun forces a run to occur with unix timestamp scheduledFor, to be executed as soon as possible.
 	// The value of scheduledFor may or may not align with the task's schedule.
 	ForceRun(ctx context.Context, taskID ID, scheduledFor int64) (*Run, error)
+
+	// BackfillTask queues a run for each time within the unix timestamps start and stop that the task is scheduled for,
+	// to be executed as the concurrency of the task allows.
+	BackfillTask(ctx context.Context, taskID ID, start, stop int64) (*Backfill, error)
+
+	// FindBackfills returns the backfills of a task.
+	FindBackfills(ctx context.Context, taskID ID) ([]*Backfill, error)
+
+	// CancelBackfill removes the queued runs of a backfill, and cancels its runs in progress.
+	CancelBackfill(ctx context.Context, taskID, backfillID ID) error
 }
 
 // TaskCreate is the set of values to create a task.
@@ -137,6 +188,7 @@ type TaskCreate struct {
 	OrganizationID ID     `json:"orgID,omitempty"`
 	Organization   string `json:"org,omitempty"`
 	Token          string `json:"token,omitempty"`
+	TriggerTaskID  ID     `json:"triggerTaskID,omitempty"`
 }
 
 func (t TaskCreate) Validate() error {
@@ -164,6 +216,10 @@ type TaskUpdate struct {
 
 	// Optional token override.
 	Token string `json:"token,omitempty"`
+
+	// TriggerTaskID optionally sets the task triggering the task.
+	// An invalid ID removes the trigger of the task.
+	TriggerTaskID *ID `json:"-"`
 }
 
 func (t *TaskUpdate) UnmarshalJSON(data []byte) error {
@@ -189,6 +245,9 @@ func (t *TaskUpdate) UnmarshalJSON(data []byte) error {
 		Retry *int64 `json:"retry,omitempty"`
 
 		Token string `json:"token,omitempty"`
+
+		// TriggerTaskID is the ID of the task triggering the task, or the empty string to remove the trigger.
+		TriggerTaskID *string `json:"triggerTaskID,omitempty"`
 	}{}
 
 	if err := json.Unmarshal(data, &jo); err != nil {
@@ -206,6 +265,15 @@ func (t *TaskUpdate) UnmarshalJSON(data []byte) error {
 	t.Flux = jo.Flux
 	t.Status = jo.Status
 	t.Token = jo.Token
+	if jo.TriggerTaskID != nil {
+		var id ID
+		if *jo.TriggerTaskID != "" {
+			if err := id.DecodeFromString(*jo.TriggerTaskID); err != nil {
+				return err
+			}
+		}
+		t.TriggerTaskID = &id
+	}
 
 	return nil
 }
@@ -230,6 +298,8 @@ func (t TaskUpdate) MarshalJSON() ([]byte, error) {
 		Retry *int64 `json:"retry,omitempty"`
 
 		Token string `json:"token,omitempty"`
+
+		TriggerTaskID *string `json:"triggerTaskID,omitempty"`
 	}{}
 	jo.Name = t.Options.Name
 	jo.Cron = t.Options.Cron
@@ -243,6 +313,13 @@ func (t TaskUpdate) MarshalJSON() ([]byte, error) {
 	jo.Flux = t.Flux
 	jo.Status = t.Status
 	jo.Token = t.Token
+	if t.TriggerTaskID != nil {
+		var id string
+		if t.TriggerTaskID.Valid() {
+			id = t.TriggerTaskID.String()
+		}
+		jo.TriggerTaskID = &id
+	}
 	return json.Marshal(jo)
 }
 
@@ -250,7 +327,7 @@ func (t TaskUpdate) Validate() error {
 	switch {
 	case !t.Options.Every.IsZero() && t.Options.Cron != "":
 		return errors.New("cannot specify both every and cron")
-	case t.Flux == nil && t.Status == nil && t.Options.IsZero() && t.Token == "":
+	case t.Flux == nil && t.Status == nil && t.Options.IsZero() && t.Token == "" && t.TriggerTaskID == nil:
 		return errors.New("cannot update task without content")
 	case t.Status != nil && *t.Status != TaskStatusActive && *t.Status != TaskStatusInactive:
 		return fmt.Errorf("invalid task status: %q", *t.Status)
@@ -68,6 +68,14 @@ func (as *AnalyticalStorage) FinishRun(ctx context.Context, taskID, runID influx
 		}
 		fields[logField] = string(logBytes)
 
+		if stats := run.Statistics; stats != nil {
+			fields[compileDurationField] = int64(stats.CompileDuration)
+			fields[executeDurationField] = int64(stats.ExecuteDuration)
+			fields[maxAllocatedField] = stats.MaxAllocated
+			fields[pointsReadField] = stats.PointsRead
+			fields[rowsWrittenField] = stats.RowsWritten
+		}
+
 		point, err := models.NewPoint("runs", tags, fields, startedAt)
 		if err != nil {
 			return run, err
@@ -312,6 +320,27 @@ func (re *runReader) readRuns(cr flux.ColReader) error {
 				if err != nil {
 					return err
 				}
+			case compileDurationField, executeDurationField, maxAllocatedField, pointsReadField, rowsWrittenField:
+				// The runs written before the statistics were kept have none.
+				if col.Type != flux.TInt || !cr.Ints(j).IsValid(i) {
+					continue
+				}
+				if r.Statistics == nil {
+					r.Statistics = &influxdb.RunStatistics{}
+				}
+				v := cr.Ints(j).Value(i)
+				switch col.Label {
+				case compileDurationField:
+					r.Statistics.CompileDuration = time.Duration(v)
+				case executeDurationField:
+					r.Statistics.ExecuteDuration = time.Duration(v)
+				case maxAllocatedField:
+					r.Statistics.MaxAllocated = v
+				case pointsReadField:
+					r.Statistics.PointsRead = v
+				case rowsWrittenField:
+					r.Statistics.RowsWritten = v
+				}
 			}
 
 		}
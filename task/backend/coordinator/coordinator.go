@@ -168,6 +168,43 @@ func (c *Coordinator) RetryRun(ctx context.Context, taskID, runID platform.ID) (
 	return r, c.sch.UpdateTask(ctx, task)
 }
 
+func (c *Coordinator) BackfillTask(ctx context.Context, taskID platform.ID, start, stop int64) (*platform.Backfill, error) {
+	task, err := c.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := c.TaskService.BackfillTask(ctx, taskID, start, stop)
+	if err != nil {
+		return b, err
+	}
+
+	return b, c.sch.UpdateTask(ctx, task)
+}
+
+// CancelBackfill removes the queued runs of the backfill, then cancels its runs in progress.
+func (c *Coordinator) CancelBackfill(ctx context.Context, taskID, backfillID platform.ID) error {
+	if err := c.TaskService.CancelBackfill(ctx, taskID, backfillID); err != nil {
+		return err
+	}
+
+	runs, _, err := c.TaskService.FindRuns(ctx, platform.RunFilter{Task: taskID})
+	if err != nil {
+		return err
+	}
+
+	for _, r := range runs {
+		if r.BackfillID != backfillID || r.Status != backend.RunStarted.String() {
+			continue
+		}
+		// The run may have finished since it was listed.
+		if err := c.CancelRun(ctx, taskID, r.ID); err != nil && err != backend.ErrRunNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *Coordinator) ForceRun(ctx context.Context, taskID platform.ID, scheduledFor int64) (*platform.Run, error) {
 	task, err := c.TaskService.FindTaskByID(ctx, taskID)
 	if err != nil {
@@ -0,0 +1,91 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/task/options"
+)
+
+// DryRunResult is the outcome of the dry run of a task script.
+type DryRunResult struct {
+	// Options are the task options of the script.
+	Options options.Options
+
+	// Now is the time the script was executed for.
+	Now time.Time
+
+	// Tables are the results of the script, as annotated CSV.
+	// They include the tables the to() calls of the script would have written.
+	Tables string
+
+	Statistics flux.Statistics
+}
+
+// DryRun executes the task script for the organization, as a run scheduled for now would,
+// without writing the data of its to() calls.
+//
+// Only the to() function of the influxdata/influxdb package honors the dry run.
+// Other functions with side effects, such as sql.to() or http.post(), are executed
+// as in a run, so scripts using them are not safe to test.
+func DryRun(ctx context.Context, qs query.AsyncQueryService, auth *influxdb.Authorization, orgID influxdb.ID, script string, now time.Time) (*DryRunResult, error) {
+	opts, err := options.FromScript(script)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid task options",
+			Err:  err,
+		}
+	}
+
+	pkg, err := flux.Parse(script)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid flux script",
+			Err:  err,
+		}
+	}
+
+	req := &query.Request{
+		Authorization:  auth,
+		OrganizationID: orgID,
+		Compiler: lang.ASTCompiler{
+			AST: pkg,
+			Now: now,
+		},
+	}
+	q, err := qs.Query(query.ContextWithDryRun(icontext.SetAuthorizer(ctx, auth)), req)
+	if err != nil {
+		return nil, err
+	}
+
+	results := flux.NewResultIteratorFromQuery(q)
+	defer results.Release()
+
+	var buf bytes.Buffer
+	enc := csv.NewMultiResultEncoder(csv.DefaultEncoderConfig())
+	_, err = enc.Encode(&buf, results)
+	// Release the results and collect the statistics regardless of the error.
+	results.Release()
+	if err != nil {
+		return nil, err
+	}
+	if err := results.Err(); err != nil {
+		return nil, err
+	}
+
+	return &DryRunResult{
+		Options:    opts,
+		Now:        now,
+		Tables:     buf.String(),
+		Statistics: results.Statistics(),
+	}, nil
+}
@@ -0,0 +1,58 @@
+package executor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/lang"
+	platform "github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/mock"
+	"github.com/influxdata/influxdb/task/backend/executor"
+)
+
+func TestDryRun(t *testing.T) {
+	const script = `option task = {name: "dry run", every: 1h}
+from(bucket: "b") |> range(start: -1h) |> to(bucket: "c")`
+
+	auth := &platform.Authorization{ID: 1, OrgID: 2, UserID: 3}
+	now := time.Unix(3600, 0).UTC()
+
+	qs := &mock.AsyncQueryService{
+		QueryF: func(ctx context.Context, req *query.Request) (flux.Query, error) {
+			if !query.IsDryRun(ctx) {
+				t.Error("expected the query to be a dry run")
+			}
+			if a, err := icontext.GetAuthorizer(ctx); err != nil || a != auth {
+				t.Errorf("expected the authorizer of the context to be set, got %v (%v)", a, err)
+			}
+			if req.OrganizationID != 2 {
+				t.Errorf("unexpected organization %v", req.OrganizationID)
+			}
+			if c, ok := req.Compiler.(lang.ASTCompiler); !ok || !c.Now.Equal(now) {
+				t.Errorf("expected an AST compiler executing at %v, got %#v", now, req.Compiler)
+			}
+			q := mock.NewQuery()
+			q.Cancel()
+			return q, nil
+		},
+	}
+
+	res, err := executor.DryRun(context.Background(), qs, auth, 2, script, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Options.Name != "dry run" || res.Options.Every.String() != "1h" {
+		t.Fatalf("unexpected options %+v", res.Options)
+	}
+	if !res.Now.Equal(now) {
+		t.Fatalf("got now %v, exp %v", res.Now, now)
+	}
+
+	if _, err := executor.DryRun(context.Background(), qs, auth, 2, "option task = {name: 1}", now); platform.ErrorCode(err) != platform.EInvalid {
+		t.Fatalf("expected invalid options to be rejected, got %v", err)
+	}
+}
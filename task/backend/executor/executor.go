@@ -76,6 +76,7 @@ type syncRunPromise struct {
 	t      *influxdb.Task
 	ctx    context.Context
 	cancel context.CancelFunc
+	wc     *query.WriteCounter
 	logger *zap.Logger
 	logEnd func() // Called to log the end of the run operation.
 
@@ -89,6 +90,7 @@ var _ backend.RunPromise = (*syncRunPromise)(nil)
 
 func newSyncRunPromise(ctx context.Context, auth *influxdb.Authorization, qr backend.QueuedRun, e *queryServiceExecutor, t *influxdb.Task) *syncRunPromise {
 	ctx, cancel := context.WithCancel(ctx)
+	wc := &query.WriteCounter{}
 	opLogger := e.logger.With(zap.Stringer("task_id", qr.TaskID), zap.Stringer("run_id", qr.RunID))
 	log, logEnd := logger.NewOperation(opLogger, "Executing task", "execute")
 	rp := &syncRunPromise{
@@ -98,8 +100,9 @@ func newSyncRunPromise(ctx context.Context, auth *influxdb.Authorization, qr bac
 		t:      t,
 		logger: log,
 		logEnd: logEnd,
-		ctx:    ctx,
+		ctx:    query.ContextWithWriteCounter(ctx, wc),
 		cancel: cancel,
+		wc:     wc,
 		ready:  make(chan struct{}),
 	}
 
@@ -189,7 +192,8 @@ func (p *syncRunPromise) doQuery(wg *sync.WaitGroup) {
 	it.Release()
 
 	// Is it okay to assume it.Err will be set if the query context is canceled?
-	p.finish(&runResult{err: it.Err(), statistics: it.Statistics()}, nil)
+	err = it.Err()
+	p.finish(&runResult{err: err, retryable: backend.IsRetryableError(err), statistics: withRowsWritten(it.Statistics(), p.wc)}, nil)
 }
 
 func (p *syncRunPromise) cancelOnContextDone(wg *sync.WaitGroup) {
@@ -247,12 +251,13 @@ func (e *asyncQueryServiceExecutor) Execute(ctx context.Context, run backend.Que
 		},
 	}
 	// Only set the authorizer on the context where we need it here.
-	q, err := e.qs.Query(icontext.SetAuthorizer(ctx, auth), req)
+	wc := &query.WriteCounter{}
+	q, err := e.qs.Query(query.ContextWithWriteCounter(icontext.SetAuthorizer(ctx, auth), wc), req)
 	if err != nil {
 		return nil, err
 	}
 
-	return newAsyncRunPromise(run, q, e), nil
+	return newAsyncRunPromise(run, q, wc, e), nil
 }
 
 func (e *asyncQueryServiceExecutor) Wait() {
@@ -263,6 +268,7 @@ func (e *asyncQueryServiceExecutor) Wait() {
 type asyncRunPromise struct {
 	qr backend.QueuedRun
 	q  flux.Query
+	wc *query.WriteCounter
 
 	logger *zap.Logger
 	logEnd func() // Called to log the end of the run operation.
@@ -275,13 +281,14 @@ type asyncRunPromise struct {
 
 var _ backend.RunPromise = (*asyncRunPromise)(nil)
 
-func newAsyncRunPromise(qr backend.QueuedRun, q flux.Query, e *asyncQueryServiceExecutor) *asyncRunPromise {
+func newAsyncRunPromise(qr backend.QueuedRun, q flux.Query, wc *query.WriteCounter, e *asyncQueryServiceExecutor) *asyncRunPromise {
 	opLogger := e.logger.With(zap.Stringer("task_id", qr.TaskID), zap.Stringer("run_id", qr.RunID))
 	log, logEnd := logger.NewOperation(opLogger, "Executing task", "execute")
 
 	p := &asyncRunPromise{
 		qr:    qr,
 		q:     q,
+		wc:    wc,
 		ready: make(chan struct{}),
 
 		logger: log,
@@ -347,7 +354,8 @@ SelectLoop:
 
 	if p.q.Err() != nil {
 		// Something went wrong with the flux. Set the error in the run result.
-		rr := &runResult{err: p.q.Err()}
+		err := p.q.Err()
+		rr := &runResult{err: err, retryable: backend.IsRetryableError(err)}
 		p.finish(rr, nil)
 		return
 	}
@@ -355,7 +363,7 @@ SelectLoop:
 	// Otherwise, query was successful.
 	// Must call query.Done before collecting statistics. It's safe to call multiple times.
 	p.q.Done()
-	p.finish(&runResult{statistics: p.q.Statistics()}, nil)
+	p.finish(&runResult{statistics: withRowsWritten(p.q.Statistics(), p.wc)}, nil)
 }
 
 func (p *asyncRunPromise) finish(res *runResult, err error) {
@@ -387,6 +395,18 @@ func (rr *runResult) Err() error                  { return rr.err }
 func (rr *runResult) IsRetryable() bool           { return rr.retryable }
 func (rr *runResult) Statistics() flux.Statistics { return rr.statistics }
 
+// withRowsWritten returns the statistics with the number of rows written by the query,
+// under the query.RowsWrittenMetadataKey metadata key.
+func withRowsWritten(stats flux.Statistics, wc *query.WriteCounter) flux.Statistics {
+	md := make(flux.Metadata, len(stats.Metadata)+1)
+	for k, v := range stats.Metadata {
+		md[k] = v
+	}
+	md[query.RowsWrittenMetadataKey] = []interface{}{wc.Rows()}
+	stats.Metadata = md
+	return stats
+}
+
 // exhaustResultIterators drains all the iterators from a flux query Result.
 func exhaustResultIterators(res flux.Result) error {
 	return res.Tables().Do(func(tbl flux.Table) error {
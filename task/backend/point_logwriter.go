@@ -19,6 +19,13 @@ const (
 	statusField       = "status"
 	logField          = "logs"
 
+	// Fields of the statistics of a successful run.
+	compileDurationField = "compileDuration"
+	executeDurationField = "executeDuration"
+	maxAllocatedField    = "maxAllocated"
+	pointsReadField      = "pointsRead"
+	rowsWrittenField     = "rowsWritten"
+
 	taskIDTag = "taskID"
 
 	// Fixed system bucket ID for task and run logs.
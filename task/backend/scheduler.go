@@ -13,6 +13,7 @@ import (
 	"github.com/influxdata/flux"
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/kit/tracing"
+	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/task/options"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -84,6 +85,16 @@ type RunResult interface {
 	Statistics() flux.Statistics
 }
 
+// IsRetryableError returns true if err is a transient failure of a run, such as
+// the query service being unavailable or rejecting the query because of its limits.
+func IsRetryableError(err error) bool {
+	switch platform.ErrorCode(err) {
+	case platform.EUnavailable, platform.ETooManyRequests:
+		return true
+	}
+	return false
+}
+
 // Scheduler accepts tasks and handles their scheduling.
 //
 // TODO(mr): right now the methods on Scheduler are synchronous.
@@ -149,6 +160,16 @@ func WithLogger(logger *zap.Logger) TickSchedulerOption {
 	}
 }
 
+// WithRetryBackoff sets the delay before retrying a failed run, which doubles
+// after each failed attempt up to max.
+// If not set, the delay starts at one second and is at most one minute.
+func WithRetryBackoff(initial, max time.Duration) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.retryBackoff = initial
+		s.maxRetryBackoff = max
+	}
+}
+
 // NewScheduler returns a new scheduler with the given desired state and the given now UTC timestamp.
 func NewScheduler(taskControlService TaskControlService, executor Executor, now int64, opts ...TickSchedulerOption) *TickScheduler {
 	o := &TickScheduler{
@@ -159,6 +180,8 @@ func NewScheduler(taskControlService TaskControlService, executor Executor, now
 		logger:             zap.NewNop(),
 		wg:                 &sync.WaitGroup{},
 		metrics:            newSchedulerMetrics(),
+		retryBackoff:       time.Second,
+		maxRetryBackoff:    time.Minute,
 	}
 
 	for _, opt := range opts {
@@ -177,6 +200,9 @@ type TickScheduler struct {
 
 	metrics *schedulerMetrics
 
+	// Delay before retrying a failed run, doubling after each attempt up to maxRetryBackoff.
+	retryBackoff, maxRetryBackoff time.Duration
+
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     *sync.WaitGroup
@@ -390,6 +416,47 @@ func (s *TickScheduler) ReleaseTask(taskID platform.ID) error {
 	return nil
 }
 
+// runTriggeredTasks starts the runs of the tasks triggered by the task,
+// which were queued when its run finished successfully.
+func (s *TickScheduler) runTriggeredTasks(taskID platform.ID) {
+	s.schedulerMu.Lock()
+	defer s.schedulerMu.Unlock()
+
+	if s.ctx == nil {
+		return
+	}
+
+	select {
+	case <-s.ctx.Done():
+		return
+	default:
+	}
+
+	for _, ts := range s.taskSchedulers {
+		if ts.task.TriggerTaskID != taskID {
+			continue
+		}
+
+		ts.nextDueMu.RLock()
+		authCtx := ts.authCtx
+		ts.nextDueMu.RUnlock()
+
+		runs, err := s.taskControlService.ManualRuns(authCtx, ts.task.ID)
+		if err != nil {
+			ts.logger.Info("Failed to find runs of triggered task", zap.Error(err))
+			continue
+		}
+		if len(runs) == 0 {
+			continue
+		}
+
+		ts.nextDueMu.Lock()
+		ts.hasQueue = true
+		ts.nextDueMu.Unlock()
+		ts.Work()
+	}
+}
+
 func (s *TickScheduler) PrometheusCollectors() []prometheus.Collector {
 	return s.metrics.PrometheusCollectors()
 }
@@ -407,6 +474,9 @@ type taskScheduler struct {
 	// Task we are scheduling for.
 	task *platform.Task
 
+	// Scheduler owning this taskScheduler, which runs the tasks triggered by the task.
+	scheduler *TickScheduler
+
 	// Authorization context for using the TaskControlService
 	authCtx context.Context
 
@@ -423,6 +493,9 @@ type taskScheduler struct {
 
 	metrics *schedulerMetrics
 
+	// Delay before retrying a failed run, doubling after each attempt up to maxRetryBackoff.
+	retryBackoff, maxRetryBackoff time.Duration
+
 	nextDueMu     sync.RWMutex // Protects following fields.
 	nextDue       int64        // Unix timestamp of next due.
 	nextDueSource int64        // Run time that produced nextDue.
@@ -457,18 +530,21 @@ func newTaskScheduler(
 
 	ctx, cancel := context.WithCancel(ctx)
 	ts := &taskScheduler{
-		now:           &s.now,
-		task:          task,
-		authCtx:       authCtx,
-		cancel:        cancel,
-		wg:            wg,
-		runners:       make([]*runner, maxC),
-		running:       make(map[platform.ID]runCtx, maxC),
-		logger:        s.logger.With(zap.String("task_id", task.ID.String())),
-		metrics:       s.metrics,
-		nextDue:       firstDue,
-		nextDueSource: math.MinInt64,
-		hasQueue:      len(runs) > 0,
+		now:             &s.now,
+		task:            task,
+		scheduler:       s,
+		authCtx:         authCtx,
+		cancel:          cancel,
+		wg:              wg,
+		runners:         make([]*runner, maxC),
+		running:         make(map[platform.ID]runCtx, maxC),
+		logger:          s.logger.With(zap.String("task_id", task.ID.String())),
+		metrics:         s.metrics,
+		retryBackoff:    s.retryBackoff,
+		maxRetryBackoff: s.maxRetryBackoff,
+		nextDue:         firstDue,
+		nextDueSource:   math.MinInt64,
+		hasQueue:        len(runs) > 0,
 	}
 
 	for i := range ts.runners {
@@ -668,8 +744,10 @@ func (r *runner) startFromWorking(now int64) {
 
 func (r *runner) clearRunning(id platform.ID) {
 	r.ts.runningMu.Lock()
-	r.ts.running[id].CancelFunc() // cleanup
-	delete(r.ts.running, id)
+	if rc, ok := r.ts.running[id]; ok {
+		rc.CancelFunc() // cleanup
+		delete(r.ts.running, id)
+	}
 	r.ts.runningMu.Unlock()
 }
 
@@ -688,6 +766,7 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 
 	defer r.wg.Done()
 	errMsg := "Failed to finish run"
+	succeeded := false
 	defer func() {
 		if _, err := r.taskControlService.FinishRun(r.ctx, qr.TaskID, qr.RunID); err != nil {
 			// TODO(mr): Need to figure out how to reconcile this error, on the next run, if it happens.
@@ -695,19 +774,94 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 			runLogger.Error(errMsg, zap.Error(err))
 
 			atomic.StoreUint32(r.state, runnerIdle)
+			return
+		}
+		if succeeded {
+			// Not waiting for the scheduler, which may be stopping and waiting for this run.
+			go r.ts.scheduler.runTriggeredTasks(qr.TaskID)
 		}
 	}()
+	defer r.clearRunning(qr.RunID)
 
 	sp, spCtx := tracing.StartSpanFromContext(ctx)
 	defer sp.Finish()
 
-	rp, err := r.executor.Execute(spCtx, qr)
+	var rr RunResult
+	for attempt, maxAttempts := 1, 1; ; attempt++ {
+		var (
+			stage     string
+			retryable bool
+			err       error
+		)
+		rr, stage, retryable, err = r.execute(spCtx, qr, runLogger)
+		if err == ErrRunCanceled {
+			r.updateRunState(qr, RunCanceled, runLogger)
+			errMsg = "Waiting for execution result failed, " + errMsg
+			// Move on to the next execution, for a canceled run.
+			r.startFromWorking(atomic.LoadInt64(r.ts.now))
+			return
+		}
+		if err == nil {
+			break
+		}
+
+		if retryable && attempt == 1 {
+			maxAttempts = r.maxAttempts(runLogger)
+		}
+		if !retryable || attempt >= maxAttempts {
+			if attempt > 1 {
+				stage = fmt.Sprintf("%s after %d attempts", stage, attempt)
+			}
+			errMsg = stage + ", " + errMsg
+			r.fail(qr, runLogger, stage, err)
+			return
+		}
+
+		delay := r.retryDelay(attempt)
+		runLogger.Info("Retrying run", zap.Int("attempt", attempt+1), zap.Duration("delay", delay), zap.Error(err))
+		r.addRunLog(qr, runLogger, fmt.Sprintf("%s: %s; retrying in %s (attempt %d of %d)", stage, err, delay, attempt+1, maxAttempts))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			r.updateRunState(qr, RunCanceled, runLogger)
+			r.startFromWorking(atomic.LoadInt64(r.ts.now))
+			return
+		case <-r.ctx.Done():
+			timer.Stop()
+			r.updateRunState(qr, RunCanceled, runLogger)
+			return
+		}
+	}
+
+	stats := rr.Statistics()
+
+	authCtx := r.authContext()
+	b, err := json.Marshal(stats)
+	if err == nil {
+		r.taskControlService.AddRunLog(authCtx, r.task.ID, qr.RunID, time.Now(), string(b))
+	}
+	if err := r.taskControlService.UpdateRunStatistics(authCtx, r.task.ID, qr.RunID, runStatistics(stats)); err != nil {
+		runLogger.Info("Failed to update run statistics", zap.Error(err))
+	}
+	r.updateRunState(qr, RunSuccess, runLogger)
+	succeeded = true
+	runLogger.Info("Execution succeeded")
+
+	// Check again if there is a new run available, without returning to idle state.
+	r.startFromWorking(atomic.LoadInt64(r.ts.now))
+}
+
+// execute makes a single attempt at executing qr, and waits for its result.
+// If the attempt failed, execute returns the stage at which it failed and whether
+// the failure is transient, such that the run may be retried.
+func (r *runner) execute(ctx context.Context, qr QueuedRun, runLogger *zap.Logger) (rr RunResult, stage string, retryable bool, err error) {
+	rp, err := r.executor.Execute(ctx, qr)
 	if err != nil {
 		runLogger.Info("Failed to begin run execution", zap.Error(err))
-		errMsg = "Beginning run execution failed, " + errMsg
-		// TODO(mr): retry?
-		r.fail(qr, runLogger, "Run failed to begin execution", err)
-		return
+		return nil, "Run failed to begin execution", IsRetryableError(err), err
 	}
 
 	ready := make(chan struct{})
@@ -715,60 +869,81 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 		// If the runner's context is canceled, cancel the RunPromise.
 		select {
 		case <-ctx.Done():
-			r.clearRunning(qr.RunID)
 			rp.Cancel()
 		// Canceled context.
 		case <-r.ctx.Done():
-			r.clearRunning(qr.RunID)
 			rp.Cancel()
 		// Wait finished.
 		case <-ready:
-			r.clearRunning(qr.RunID)
 		}
 	}()
 
-	// TODO(mr): handle rr.IsRetryable().
-	rr, err := rp.Wait()
+	rr, err = rp.Wait()
 	close(ready)
 	if err != nil {
 		if err == ErrRunCanceled {
-			r.updateRunState(qr, RunCanceled, runLogger)
-			errMsg = "Waiting for execution result failed, " + errMsg
-			// Move on to the next execution, for a canceled run.
-			r.startFromWorking(atomic.LoadInt64(r.ts.now))
-			return
+			return nil, "", false, err
 		}
 
 		runLogger.Info("Failed to wait for execution result", zap.Error(err))
-
-		// TODO(mr): retry?
-		r.fail(qr, runLogger, "Waiting for execution result", err)
-		return
+		return nil, "Waiting for execution result", IsRetryableError(err), err
 	}
 	if err := rr.Err(); err != nil {
 		runLogger.Info("Run failed to execute", zap.Error(err))
-		errMsg = "Run failed to execute, " + errMsg
+		return rr, "Run failed to execute", rr.IsRetryable(), err
+	}
+	return rr, "", false, nil
+}
 
-		// TODO(mr): retry?
-		r.fail(qr, runLogger, "Run failed to execute", err)
-		return
+// maxAttempts returns the number of times a run of the task is attempted:
+// once, then up to the number of retries set by the retry option of the task.
+func (r *runner) maxAttempts(runLogger *zap.Logger) int {
+	opt, err := options.FromScript(r.task.Flux)
+	if err != nil {
+		runLogger.Info("Failed to read task options, not retrying", zap.Error(err))
+		return 1
 	}
+	if opt.Retry == nil || *opt.Retry < 1 {
+		return 1
+	}
+	return 1 + int(*opt.Retry)
+}
 
-	stats := rr.Statistics()
+// retryDelay returns the delay before the attempt after the given one.
+func (r *runner) retryDelay(attempt int) time.Duration {
+	delay := r.ts.retryBackoff
+	for i := 1; i < attempt && delay < r.ts.maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.ts.maxRetryBackoff {
+		delay = r.ts.maxRetryBackoff
+	}
+	return delay
+}
 
-	b, err := json.Marshal(stats)
-	if err == nil {
-		// authctx can be updated mid process
-		r.ts.nextDueMu.RLock()
-		authCtx := r.ts.authCtx
-		r.ts.nextDueMu.RUnlock()
-		r.taskControlService.AddRunLog(authCtx, r.task.ID, qr.RunID, time.Now(), string(b))
+// runStatistics returns the statistics of a run from the statistics of its query.
+func runStatistics(stats flux.Statistics) platform.RunStatistics {
+	return platform.RunStatistics{
+		CompileDuration: stats.CompileDuration,
+		ExecuteDuration: stats.ExecuteDuration,
+		MaxAllocated:    stats.MaxAllocated,
+		PointsRead:      query.SumMetadata(stats.Metadata, query.ScannedValuesMetadataKey),
+		RowsWritten:     query.SumMetadata(stats.Metadata, query.RowsWrittenMetadataKey),
 	}
-	r.updateRunState(qr, RunSuccess, runLogger)
-	runLogger.Info("Execution succeeded")
+}
 
-	// Check again if there is a new run available, without returning to idle state.
-	r.startFromWorking(atomic.LoadInt64(r.ts.now))
+// authContext returns the context authorizing the updates of the runs of the task.
+func (r *runner) authContext() context.Context {
+	// authctx can be updated mid process
+	r.ts.nextDueMu.RLock()
+	defer r.ts.nextDueMu.RUnlock()
+	return r.ts.authCtx
+}
+
+func (r *runner) addRunLog(qr QueuedRun, runLogger *zap.Logger, log string) {
+	if err := r.taskControlService.AddRunLog(r.authContext(), r.task.ID, qr.RunID, time.Now(), log); err != nil {
+		runLogger.Info("Failed to update run log", zap.Error(err))
+	}
 }
 
 func (r *runner) updateRunState(qr QueuedRun, s RunStatus, runLogger *zap.Logger) {
@@ -776,6 +776,145 @@ func TestScheduler_RunFailureCleanup(t *testing.T) {
 	}
 }
 
+func TestScheduler_RetryRun(t *testing.T) {
+	t.Parallel()
+
+	tcs := mock.NewTaskControlService()
+	e := mock.NewExecutor()
+	ll := newLogListener(tcs)
+	rl := newRunListener(ll)
+	s := backend.NewScheduler(rl, e, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithRetryBackoff(time.Millisecond, 2*time.Millisecond))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &platform.Task{
+		ID:              platform.ID(1),
+		Every:           "1s",
+		LatestCompleted: "1970-01-01T00:00:05Z",
+		Flux:            `option task = {name:"x", every:1m, retry: 3} from(bucket:"a") |> to(bucket:"b", org: "o")`,
+	}
+
+	tcs.SetTask(task)
+	if err := s.ClaimTask(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+
+	// nextAttempt waits for the run to be executed again, after prev failed.
+	nextAttempt := func(prev *mock.RunPromise) *mock.RunPromise {
+		t.Helper()
+		for i := 0; i < 50; i++ {
+			if rps := e.RunningFor(task.ID); len(rps) == 1 && rps[0] != prev {
+				return rps[0]
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatal("expected the run to be retried")
+		return nil
+	}
+	unavailable := &platform.Error{Code: platform.EUnavailable, Msg: "query service unavailable"}
+
+	// With retry: 3, a run is attempted up to 4 times.
+	// A run succeeding on its second attempt succeeds.
+	s.Tick(6)
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp := promises[0]
+	rp.Finish(mock.NewRunResult(unavailable, true), nil)
+	pollForRunLog(t, ll, task.ID, rp.Run().RunID, "Run failed to execute: "+unavailable.Error()+"; retrying in 1ms (attempt 2 of 4)")
+
+	rp = nextAttempt(rp)
+	rp.Finish(mock.NewRunResult(nil, false), nil)
+	pollForRunStatus(t, rl, task.ID, 1, 0, backend.RunSuccess.String())
+
+	// A run failing on each attempt fails, once the attempts are exhausted.
+	s.Tick(7)
+	promises, err = e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp = promises[0]
+	runID := rp.Run().RunID
+	for i := 0; i < 4; i++ {
+		if i > 0 {
+			rp = nextAttempt(rp)
+		}
+		rp.Finish(mock.NewRunResult(unavailable, true), nil)
+	}
+	pollForRunLog(t, ll, task.ID, runID, "Run failed to execute: "+unavailable.Error()+"; retrying in 1ms (attempt 2 of 4)")
+	pollForRunLog(t, ll, task.ID, runID, "Run failed to execute: "+unavailable.Error()+"; retrying in 2ms (attempt 3 of 4)")
+	pollForRunLog(t, ll, task.ID, runID, "Run failed to execute: "+unavailable.Error()+"; retrying in 2ms (attempt 4 of 4)")
+	pollForRunLog(t, ll, task.ID, runID, "Run failed to execute after 4 attempts: "+unavailable.Error())
+	pollForRunStatus(t, rl, task.ID, 2, 1, backend.RunFail.String())
+
+	// A run failing with an error that is not retryable is not retried.
+	s.Tick(8)
+	promises, err = e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp = promises[0]
+	rp.Finish(mock.NewRunResult(errors.New("boom"), false), nil)
+	pollForRunLog(t, ll, task.ID, rp.Run().RunID, "Run failed to execute: boom")
+	pollForRunStatus(t, rl, task.ID, 3, 2, backend.RunFail.String())
+
+	if n := tcs.TotalRunsCreatedForTask(task.ID); n != 3 {
+		t.Fatalf("expected 3 runs created, got %d", n)
+	}
+}
+
+func TestScheduler_RetryRun_Default(t *testing.T) {
+	t.Parallel()
+
+	tcs := mock.NewTaskControlService()
+	e := mock.NewExecutor()
+	ll := newLogListener(tcs)
+	rl := newRunListener(ll)
+	s := backend.NewScheduler(rl, e, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithRetryBackoff(time.Millisecond, 2*time.Millisecond))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	// The retry option defaults to 1, so a run is retried once.
+	task := &platform.Task{
+		ID:              platform.ID(1),
+		Every:           "1s",
+		LatestCompleted: "1970-01-01T00:00:05Z",
+		Flux:            `option task = {name:"x", every:1m} from(bucket:"a") |> to(bucket:"b", org: "o")`,
+	}
+
+	tcs.SetTask(task)
+	if err := s.ClaimTask(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+
+	unavailable := &platform.Error{Code: platform.EUnavailable, Msg: "query service unavailable"}
+
+	s.Tick(6)
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp := promises[0]
+	rp.Finish(mock.NewRunResult(unavailable, true), nil)
+	pollForRunLog(t, ll, task.ID, rp.Run().RunID, "Run failed to execute: "+unavailable.Error()+"; retrying in 1ms (attempt 2 of 2)")
+
+	first := rp
+	for i := 0; i < 50 && rp == first; i++ {
+		if rps := e.RunningFor(task.ID); len(rps) == 1 && rps[0] != first {
+			rp = rps[0]
+		} else {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if rp == first {
+		t.Fatal("expected the run to be retried")
+	}
+	rp.Finish(mock.NewRunResult(unavailable, true), nil)
+	pollForRunLog(t, ll, task.ID, rp.Run().RunID, "Run failed to execute after 2 attempts: "+unavailable.Error())
+	pollForRunStatus(t, rl, task.ID, 1, 0, backend.RunFail.String())
+}
+
 func TestScheduler_Metrics(t *testing.T) {
 	t.Parallel()
 
@@ -32,6 +32,9 @@ type TaskControlService interface {
 
 	// AddRunLog adds a log line to the run.
 	AddRunLog(ctx context.Context, taskID, runID influxdb.ID, when time.Time, log string) error
+
+	// UpdateRunStatistics sets the statistics of the query of the run.
+	UpdateRunStatistics(ctx context.Context, taskID, runID influxdb.ID, stats influxdb.RunStatistics) error
 }
 
 // TaskControlAdaptor creates a TaskControlService for the older TaskStore system.
@@ -243,3 +246,8 @@ func ToInfluxTask(t *StoreTask, m *StoreTaskMeta) (*influxdb.Task, error) {
 	}
 	return pt, nil
 }
+
+// UpdateRunStatistics is a no-op, as the older TaskStore system does not keep the statistics of the runs.
+func (tcs *taskControlAdaptor) UpdateRunStatistics(ctx context.Context, taskID, runID influxdb.ID, stats influxdb.RunStatistics) error {
+	return nil
+}
@@ -290,6 +290,19 @@ func (d *TaskControlService) AddRunLog(ctx context.Context, taskID, runID influx
 	return nil
 }
 
+// UpdateRunStatistics sets the statistics of the query of the run.
+func (d *TaskControlService) UpdateRunStatistics(ctx context.Context, taskID, runID influxdb.ID, stats influxdb.RunStatistics) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	run := d.runs[taskID][runID]
+	if run == nil {
+		panic("cannot set the statistics of a non existent run")
+	}
+	run.Statistics = &stats
+	return nil
+}
+
 func (d *TaskControlService) CreatedFor(taskID influxdb.ID) []backend.QueuedRun {
 	d.mu.Lock()
 	defer d.mu.Unlock()
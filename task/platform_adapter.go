@@ -128,6 +128,10 @@ func (p pAdapter) CreateTask(ctx context.Context, t platform.TaskCreate) (*platf
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
 
+	if t.TriggerTaskID.Valid() {
+		return nil, errTriggerNotSupported
+	}
+
 	auth, err := icontext.GetAuthorizer(ctx)
 	if err != nil {
 		return nil, err
@@ -211,6 +215,9 @@ func (p pAdapter) UpdateTask(ctx context.Context, id platform.ID, upd platform.T
 	if err != nil {
 		return nil, err
 	}
+	if upd.TriggerTaskID != nil {
+		return nil, errTriggerNotSupported
+	}
 	req := backend.UpdateTaskRequest{ID: id}
 	if upd.Flux != nil {
 		req.Script = *upd.Flux
@@ -367,6 +374,30 @@ func (p pAdapter) CancelRun(ctx context.Context, taskID, runID platform.ID) erro
 	return p.rc.CancelRun(ctx, taskID, runID)
 }
 
+// errBackfillNotSupported is returned for backfills, which the Store cannot list or cancel.
+var errBackfillNotSupported = &platform.Error{
+	Code: platform.EMethodNotAllowed,
+	Msg:  "backfills are not supported by the task store",
+}
+
+func (p pAdapter) BackfillTask(ctx context.Context, taskID platform.ID, start, stop int64) (*platform.Backfill, error) {
+	return nil, errBackfillNotSupported
+}
+
+func (p pAdapter) FindBackfills(ctx context.Context, taskID platform.ID) ([]*platform.Backfill, error) {
+	return nil, errBackfillNotSupported
+}
+
+func (p pAdapter) CancelBackfill(ctx context.Context, taskID, backfillID platform.ID) error {
+	return errBackfillNotSupported
+}
+
+// errTriggerNotSupported is returned for task triggers, which the Store cannot persist.
+var errTriggerNotSupported = &platform.Error{
+	Code: platform.EMethodNotAllowed,
+	Msg:  "task triggers are not supported by the task store",
+}
+
 var errTokenUnreadable = errors.New("token invalid or unreadable by the current user")
 
 // authorizationIDFromToken looks up the authorization ID from the given token,
@@ -35,6 +35,23 @@ func TestOptionsMarshal(t *testing.T) {
 	}
 }
 
+func TestTaskUpdate_TriggerTaskID(t *testing.T) {
+	id := platform.ID(1)
+	for _, exp := range []*platform.ID{nil, &id, new(platform.ID)} {
+		b, err := json.Marshal(platform.TaskUpdate{TriggerTaskID: exp})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var tu platform.TaskUpdate
+		if err := json.Unmarshal(b, &tu); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tu.TriggerTaskID, exp); diff != "" {
+			t.Fatalf("unexpected trigger task ID after round trip of %s -got/+exp\n%s", b, diff)
+		}
+	}
+}
+
 func TestOptionsEdit(t *testing.T) {
 	tu := &platform.TaskUpdate{}
 	tu.Options.Every = *(options.MustParseDuration("10s"))
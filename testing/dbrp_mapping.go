@@ -194,6 +194,49 @@ func CreateDBRPMapping(
 				},
 			},
 		},
+		{
+			name: "create default dbrpMapping replaces previous default",
+			fields: DBRPMappingFields{
+				DBRPMappings: []*platform.DBRPMapping{{
+					Cluster:         "cluster",
+					Database:        "database",
+					RetentionPolicy: "retention_policyA",
+					Default:         true,
+					OrganizationID:  MustIDBase16(dbrpOrg3ID),
+					BucketID:        MustIDBase16(dbrpBucketAID),
+				}},
+			},
+			args: args{
+				dbrpMapping: &platform.DBRPMapping{
+					Cluster:         "cluster",
+					Database:        "database",
+					RetentionPolicy: "retention_policyB",
+					Default:         true,
+					OrganizationID:  MustIDBase16(dbrpOrg3ID),
+					BucketID:        MustIDBase16(dbrpBucketBID),
+				},
+			},
+			wants: wants{
+				dbrpMappings: []*platform.DBRPMapping{
+					{
+						Cluster:         "cluster",
+						Database:        "database",
+						RetentionPolicy: "retention_policyA",
+						Default:         false,
+						OrganizationID:  MustIDBase16(dbrpOrg3ID),
+						BucketID:        MustIDBase16(dbrpBucketAID),
+					},
+					{
+						Cluster:         "cluster",
+						Database:        "database",
+						RetentionPolicy: "retention_policyB",
+						Default:         true,
+						OrganizationID:  MustIDBase16(dbrpOrg3ID),
+						BucketID:        MustIDBase16(dbrpBucketBID),
+					},
+				},
+			},
+		},
 		{
 			name: "error on create existing dbrpMapping",
 			fields: DBRPMappingFields{
@@ -217,7 +260,7 @@ func CreateDBRPMapping(
 				},
 			},
 			wants: wants{
-				err: errors.New("dbrp mapping already exists"),
+				err: platform.ErrDBRPAlreadyExists,
 				dbrpMappings: []*platform.DBRPMapping{
 					{
 						Cluster:         "cluster1",
@@ -243,9 +286,7 @@ func CreateDBRPMapping(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
-				}
+				ErrorsEqual(t, err, tt.wants.err)
 			}
 
 			dbrpMappings, _, err := s.FindMany(ctx, platform.DBRPMappingFilter{})
@@ -420,9 +461,7 @@ func FindDBRPMappings(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
-				}
+				ErrorsEqual(t, err, tt.wants.err)
 			}
 
 			if diff := cmp.Diff(dbrpMappings, tt.wants.dbrpMappings, dbrpMappingCmpOptions...); diff != "" {
@@ -512,7 +551,7 @@ func FindDBRPMappingByKey(
 				RetentionPolicy: "retention_policyA",
 			},
 			wants: wants{
-				err: errors.New("dbrp mapping not found"),
+				err: platform.ErrDBRPNotFound,
 			},
 		},
 	}
@@ -529,9 +568,7 @@ func FindDBRPMappingByKey(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
-				}
+				ErrorsEqual(t, err, tt.wants.err)
 			}
 
 			if diff := cmp.Diff(dbrpMapping, tt.wants.dbrpMapping, dbrpMappingCmpOptions...); diff != "" {
@@ -655,9 +692,7 @@ func FindDBRPMapping(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
-				}
+				ErrorsEqual(t, err, tt.wants.err)
 			}
 
 			if diff := cmp.Diff(dbrpMapping, tt.wants.dbrpMapping, dbrpMappingCmpOptions...); diff != "" {
@@ -785,9 +820,7 @@ func DeleteDBRPMapping(
 			}
 
 			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
-				}
+				ErrorsEqual(t, err, tt.wants.err)
 			}
 
 			filter := platform.DBRPMappingFilter{}
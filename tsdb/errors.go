@@ -21,6 +21,9 @@ type PartialWriteError struct {
 
 	// A sorted slice of series keys that were dropped.
 	DroppedKeys [][]byte
+
+	// The points that were dropped, along with the reason each was dropped.
+	DroppedPoints []DroppedPoint
 }
 
 func (e PartialWriteError) Error() string {
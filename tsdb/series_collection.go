@@ -21,9 +21,10 @@ type SeriesCollection struct {
 	SeriesIDs  []SeriesID
 
 	// Keeps track of invalid entries.
-	Dropped     uint64
-	DroppedKeys [][]byte
-	Reason      string
+	Dropped       uint64
+	DroppedKeys   [][]byte
+	DroppedPoints []DroppedPoint
+	Reason        string
 
 	// Used by the concurrent iterators to stage drops. Inefficient, but should be
 	// very infrequently used.
@@ -34,7 +35,13 @@ type SeriesCollection struct {
 type seriesCollectionState struct {
 	mu     sync.Mutex
 	reason string
-	index  map[int]struct{}
+	index  map[int]string
+}
+
+// DroppedPoint is a point which has been dropped from a SeriesCollection.
+type DroppedPoint struct {
+	Point  models.Point
+	Reason string
 }
 
 // NewSeriesCollection builds a SeriesCollection from a slice of points. It does some filtering
@@ -205,9 +212,30 @@ func (s *SeriesCollection) InvalidateAll(reason string) {
 	}
 	s.Dropped += uint64(len(s.Keys))
 	s.DroppedKeys = append(s.DroppedKeys, s.Keys...)
+	for _, pt := range s.Points {
+		s.DroppedPoints = append(s.DroppedPoints, DroppedPoint{Point: pt, Reason: reason})
+	}
 	s.Truncate(0)
 }
 
+// Drop marks the entry at index as invalid with the reason, including it in the set of
+// dropped keys and points. Only the first reason is kept as the reason of the collection.
+// The entry itself is not removed, which is left to the caller. It is not safe for
+// concurrent callers.
+func (s *SeriesCollection) Drop(index int, reason string) {
+	if s.Reason == "" {
+		s.Reason = reason
+	}
+	s.Dropped++
+
+	if index < len(s.Keys) {
+		s.DroppedKeys = append(s.DroppedKeys, s.Keys[index])
+	}
+	if index < len(s.Points) {
+		s.DroppedPoints = append(s.DroppedPoints, DroppedPoint{Point: s.Points[index], Reason: reason})
+	}
+}
+
 // ApplyConcurrentDrops will remove all of the dropped values during concurrent iteration. It should
 // not be called concurrently with any calls to Invalid.
 func (s *SeriesCollection) ApplyConcurrentDrops() {
@@ -216,15 +244,14 @@ func (s *SeriesCollection) ApplyConcurrentDrops() {
 		return
 	}
 
+	if s.Reason == "" {
+		s.Reason = state.reason
+	}
+
 	length, j := s.Length(), 0
 	for i := 0; i < length; i++ {
-		if _, ok := state.index[i]; ok {
-			s.Dropped++
-
-			if i < len(s.Keys) {
-				s.DroppedKeys = append(s.DroppedKeys, s.Keys[i])
-			}
-
+		if reason, ok := state.index[i]; ok {
+			s.Drop(i, reason)
 			continue
 		}
 
@@ -233,10 +260,6 @@ func (s *SeriesCollection) ApplyConcurrentDrops() {
 	}
 	s.Truncate(j)
 
-	if s.Reason == "" {
-		s.Reason = state.reason
-	}
-
 	// clear concurrent state
 	atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&s.state)), nil)
 }
@@ -266,9 +289,9 @@ func (s *SeriesCollection) invalidIndex(index int, reason string) {
 
 	state.mu.Lock()
 	if state.index == nil {
-		state.index = make(map[int]struct{})
+		state.index = make(map[int]string)
 	}
-	state.index[index] = struct{}{}
+	state.index[index] = reason
 	if state.reason == "" {
 		state.reason = reason
 	}
@@ -283,9 +306,10 @@ func (s *SeriesCollection) PartialWriteError() error {
 	}
 	droppedKeys := bytesutil.SortDedup(s.DroppedKeys)
 	return PartialWriteError{
-		Reason:      s.Reason,
-		Dropped:     len(droppedKeys),
-		DroppedKeys: droppedKeys,
+		Reason:        s.Reason,
+		Dropped:       len(droppedKeys),
+		DroppedKeys:   droppedKeys,
+		DroppedPoints: s.DroppedPoints,
 	}
 }
 
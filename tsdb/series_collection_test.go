@@ -146,4 +146,23 @@ func TestSeriesCollection(t *testing.T) {
 			DroppedKeys: bs("ka", "kc"),
 		})
 	})
+
+	t.Run("Drop", func(t *testing.T) {
+		pa := models.MustNewPoint("a", models.Tags{}, models.Fields{"f": 1.0}, time.Unix(0, 0))
+		pb := models.MustNewPoint("b", models.Tags{}, models.Fields{"f": 1.0}, time.Unix(0, 0))
+		collection := NewSeriesCollection([]models.Point{pa, pb})
+
+		// drop the first entry and keep the second
+		collection.Drop(0, "first reason")
+		collection.Copy(0, 1)
+		collection.Truncate(1)
+
+		assertEqual(t, "length", collection.Length(), 1)
+		assertEqual(t, "error", collection.PartialWriteError(), PartialWriteError{
+			Reason:        "first reason",
+			Dropped:       1,
+			DroppedKeys:   bs("a"),
+			DroppedPoints: []DroppedPoint{{Point: pa, Reason: "first reason"}},
+		})
+	})
 }
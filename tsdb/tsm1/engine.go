@@ -1361,6 +1361,24 @@ var (
 
 func BlockTypeToInfluxQLDataType(typ byte) influxql.DataType { return blockToFieldType[typ&7] }
 
+// BlockTypeToFieldType returns the models.FieldType of the values stored in a block of type typ.
+func BlockTypeToFieldType(typ byte) models.FieldType {
+	switch typ & 7 {
+	case BlockFloat64:
+		return models.Float
+	case BlockInteger:
+		return models.Integer
+	case BlockBoolean:
+		return models.Boolean
+	case BlockString:
+		return models.String
+	case BlockUnsigned:
+		return models.Unsigned
+	default:
+		return models.Empty
+	}
+}
+
 // SeriesAndFieldFromCompositeKey returns the series key and the field key extracted from the composite key.
 func SeriesAndFieldFromCompositeKey(key []byte) ([]byte, []byte) {
 	sep := bytes.Index(key, KeyFieldSeparatorBytes)
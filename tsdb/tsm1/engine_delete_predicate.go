@@ -0,0 +1,127 @@
+package tsm1
+
+import (
+	"math"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/bytesutil"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxql"
+)
+
+// DeleteBucketRangePredicate removes the TSM data belonging to the series of a bucket
+// matching the provided predicate, within the time range [min, max]. Series with no
+// data left after the delete are removed from the index and series file.
+//
+// A nil predicate matches every series in the bucket, which is the same as calling
+// DeleteBucketRange.
+func (e *Engine) DeleteBucketRangePredicate(name []byte, min, max int64, pred influxql.Expr) error {
+	if pred == nil {
+		return e.DeleteBucketRange(name, min, max)
+	}
+
+	// Ensure that the index does not compact away the measurement or series we're
+	// going to delete before we're done with them.
+	e.index.DisableCompactions()
+	defer e.index.EnableCompactions()
+	e.index.Wait()
+
+	// Disable and abort running level compactions so that tombstones added to
+	// existing tsm files don't get removed. See DeleteBucketRange for details.
+	e.disableLevelCompactions(true)
+	defer e.enableLevelCompactions(true)
+
+	e.sfile.DisableCompactions()
+	defer e.sfile.EnableCompactions()
+
+	// Min and max time in the engine are slightly different from the query language values.
+	if min == influxql.MinTime {
+		min = math.MinInt64
+	}
+	if max == influxql.MaxTime {
+		max = math.MaxInt64
+	}
+
+	// Resolve the series matching the predicate. The TSI index and Series File
+	// do not store series data in escaped form.
+	itr, err := e.index.MeasurementSeriesByExprIterator(models.UnescapeMeasurement(name), pred)
+	if err != nil {
+		return err
+	} else if itr == nil {
+		return nil
+	}
+	defer itr.Close()
+
+	var (
+		keys [][]byte
+		ids  = make(map[string]tsdb.SeriesID)
+	)
+	for {
+		elem, err := itr.Next()
+		if err != nil {
+			return err
+		} else if elem.SeriesID.IsZero() {
+			break
+		}
+
+		skey := e.sfile.SeriesKey(elem.SeriesID)
+		if len(skey) == 0 {
+			continue
+		}
+
+		// The field key is stored as the last tag of the series, so the
+		// TSM key can be rebuilt entirely from the series key.
+		sname, stags := tsdb.ParseSeriesKey(skey)
+		key := AppendSeriesFieldKeyBytes(nil, models.MakeKey(sname, stags), stags.Get(models.FieldKeyTagKeyBytes))
+		keys = append(keys, key)
+		ids[string(key)] = elem.SeriesID
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	// FileStore.DeleteRange expects the keys to be sorted.
+	bytesutil.Sort(keys)
+
+	// Write tombstones for the matching keys and purge them from the cache.
+	if err := e.FileStore.DeleteRange(keys, min, max); err != nil {
+		return err
+	}
+	e.Cache.DeleteRange(keys, min, max)
+
+	// Any key that is no longer present in either a TSM file or the cache has
+	// no data left, so the series can be removed from the index.
+	possiblyDead := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if len(e.Cache.Values(key)) == 0 {
+			possiblyDead[string(key)] = struct{}{}
+		}
+	}
+
+	e.FileStore.ForEachFile(func(f TSMFile) bool {
+		for key := range possiblyDead {
+			if f.Contains([]byte(key)) {
+				delete(possiblyDead, key)
+			}
+		}
+		return len(possiblyDead) > 0
+	})
+
+	// TODO(jeff): as with DeleteBucketRange, removal from the index and series file
+	// is not atomic with the removal of the data from the tsm files.
+	for key := range possiblyDead {
+		skey, _ := SeriesAndFieldFromCompositeKey([]byte(key))
+		sid := ids[key]
+
+		if err := e.index.DropSeries(sid, skey, true); err != nil {
+			return err
+		}
+
+		if err := e.sfile.DeleteSeriesID(sid); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package tsm1_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxql"
+)
+
+func TestEngine_DeleteBucketRangePredicate(t *testing.T) {
+	// Create a few points.
+	p1 := MustParsePointString("cpu,host=A value=1.1 1", "mm0")
+	p2 := MustParsePointString("cpu,host=A value=1.2 2", "mm0")
+	p3 := MustParsePointString("cpu,host=B value=1.3 3", "mm0")
+	p4 := MustParsePointString("cpu,host=B value=1.4 4", "mm0")
+	p5 := MustParsePointString("mem,host=A value=1.5 5", "mm0")
+	p6 := MustParsePointString("cpu,host=A value=1.6 6", "mm1")
+
+	e, err := NewEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	if err := e.writePoints(p1, p2, p3, p4, p5, p6); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	if err := e.WriteSnapshot(context.Background()); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	}
+
+	// Delete part of the data for host A of the cpu measurement.
+	pred := &influxql.BinaryExpr{
+		Op: influxql.AND,
+		LHS: &influxql.BinaryExpr{
+			Op:  influxql.EQ,
+			LHS: &influxql.VarRef{Val: models.MeasurementTagKey, Type: influxql.Tag},
+			RHS: &influxql.StringLiteral{Val: "cpu"},
+		},
+		RHS: influxql.MustParseExpr(`host = 'A'`),
+	}
+	if err := e.DeleteBucketRangePredicate([]byte("mm0"), 0, 1, pred); err != nil {
+		t.Fatalf("failed to delete series: %v", err)
+	}
+
+	keys := e.FileStore.Keys()
+	exp := map[string]byte{
+		"mm0,\x00=cpu,host=A,\xff=value#!~#value": 0,
+		"mm0,\x00=cpu,host=B,\xff=value#!~#value": 0,
+		"mm0,\x00=mem,host=A,\xff=value#!~#value": 0,
+		"mm1,\x00=cpu,host=A,\xff=value#!~#value": 0,
+	}
+	if !reflect.DeepEqual(keys, exp) {
+		t.Fatalf("unexpected series in file store: %v != %v", keys, exp)
+	}
+
+	if got, exp := e.SeriesN(), int64(4); got != exp {
+		t.Fatalf("series count mismatch: got %d, exp %d", got, exp)
+	}
+
+	// Deleting the remaining data should remove the series from the index,
+	// while leaving the other series in the bucket untouched.
+	if err := e.DeleteBucketRangePredicate([]byte("mm0"), 0, 9, pred); err != nil {
+		t.Fatalf("failed to delete series: %v", err)
+	}
+
+	keys = e.FileStore.Keys()
+	exp = map[string]byte{
+		"mm0,\x00=cpu,host=B,\xff=value#!~#value": 0,
+		"mm0,\x00=mem,host=A,\xff=value#!~#value": 0,
+		"mm1,\x00=cpu,host=A,\xff=value#!~#value": 0,
+	}
+	if !reflect.DeepEqual(keys, exp) {
+		t.Fatalf("unexpected series in file store: %v != %v", keys, exp)
+	}
+
+	if got, exp := e.SeriesN(), int64(3); got != exp {
+		t.Fatalf("series count mismatch: got %d, exp %d", got, exp)
+	}
+}
+
+func TestEngine_DeleteBucketRangePredicate_Cache(t *testing.T) {
+	p1 := MustParsePointString("cpu,host=A value=1.1 1", "mm0")
+	p2 := MustParsePointString("cpu,host=B value=1.2 2", "mm0")
+
+	e, err := NewEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	if err := e.writePoints(p1, p2); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	pred := influxql.MustParseExpr(`host = 'B'`)
+	if err := e.DeleteBucketRangePredicate([]byte("mm0"), 0, 9, pred); err != nil {
+		t.Fatalf("failed to delete series: %v", err)
+	}
+
+	if got, exp := e.Cache.Keys(), 1; len(got) != exp {
+		t.Fatalf("cache key count mismatch: got %d, exp %d", len(got), exp)
+	}
+
+	if got, exp := e.SeriesN(), int64(1); got != exp {
+		t.Fatalf("series count mismatch: got %d, exp %d", got, exp)
+	}
+}